@@ -0,0 +1,69 @@
+package backfill
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/serve"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db/schema"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/poller"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+)
+
+const (
+	CmdFrom = "from"
+	CmdTo   = "to"
+)
+
+var Command = &cobra.Command{
+	Use:   "backfill",
+	Short: "Refetch and insert heights with missing transactions",
+	Long:  "Refetch heights with missing transactions (see `verify gaps`) within the given range, insert the missing blocks and transactions, and extract the inserted transactions if the extractor has passed their heights.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := cmd.Flags().GetInt64(CmdFrom)
+		if err != nil {
+			return err
+		}
+		to, err := cmd.Flags().GetInt64(CmdTo)
+		if err != nil {
+			return err
+		}
+		if from <= 0 || to < from {
+			return fmt.Errorf("invalid height range [%d, %d]", from, to)
+		}
+		pool, err := db.GetConnPoolFromCmdArgs(cmd)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize database connection pool", "error", err)
+		}
+		conn, err := db.AcquireFromPool(pool)
+		if err != nil {
+			logger.L.Panicw("Cannot acquire connection from database connection pool", "error", err)
+		}
+		err = schema.InitDB(conn)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize database", "error", err)
+		}
+		conn.Release()
+
+		source, closeSource, err := serve.NewBlockSourceFromCmd(cmd)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize block source", "error", err)
+		}
+		defer closeSource()
+
+		return poller.Backfill(cmd.Context(), pool, source, from, to, extractor.Extractors)
+	},
+}
+
+func init() {
+	Command.PersistentFlags().Int64(CmdFrom, 0, "first height to backfill")
+	Command.PersistentFlags().Int64(CmdTo, 0, "last height to backfill")
+	_ = Command.MarkPersistentFlagRequired(CmdFrom)
+	_ = Command.MarkPersistentFlagRequired(CmdTo)
+	serve.ConfigBlockSourceCmd(Command)
+	rest.ConfigCmd(Command)
+}
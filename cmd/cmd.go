@@ -1,11 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"os/signal"
+	"syscall"
+
 	"github.com/spf13/cobra"
 
+	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/backfill"
+	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/failures"
 	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/importdb"
 	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/migrate"
+	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/reextract"
 	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/serve"
+	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/verify"
 	"github.com/likecoin/likecoin-chain-tx-indexer/db"
 	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
 )
@@ -19,7 +27,16 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// commands should stop gracefully when ctx is done, i.e. finish or discard the current database batch and drain HTTP requests
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		logger.L.Info("Received shutdown signal, stopping gracefully")
+		// restore default signal handling, so a second signal terminates immediately
+		stop()
+	}()
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		logger.L.Fatalw("Root command execution failed", "error", err)
 	}
 }
@@ -31,5 +48,9 @@ func init() {
 		importdb.Command,
 		serve.Command,
 		migrate.MigrateCommand,
+		verify.Command,
+		backfill.Command,
+		failures.Command,
+		reextract.Command,
 	)
 }
@@ -0,0 +1,110 @@
+package failures
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/spf13/cobra"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db/schema"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+const (
+	CmdResolved  = "resolved"
+	CmdTxHash    = "tx-hash"
+	CmdProcessor = "processor"
+	CmdLimit     = "limit"
+	CmdKey       = "key"
+	CmdId        = "id"
+)
+
+var Command = &cobra.Command{
+	Use:   "extract-failures",
+	Short: "Manage transactions failed in extraction",
+}
+
+var ListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List extract failures",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		q := db.QueryExtractFailuresRequest{}
+		p := db.PageRequest{}
+		var err error
+		if q.Resolved, err = cmd.Flags().GetBool(CmdResolved); err != nil {
+			return err
+		}
+		if q.TxHash, err = cmd.Flags().GetString(CmdTxHash); err != nil {
+			return err
+		}
+		if q.Processor, err = cmd.Flags().GetString(CmdProcessor); err != nil {
+			return err
+		}
+		if p.Limit, err = cmd.Flags().GetInt(CmdLimit); err != nil {
+			return err
+		}
+		if p.Key, err = cmd.Flags().GetUint64(CmdKey); err != nil {
+			return err
+		}
+		conn := acquireConn(cmd)
+		defer conn.Release()
+		res, err := db.GetExtractFailures(conn, q, p)
+		if err != nil {
+			return err
+		}
+		output, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+var RetryCommand = &cobra.Command{
+	Use:   "retry",
+	Short: "Retry unresolved extract failures, e.g. after the processor is fixed",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := cmd.Flags().GetInt64Slice(CmdId)
+		if err != nil {
+			return err
+		}
+		conn := acquireConn(cmd)
+		defer conn.Release()
+		res, err := extractor.RetryExtractFailures(conn, ids)
+		if err != nil {
+			return err
+		}
+		logger.L.Infow("Retried extract failures", "resolved", res.Resolved, "failed", res.Failed)
+		return nil
+	},
+}
+
+func acquireConn(cmd *cobra.Command) *pgxpool.Conn {
+	pool, err := db.GetConnPoolFromCmdArgs(cmd)
+	if err != nil {
+		logger.L.Panicw("Cannot initialize database connection pool", "error", err)
+	}
+	conn, err := db.AcquireFromPool(pool)
+	if err != nil {
+		logger.L.Panicw("Cannot acquire connection from database connection pool", "error", err)
+	}
+	err = schema.InitDB(conn)
+	if err != nil {
+		logger.L.Panicw("Cannot initialize database", "error", err)
+	}
+	return conn
+}
+
+func init() {
+	Command.AddCommand(ListCommand, RetryCommand)
+	ListCommand.PersistentFlags().Bool(CmdResolved, false, "list resolved failures instead of unresolved ones")
+	ListCommand.PersistentFlags().String(CmdTxHash, "", "filter by transaction hash")
+	ListCommand.PersistentFlags().String(CmdProcessor, "", "filter by processor name, e.g. extractor.sendNft")
+	ListCommand.PersistentFlags().Int(CmdLimit, 100, "maximum number of failures to list")
+	ListCommand.PersistentFlags().Uint64(CmdKey, 0, "list failures after this id, i.e. next_key of the previous page")
+	RetryCommand.PersistentFlags().Int64Slice(CmdId, nil, "ids of the failures to retry, retry all unresolved failures if not given")
+}
@@ -0,0 +1,38 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db/schema/parallel"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+var MigrationHeightTxHashCommand = &cobra.Command{
+	Use:   "height-tx-hash",
+	Short: "Setup height and tx_hash columns in iscn, nft_class, nft, nft_income and nft_marketplace tables",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		batchSize, err := cmd.Flags().GetUint64(CmdBatchSize)
+		if err != nil {
+			return err
+		}
+		pool, err := db.GetConnPoolFromCmdArgs(cmd)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize database connection pool", "error", err)
+		}
+		conn, err := db.AcquireFromPool(pool)
+		if err != nil {
+			logger.L.Panicw("Cannot acquire connection from database connection pool", "error", err)
+		}
+		defer conn.Release()
+		return parallel.MigrateHeightTxHash(conn, batchSize)
+	},
+}
+
+func init() {
+	MigrationHeightTxHashCommand.PersistentFlags().Uint64(
+		CmdBatchSize,
+		1000,
+		"number of ids in each table to scan each time",
+	)
+}
@@ -22,5 +22,7 @@ func init() {
 		MigrationNftEventMemoCommand,
 		MigrationNftIncomeCommand,
 		MigrationNftEventIscnOwnerCommand,
+		MigrationHeightTxHashCommand,
+		MigrationOwnerHeightCommand,
 	)
 }
@@ -0,0 +1,73 @@
+package reextract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db/schema"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+const (
+	CmdFrom   = "from"
+	CmdTo     = "to"
+	CmdDomain = "domain"
+)
+
+var Command = &cobra.Command{
+	Use:   "reextract",
+	Short: "Re-extract the data of the given domains from the stored transactions",
+	Long: fmt.Sprintf(
+		"Run the extractor again on the transactions within the given range for the given domains (%s), replacing the extracted rows. "+
+			"It runs in batches and can run alongside the poller, an interrupted run resumes when started again with the same arguments.",
+		strings.Join(extractor.Domains(), ", "),
+	),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := cmd.Flags().GetInt64(CmdFrom)
+		if err != nil {
+			return err
+		}
+		to, err := cmd.Flags().GetInt64(CmdTo)
+		if err != nil {
+			return err
+		}
+		if from <= 0 || to < from {
+			return fmt.Errorf("invalid height range [%d, %d]", from, to)
+		}
+		domains, err := cmd.Flags().GetStringSlice(CmdDomain)
+		if err != nil {
+			return err
+		}
+		if len(domains) == 0 {
+			return fmt.Errorf("no domain is given, expect some of %s", strings.Join(extractor.Domains(), ","))
+		}
+		pool, err := db.GetConnPoolFromCmdArgs(cmd)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize database connection pool", "error", err)
+		}
+		conn, err := db.AcquireFromPool(pool)
+		if err != nil {
+			logger.L.Panicw("Cannot acquire connection from database connection pool", "error", err)
+		}
+		defer conn.Release()
+		err = schema.InitDB(conn)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize database", "error", err)
+		}
+
+		return extractor.Reextract(cmd.Context(), conn, from, to, domains)
+	},
+}
+
+func init() {
+	Command.PersistentFlags().Int64(CmdFrom, 0, "first height to re-extract")
+	Command.PersistentFlags().Int64(CmdTo, 0, "last height to re-extract")
+	Command.PersistentFlags().StringSlice(CmdDomain, nil, fmt.Sprintf("comma separated domains to re-extract (%s)", strings.Join(extractor.Domains(), ",")))
+	_ = Command.MarkPersistentFlagRequired(CmdFrom)
+	_ = Command.MarkPersistentFlagRequired(CmdTo)
+	_ = Command.MarkPersistentFlagRequired(CmdDomain)
+}
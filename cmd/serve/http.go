@@ -1,9 +1,13 @@
 package serve
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/endpoints"
 	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
 	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
 )
@@ -26,7 +30,7 @@ func ServeHTTP(cmd *cobra.Command) {
 	if err != nil {
 		logger.L.Panicw("Cannot get listen address from command line parameters", "error", err)
 	}
-	lcdEndpoint, err := cmd.Flags().GetString(rest.CmdLcdEndpoint)
+	lcdEndpoints, err := cmd.Flags().GetStringSlice(rest.CmdLcdEndpoint)
 	if err != nil {
 		logger.L.Panicw("Cannot get lcd endpoint address from command line parameters", "error", err)
 	}
@@ -35,8 +39,17 @@ func ServeHTTP(cmd *cobra.Command) {
 		logger.L.Panicw("Cannot get API sender addresses from command line parameters", "error", err)
 	}
 
-	if lcdEndpoint[len(lcdEndpoint)-1] == '/' {
-		lcdEndpoint = lcdEndpoint[:len(lcdEndpoint)-1]
+	adminToken, err := cmd.Flags().GetString(rest.CmdAdminToken)
+	if err != nil {
+		logger.L.Panicw("Cannot get admin token from command line parameters", "error", err)
+	}
+
+	lcd, err := endpoints.NewPool(&http.Client{Timeout: 10 * time.Second}, lcdEndpoints)
+	if err != nil {
+		logger.L.Panicw("Cannot initialize lcd endpoints", "error", err)
+	}
+	err = rest.Run(cmd.Context(), pool, listenAddr, lcd, defaultApiAddresses, adminToken)
+	if err != nil {
+		logger.L.Errorw("HTTP server stopped with error", "error", err)
 	}
-	rest.Run(pool, listenAddr, lcdEndpoint, defaultApiAddresses)
 }
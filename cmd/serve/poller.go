@@ -1,6 +1,7 @@
 package serve
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
@@ -10,12 +11,23 @@ import (
 
 	"github.com/likecoin/likecoin-chain-tx-indexer/db"
 	"github.com/likecoin/likecoin-chain-tx-indexer/db/schema"
+	"github.com/likecoin/likecoin-chain-tx-indexer/endpoints"
 	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
 	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
 	"github.com/likecoin/likecoin-chain-tx-indexer/poller"
 	"github.com/likecoin/likecoin-chain-tx-indexer/pubsub"
 )
 
+const (
+	CmdPollerSource      = "poller-source"
+	CmdSubscribeNewBlock = "subscribe-new-block"
+	CmdSyncExtract       = "sync-extract"
+
+	PollerSourceLcd  = "lcd"
+	PollerSourceRpc  = "rpc"
+	PollerSourceGrpc = "grpc"
+)
+
 var PollerCommand = &cobra.Command{
 	Use:   "poller",
 	Short: "Run the indexing service",
@@ -41,9 +53,19 @@ func ServePoller(cmd *cobra.Command) {
 	}
 	conn.Release()
 
-	lcdEndpoint, err := cmd.Flags().GetString("lcd-endpoint")
+	rpcEndpoint, err := cmd.Flags().GetString("rpc-endpoint")
+	if err != nil {
+		logger.L.Panicw("Cannot get rpc endpoint address from command line parameters", "error", err)
+	}
+
+	subscribeNewBlock, err := cmd.Flags().GetBool(CmdSubscribeNewBlock)
+	if err != nil {
+		logger.L.Panicw("Cannot get new block subscription option from command line parameters", "error", err)
+	}
+
+	syncExtract, err := cmd.Flags().GetBool(CmdSyncExtract)
 	if err != nil {
-		logger.L.Panicw("Cannot get lcd endpoint address from command line parameters", "error", err)
+		logger.L.Panicw("Cannot get synchronous extraction option from command line parameters", "error", err)
 	}
 
 	err = pubsub.InitPubsubFromCmd(cmd)
@@ -51,15 +73,99 @@ func ServePoller(cmd *cobra.Command) {
 		logger.L.Errorw("Pubsub initialization filed", "error", err)
 	}
 
-	ctx := poller.CosmosCallContext{
-		Codec: app.MakeEncodingConfig().Amino.Amino,
-		Client: &http.Client{
-			Transport: &http.Transport{
-				MaxIdleConnsPerHost: 20,
-			},
-			Timeout: 10 * time.Second,
+	source, closeSource, err := NewBlockSourceFromCmd(cmd)
+	if err != nil {
+		logger.L.Panicw("Cannot initialize poller source", "error", err)
+	}
+	defer closeSource()
+
+	ctx := cmd.Context()
+	var newBlock <-chan struct{}
+	if subscribeNewBlock {
+		if rpcEndpoint == "" {
+			logger.L.Panicw("RPC endpoint is required for new block subscription")
+		}
+		newBlock, err = poller.SubscribeNewBlock(ctx, rpcEndpoint)
+		if err != nil {
+			logger.L.Panicw("Cannot subscribe to new block events", "error", err)
+		}
+	}
+	laggingDone := extractor.RunLagging(ctx, pool)
+	if syncExtract {
+		poller.Run(ctx, pool, source, newBlock, extractor.Extractors)
+	} else {
+		trigger, extractorDone := extractor.Run(ctx, pool)
+		poller.Run(ctx, pool, source, newBlock, nil, trigger)
+		<-extractorDone
+	}
+	<-laggingDone
+}
+
+// NewBlockSourceFromCmd creates the block source selected by command line parameters,
+// closeSource should be called to release the underlying connection when the source is no longer used
+func NewBlockSourceFromCmd(cmd *cobra.Command) (source poller.BlockSource, closeSource func(), err error) {
+	lcdEndpoints, err := cmd.Flags().GetStringSlice("lcd-endpoint")
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get lcd endpoint address from command line parameters: %w", err)
+	}
+
+	rpcEndpoint, err := cmd.Flags().GetString("rpc-endpoint")
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get rpc endpoint address from command line parameters: %w", err)
+	}
+
+	grpcEndpoint, err := cmd.Flags().GetString("grpc-endpoint")
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get gRPC endpoint address from command line parameters: %w", err)
+	}
+
+	pollerSource, err := cmd.Flags().GetString(CmdPollerSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get poller source from command line parameters: %w", err)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConnsPerHost: 20,
 		},
-		LcdEndpoint: lcdEndpoint,
+		Timeout: 10 * time.Second,
 	}
-	poller.Run(pool, &ctx, extractor.Run(pool))
+	lcd, err := endpoints.NewPool(client, lcdEndpoints)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot initialize lcd endpoints: %w", err)
+	}
+	ctx := poller.CosmosCallContext{
+		Codec:       app.MakeEncodingConfig().Amino.Amino,
+		Client:      client,
+		Lcd:         lcd,
+		RpcEndpoint: rpcEndpoint,
+	}
+	closeSource = func() {}
+	switch pollerSource {
+	case PollerSourceLcd:
+		source = poller.NewLcdSource(&ctx)
+	case PollerSourceRpc:
+		if rpcEndpoint == "" {
+			return nil, nil, fmt.Errorf("RPC endpoint is required for RPC poller source")
+		}
+		source = poller.NewRpcSource(&ctx)
+	case PollerSourceGrpc:
+		if grpcEndpoint == "" {
+			return nil, nil, fmt.Errorf("gRPC endpoint is required for gRPC poller source")
+		}
+		grpcConn, err := poller.DialGrpc(grpcEndpoint)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot connect to gRPC endpoint: %w", err)
+		}
+		closeSource = func() { grpcConn.Close() }
+		source = poller.NewGrpcSource(grpcConn)
+	default:
+		return nil, nil, fmt.Errorf("unknown poller source %s", pollerSource)
+	}
+	return source, closeSource, nil
+}
+
+// ConfigBlockSourceCmd adds the flags used by NewBlockSourceFromCmd besides the endpoint flags from rest.ConfigCmd
+func ConfigBlockSourceCmd(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(CmdPollerSource, PollerSourceLcd, "Source of blocks and transactions for poller, one of lcd, rpc (requires --rpc-endpoint) or grpc (requires --grpc-endpoint)")
 }
@@ -1,6 +1,8 @@
 package serve
 
 import (
+	"sync"
+
 	"github.com/spf13/cobra"
 
 	"github.com/likecoin/likecoin-chain-tx-indexer/pubsub"
@@ -12,13 +14,22 @@ var Command = &cobra.Command{
 	Short: "Run the indexing service and expose HTTP API",
 	Long:  "Deprecated. Use the `rest` and `poll` subcommands to run HTTP API server and poller separately instead.",
 	Run: func(cmd *cobra.Command, args []string) {
-		go ServeHTTP(cmd)
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ServeHTTP(cmd)
+		}()
 		ServePoller(cmd)
+		wg.Wait()
 	},
 }
 
 func init() {
 	Command.AddCommand(PollerCommand, HTTPCommand)
+	ConfigBlockSourceCmd(Command)
+	Command.PersistentFlags().Bool(CmdSyncExtract, false, "Extract transactions in the same database transaction as they are polled, instead of in a separated extractor")
+	Command.PersistentFlags().Bool(CmdSubscribeNewBlock, false, "Subscribe to new block events from Tendermint RPC websocket (requires --rpc-endpoint), so new blocks are polled right away")
 	rest.ConfigCmd(Command)
 	pubsub.ConfigCmd(Command)
 }
@@ -0,0 +1,83 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/cmd/serve"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db/schema"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/poller"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+)
+
+const (
+	CmdFrom = "from"
+	CmdTo   = "to"
+)
+
+var Command = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the integrity of indexed data",
+}
+
+var GapsCommand = &cobra.Command{
+	Use:   "gaps",
+	Short: "Find heights with missing transactions",
+	Long:  "Find heights with missing transactions, by comparing the transaction count of each block against the rows in txs table. The transaction count is taken from the blocks table, or from the node if the block is not in the blocks table.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := cmd.Flags().GetInt64(CmdFrom)
+		if err != nil {
+			return err
+		}
+		to, err := cmd.Flags().GetInt64(CmdTo)
+		if err != nil {
+			return err
+		}
+		pool, err := db.GetConnPoolFromCmdArgs(cmd)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize database connection pool", "error", err)
+		}
+		conn, err := db.AcquireFromPool(pool)
+		if err != nil {
+			logger.L.Panicw("Cannot acquire connection from database connection pool", "error", err)
+		}
+		err = schema.InitDB(conn)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize database", "error", err)
+		}
+		if to == 0 {
+			to, err = db.GetLatestHeight(conn)
+			if err != nil {
+				logger.L.Panicw("Cannot get latest height", "error", err)
+			}
+		}
+		conn.Release()
+
+		source, closeSource, err := serve.NewBlockSourceFromCmd(cmd)
+		if err != nil {
+			logger.L.Panicw("Cannot initialize block source", "error", err)
+		}
+		defer closeSource()
+
+		gaps, err := poller.FindGaps(cmd.Context(), pool, source, from, to)
+		if err != nil {
+			return err
+		}
+		for _, gap := range gaps {
+			fmt.Printf("height = %d, expected txs = %d, indexed txs = %d\n", gap.Height, gap.Expected, gap.Indexed)
+		}
+		logger.L.Infow("Gap verification finished", "from", from, "to", to, "gaps", len(gaps))
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(GapsCommand)
+	GapsCommand.PersistentFlags().Int64(CmdFrom, 1, "first height to verify")
+	GapsCommand.PersistentFlags().Int64(CmdTo, 0, "last height to verify, default to the latest polled height")
+	serve.ConfigBlockSourceCmd(Command)
+	rest.ConfigCmd(Command)
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// InsertAuthzGrant inserts the grant, replacing the previous grant with the same key as the chain does.
+// A grant older than the existing one is skipped, so the grants are not rolled back when re-extracting part of the heights.
+func (batch *Batch) InsertAuthzGrant(g AuthzGrant) {
+	sql := `
+	INSERT INTO authz_grant (
+		granter, grantee, msg_type_url, authorization_type, authorization,
+		spend_limit, expiration, height, tx_hash, timestamp
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT (granter, grantee, msg_type_url) DO UPDATE SET
+		authorization_type = EXCLUDED.authorization_type,
+		authorization = EXCLUDED.authorization,
+		spend_limit = EXCLUDED.spend_limit,
+		expiration = EXCLUDED.expiration,
+		height = EXCLUDED.height,
+		tx_hash = EXCLUDED.tx_hash,
+		timestamp = EXCLUDED.timestamp,
+		last_exec_height = NULL,
+		last_exec_tx_hash = '',
+		last_exec_timestamp = NULL,
+		revoked_height = NULL,
+		revoked_tx_hash = '',
+		revoked_timestamp = NULL
+	WHERE authz_grant.height <= EXCLUDED.height
+	`
+	var spendLimit []byte
+	if len(g.SpendLimit) > 0 {
+		spendLimit = g.SpendLimit
+	}
+	batch.Batch.Queue(sql,
+		utils.NormalizeAddress(g.Granter, MainAddressPrefix), utils.NormalizeAddress(g.Grantee, MainAddressPrefix), g.MsgTypeUrl, g.AuthorizationType, []byte(g.Authorization),
+		spendLimit, g.Expiration, g.Height, g.TxHash, g.Timestamp,
+	)
+}
+
+// RevokeAuthzGrant marks the grant as revoked, by MsgRevoke or when the spend limit is used up
+func (batch *Batch) RevokeAuthzGrant(key AuthzGrantKey, height int64, txHash string, timestamp time.Time) {
+	sql := `
+	UPDATE authz_grant
+	SET revoked_height = $4, revoked_tx_hash = $5, revoked_timestamp = $6
+	WHERE granter = $1 AND grantee = $2 AND msg_type_url = $3
+		AND height <= $4
+	`
+	batch.Batch.Queue(sql, utils.NormalizeAddress(key.Granter, MainAddressPrefix), utils.NormalizeAddress(key.Grantee, MainAddressPrefix), key.MsgTypeUrl, height, txHash, timestamp)
+}
+
+// UpdateAuthzGrantExec records the MsgExec executing the grant
+func (batch *Batch) UpdateAuthzGrantExec(key AuthzGrantKey, height int64, txHash string, timestamp time.Time) {
+	sql := `
+	UPDATE authz_grant
+	SET last_exec_height = $4, last_exec_tx_hash = $5, last_exec_timestamp = $6
+	WHERE granter = $1 AND grantee = $2 AND msg_type_url = $3
+		AND height <= $4
+		AND (last_exec_height IS NULL OR last_exec_height <= $4)
+	`
+	batch.Batch.Queue(sql, utils.NormalizeAddress(key.Granter, MainAddressPrefix), utils.NormalizeAddress(key.Grantee, MainAddressPrefix), key.MsgTypeUrl, height, txHash, timestamp)
+}
+
+// GetAuthzGrants returns the grants neither revoked nor expired
+func GetAuthzGrants(conn *pgxpool.Conn, q QueryAuthzGrantsRequest, p PageRequest) (QueryAuthzGrantsResponse, error) {
+	granterVariations := utils.ConvertAddressPrefixes(q.Granter, AddressPrefixes)
+	granteeVariations := utils.ConvertAddressPrefixes(q.Grantee, AddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT id, granter, grantee, msg_type_url, authorization_type,
+			authorization, spend_limit, expiration, height, tx_hash,
+			timestamp, last_exec_height, last_exec_tx_hash, last_exec_timestamp
+		FROM authz_grant
+		WHERE revoked_height IS NULL
+			AND (expiration IS NULL OR expiration > NOW())
+			AND ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR granter = ANY($4))
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR grantee = ANY($5))
+			AND ($6 = '' OR msg_type_url = $6)
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(ctx, sql, p.After(), p.Before(), p.Limit, granterVariations, granteeVariations, q.MsgTypeUrl)
+	if err != nil {
+		logger.L.Errorw("Failed to query authz grants", "error", err, "q", q)
+		return QueryAuthzGrantsResponse{}, fmt.Errorf("query authz grants error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryAuthzGrantsResponse{
+		Grants: make([]AuthzGrant, 0),
+	}
+	for rows.Next() {
+		var g AuthzGrant
+		var authorization, spendLimit []byte
+		if err = rows.Scan(
+			&res.Pagination.NextKey, &g.Granter, &g.Grantee, &g.MsgTypeUrl, &g.AuthorizationType,
+			&authorization, &spendLimit, &g.Expiration, &g.Height, &g.TxHash,
+			&g.Timestamp, &g.LastExecHeight, &g.LastExecTxHash, &g.LastExecTimestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan authz grant", "error", err, "q", q)
+			return QueryAuthzGrantsResponse{}, fmt.Errorf("scan authz grant error: %w", err)
+		}
+		g.Authorization = authorization
+		g.SpendLimit = spendLimit
+		res.Grants = append(res.Grants, g)
+	}
+	res.Pagination.Count = len(res.Grants)
+	return res, nil
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+func (batch *Batch) InsertTokenTransfer(t TokenTransfer) {
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		amount = EXCLUDED.amount,
+		height = EXCLUDED.height,
+		memo = EXCLUDED.memo,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO token_transfer (sender, receiver, denom, amount, height, tx_hash, msg_index, memo, timestamp)
+	VALUES ($1, $2, $3, $4::numeric, $5, $6, $7, $8, $9)
+	ON CONFLICT (tx_hash, msg_index, sender, receiver, denom) %s
+	`, conflict)
+	batch.Batch.Queue(sql, utils.NormalizeAddress(t.Sender, MainAddressPrefix), utils.NormalizeAddress(t.Receiver, MainAddressPrefix), t.Denom, t.Amount, t.Height, t.TxHash, t.MsgIndex, t.Memo, t.Timestamp)
+}
+
+func GetTokenTransfers(conn *pgxpool.Conn, q QueryTokenTransfersRequest, p PageRequest) (QueryTokenTransfersResponse, error) {
+	addressVariations := utils.ConvertAddressPrefixes(q.Address, AddressPrefixes)
+	senderVariations := utils.ConvertAddressPrefixes(q.Sender, AddressPrefixes)
+	receiverVariations := utils.ConvertAddressPrefixes(q.Receiver, AddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT id, sender, receiver, denom, amount::text,
+			height, tx_hash, msg_index, memo, timestamp
+		FROM token_transfer
+		WHERE ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR sender = ANY($4) OR receiver = ANY($4))
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR sender = ANY($5))
+			AND ($6::text[] IS NULL OR cardinality($6::text[]) = 0 OR receiver = ANY($6))
+			AND ($7 = '' OR denom = $7)
+			AND ($8 = 0 OR timestamp > to_timestamp($8))
+			AND ($9 = 0 OR timestamp < to_timestamp($9))
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(
+		ctx, sql,
+		p.After(), p.Before(), p.Limit, addressVariations, senderVariations,
+		receiverVariations, q.Denom, q.After, q.Before,
+	)
+	if err != nil {
+		logger.L.Errorw("Failed to query token transfers", "error", err, "q", q)
+		return QueryTokenTransfersResponse{}, fmt.Errorf("query token transfers error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryTokenTransfersResponse{
+		Transfers: make([]TokenTransfer, 0),
+	}
+	for rows.Next() {
+		var t TokenTransfer
+		if err = rows.Scan(
+			&res.Pagination.NextKey, &t.Sender, &t.Receiver, &t.Denom, &t.Amount,
+			&t.Height, &t.TxHash, &t.MsgIndex, &t.Memo, &t.Timestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan token transfer", "error", err, "q", q)
+			return QueryTokenTransfersResponse{}, fmt.Errorf("scan token transfer error: %w", err)
+		}
+		res.Transfers = append(res.Transfers, t)
+	}
+	res.Pagination.Count = len(res.Transfers)
+	return res, nil
+}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+func (batch *Batch) InsertBlock(block Block) error {
+	if batch.Batch.Len() >= batch.limit && batch.prevHeight > 0 && block.Height != batch.prevHeight {
+		err := batch.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	batch.Batch.Queue(`
+		INSERT INTO blocks (height, hash, proposer_address, time, tx_count)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING`,
+		block.Height, block.Hash, block.ProposerAddress, block.Time.UTC(), block.TxCount,
+	)
+	batch.prevHeight = block.Height
+	return nil
+}
+
+func scanBlock(row pgx.Row) (Block, error) {
+	var b Block
+	err := row.Scan(&b.Height, &b.Hash, &b.ProposerAddress, &b.Time, &b.TxCount)
+	b.Time = b.Time.UTC()
+	return b, err
+}
+
+func GetBlocks(conn *pgxpool.Conn, q QueryBlocksRequest, p PageRequest) (QueryBlocksResponse, error) {
+	sql := fmt.Sprintf(`
+		SELECT height, hash, proposer_address, time, tx_count
+		FROM blocks
+		WHERE ($1 = 0 OR height > $1)
+			AND ($2 = 0 OR height < $2)
+			AND ($4 = 0 OR time > to_timestamp($4))
+			AND ($5 = 0 OR time < to_timestamp($5))
+			AND ($6::boolean IS NULL OR (tx_count = 0) = $6)
+			AND ($7 = '' OR proposer_address = $7)
+		ORDER BY height %s
+		LIMIT $3
+	`, p.Order())
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	rows, err := conn.Query(
+		ctx, sql,
+		p.After(), p.Before(), p.Limit, q.After, q.Before,
+		q.Empty, q.ProposerAddress,
+	)
+	if err != nil {
+		logger.L.Errorw("Failed to query blocks", "error", err, "q", q)
+		return QueryBlocksResponse{}, fmt.Errorf("query blocks error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryBlocksResponse{
+		Blocks: make([]Block, 0),
+	}
+	for rows.Next() {
+		b, err := scanBlock(rows)
+		if err != nil {
+			logger.L.Errorw("Failed to scan block", "error", err, "q", q)
+			return QueryBlocksResponse{}, fmt.Errorf("scan block error: %w", err)
+		}
+		res.Blocks = append(res.Blocks, b)
+		res.Pagination.NextKey = uint64(b.Height)
+	}
+	res.Pagination.Count = len(res.Blocks)
+	return res, nil
+}
+
+func GetBlockByHeight(conn *pgxpool.Conn, height int64) (Block, error) {
+	sql := `
+		SELECT height, hash, proposer_address, time, tx_count
+		FROM blocks
+		WHERE height = $1
+	`
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	return scanBlock(conn.QueryRow(ctx, sql, height))
+}
+
+// GetBlockAtTime returns the latest block committed at or before the given time
+func GetBlockAtTime(conn *pgxpool.Conn, t time.Time) (Block, error) {
+	sql := `
+		SELECT height, hash, proposer_address, time, tx_count
+		FROM blocks
+		WHERE time <= $1
+		ORDER BY time DESC, height DESC
+		LIMIT 1
+	`
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	return scanBlock(conn.QueryRow(ctx, sql, t.UTC()))
+}
+
+// GetUnverifiedHeights returns heights in [from, to] whose transactions cannot be verified as complete,
+// i.e. either the block header is not indexed, or the transaction count of the block does not match the rows in txs
+func GetUnverifiedHeights(conn *pgxpool.Conn, from, to int64) ([]HeightTxCount, error) {
+	sql := `
+		SELECT h.height, b.tx_count, COALESCE(t.count, 0)
+		FROM generate_series($1::bigint, $2::bigint) AS h (height)
+		LEFT JOIN blocks AS b
+			ON b.height = h.height
+		LEFT JOIN (
+			SELECT height, count(*) AS count
+			FROM txs
+			WHERE height >= $1 AND height <= $2
+			GROUP BY height
+		) AS t
+			ON t.height = h.height
+		WHERE b.height IS NULL
+			OR b.tx_count <> COALESCE(t.count, 0)
+		ORDER BY h.height
+	`
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	rows, err := conn.Query(ctx, sql, from, to)
+	if err != nil {
+		logger.L.Errorw("Failed to query unverified heights", "error", err, "from", from, "to", to)
+		return nil, fmt.Errorf("query unverified heights error: %w", err)
+	}
+	defer rows.Close()
+
+	res := []HeightTxCount{}
+	for rows.Next() {
+		var h HeightTxCount
+		err = rows.Scan(&h.Height, &h.BlockTxCount, &h.TxCount)
+		if err != nil {
+			logger.L.Errorw("Failed to scan unverified height", "error", err, "from", from, "to", to)
+			return nil, fmt.Errorf("scan unverified height error: %w", err)
+		}
+		res = append(res, h)
+	}
+	return res, rows.Err()
+}
+
+// GetTxIndices returns the indices of transactions already in the txs table for each height in [from, to]
+func GetTxIndices(conn *pgxpool.Conn, from, to int64) (map[int64]map[int]bool, error) {
+	sql := `
+		SELECT height, tx_index
+		FROM txs
+		WHERE height >= $1 AND height <= $2
+	`
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	rows, err := conn.Query(ctx, sql, from, to)
+	if err != nil {
+		logger.L.Errorw("Failed to query tx indices", "error", err, "from", from, "to", to)
+		return nil, fmt.Errorf("query tx indices error: %w", err)
+	}
+	defer rows.Close()
+
+	res := map[int64]map[int]bool{}
+	for rows.Next() {
+		var height int64
+		var txIndex int
+		err = rows.Scan(&height, &txIndex)
+		if err != nil {
+			return nil, fmt.Errorf("scan tx index error: %w", err)
+		}
+		if res[height] == nil {
+			res[height] = map[int]bool{}
+		}
+		res[height][txIndex] = true
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,94 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestBlocks(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blocks := []Block{
+		{
+			Height:          1000,
+			Hash:            "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+			ProposerAddress: "1111111111111111111111111111111111111111",
+			Time:            time.Unix(1680000000, 0).UTC(),
+			TxCount:         2,
+		},
+		{
+			Height:          1001,
+			Hash:            "BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB",
+			ProposerAddress: "2222222222222222222222222222222222222222",
+			Time:            time.Unix(1680000006, 0).UTC(),
+			TxCount:         0,
+		},
+		{
+			Height:          1002,
+			Hash:            "CCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCC",
+			ProposerAddress: "1111111111111111111111111111111111111111",
+			Time:            time.Unix(1680000012, 0).UTC(),
+			TxCount:         0,
+		},
+		{
+			Height:          1003,
+			Hash:            "DDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDD",
+			ProposerAddress: "2222222222222222222222222222222222222222",
+			Time:            time.Unix(1680000018, 0).UTC(),
+			TxCount:         1,
+		},
+	}
+	InsertTestData(DBTestData{Blocks: blocks})
+
+	empty := true
+	notEmpty := false
+	testCases := []struct {
+		name    string
+		query   QueryBlocksRequest
+		page    PageRequest
+		heights []int64
+	}{
+		{"all", QueryBlocksRequest{}, PageRequest{Limit: 10}, []int64{1000, 1001, 1002, 1003}},
+		{"reverse", QueryBlocksRequest{}, PageRequest{Limit: 10, Reverse: true}, []int64{1003, 1002, 1001, 1000}},
+		{"limit", QueryBlocksRequest{}, PageRequest{Limit: 2}, []int64{1000, 1001}},
+		{"key", QueryBlocksRequest{}, PageRequest{Limit: 10, Key: 1001}, []int64{1002, 1003}},
+		{"empty", QueryBlocksRequest{Empty: &empty}, PageRequest{Limit: 10}, []int64{1001, 1002}},
+		{"not empty", QueryBlocksRequest{Empty: &notEmpty}, PageRequest{Limit: 10}, []int64{1000, 1003}},
+		{"proposer", QueryBlocksRequest{ProposerAddress: blocks[0].ProposerAddress}, PageRequest{Limit: 10}, []int64{1000, 1002}},
+		{"after", QueryBlocksRequest{After: 1680000006}, PageRequest{Limit: 10}, []int64{1002, 1003}},
+		{"before", QueryBlocksRequest{Before: 1680000006}, PageRequest{Limit: 10}, []int64{1000}},
+	}
+	for i, testCase := range testCases {
+		res, err := GetBlocks(Conn, testCase.query, testCase.page)
+		require.NoError(t, err, "Error in test case #%02d (%s)", i, testCase.name)
+		heights := []int64{}
+		for _, b := range res.Blocks {
+			heights = append(heights, b.Height)
+		}
+		require.Equal(t, testCase.heights, heights, "Error in test case #%02d (%s)", i, testCase.name)
+		require.Equal(t, len(testCase.heights), res.Pagination.Count, "Error in test case #%02d (%s)", i, testCase.name)
+	}
+
+	block, err := GetBlockByHeight(Conn, 1001)
+	require.NoError(t, err)
+	require.Equal(t, blocks[1], block)
+
+	_, err = GetBlockByHeight(Conn, 999)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	block, err = GetBlockAtTime(Conn, time.Unix(1680000010, 0))
+	require.NoError(t, err)
+	require.Equal(t, int64(1001), block.Height)
+
+	block, err = GetBlockAtTime(Conn, time.Unix(1680000018, 0))
+	require.NoError(t, err)
+	require.Equal(t, int64(1003), block.Height)
+
+	_, err = GetBlockAtTime(Conn, time.Unix(1679999999, 0))
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -78,8 +78,13 @@ func ConfigCmd(cmd *cobra.Command) {
 }
 
 func GetTimeoutContext() (context.Context, context.CancelFunc) {
+	return GetTimeoutContextFrom(context.Background())
+}
+
+// GetTimeoutContextFrom is the same as GetTimeoutContext, but the returned context is also canceled when parent is done
+func GetTimeoutContextFrom(parent context.Context) (context.Context, context.CancelFunc) {
 	// TODO: move into config
-	return context.WithTimeout(context.Background(), 45*time.Second)
+	return context.WithTimeout(parent, 45*time.Second)
 }
 
 func GetConnPoolFromCmdArgs(cmd *cobra.Command) (*pgxpool.Pool, error) {
@@ -222,8 +227,11 @@ func QueryTxs(conn *pgxpool.Conn, events types.StringEvents, height uint64, p Pa
 }
 
 type Batch struct {
-	Conn       *pgxpool.Conn
-	Batch      pgx.Batch
+	Conn  *pgxpool.Conn
+	Batch pgx.Batch
+	// Overwrite makes the extracted rows replace the existing ones with the same key instead of being skipped,
+	// for re-extracting transactions already extracted
+	Overwrite  bool
 	limit      int
 	prevHeight int64
 }
@@ -280,11 +288,18 @@ func (batch *Batch) Flush() error {
 		defer cancel()
 		result := batch.Conn.SendBatch(ctx, &batch.Batch)
 		_, err := result.Exec()
+		if err != nil {
+			result.Close()
+			logger.L.Debugw("Error when flushing Postgres batch", "err", err, "batch_size", batch.Batch.Len())
+			return err
+		}
+		// the queued statements run in one implicit transaction, Close reports the error of any statement after the first one,
+		// in which case the whole batch is rolled back
+		err = result.Close()
 		if err != nil {
 			logger.L.Debugw("Error when flushing Postgres batch", "err", err, "batch_size", batch.Batch.Len())
 			return err
 		}
-		result.Close()
 		batch.Batch = pgx.Batch{}
 	}
 	return nil
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
 	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
@@ -23,21 +25,142 @@ type EventsList []struct {
 
 type EventContext struct {
 	Batch      *Batch
+	Height     int64
 	Messages   []json.RawMessage
 	EventsList EventsList
 	Timestamp  time.Time
 	TxHash     string
 	Memo       string
+	Fee        TxFee
 
 	// If the event is from authz, we process it by making a psuedo EventContext
 	// for each authz message, and then set this field to the original EventContext
 	AuthzParent *EventContext
+	// index of the MsgExec in AuthzParent
+	AuthzMsgIndex int
+}
+
+// TxFee is the fee of the transaction, paid by the fee allowance from Granter if it is not empty
+type TxFee struct {
+	Amount  types.Coins `json:"amount"`
+	Payer   string      `json:"payer"`
+	Granter string      `json:"granter"`
 }
 
 type Extractor func(ctx EventContext) error
 
-func Extract(conn *pgxpool.Conn, extractor Extractor) (finished bool, err error) {
-	prevSyncedHeight, err := GetMetaHeight(conn, META_EXTRACTOR)
+// ExtractorGroup is a group of processors with its own extraction progress in the meta table,
+// so a new group, or a group with its version bumped, extracts from height 0 without affecting the others
+type ExtractorGroup struct {
+	Name    string
+	Version int
+}
+
+func (g ExtractorGroup) MetaKey() string {
+	return fmt.Sprintf("extractor_%s_v%d", g.Name, g.Version)
+}
+
+// GroupedExtractor provides the extractor running the processors of any subset of its groups
+type GroupedExtractor interface {
+	Groups() []ExtractorGroup
+	ForGroups(groups []ExtractorGroup) Extractor
+}
+
+// GetExtractorHeights returns the height extracted by the groups tracking the latest height (META_EXTRACTOR),
+// and the extracted height of each group, which is 0 for groups never extracted
+func GetExtractorHeights(conn *pgxpool.Conn, groups []ExtractorGroup) (trackingHeight int64, heights []int64, err error) {
+	keys := make([]string, len(groups)+1)
+	keys[0] = META_EXTRACTOR
+	for i, g := range groups {
+		keys[i+1] = g.MetaKey()
+	}
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	// read in one query, so the heights are consistent with each other
+	rows, err := conn.Query(ctx, `SELECT id, height FROM meta WHERE id = ANY($1)`, keys)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to query extractor heights: %w", err)
+	}
+	defer rows.Close()
+	metaHeights := map[string]int64{}
+	for rows.Next() {
+		var key string
+		var height int64
+		err = rows.Scan(&key, &height)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to scan extractor height: %w", err)
+		}
+		metaHeights[key] = height
+	}
+	if err = rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("failed to read extractor heights: %w", err)
+	}
+	trackingHeight, ok := metaHeights[META_EXTRACTOR]
+	if !ok {
+		return 0, nil, fmt.Errorf("extractor height not found in meta table")
+	}
+	heights = make([]int64, len(groups))
+	for i, g := range groups {
+		heights[i] = metaHeights[g.MetaKey()]
+	}
+	return trackingHeight, heights, nil
+}
+
+// GetExtractorCursors returns the extracted height of each group of extractor
+func GetExtractorCursors(conn *pgxpool.Conn, extractor GroupedExtractor) ([]ExtractorCursor, error) {
+	groups := extractor.Groups()
+	_, heights, err := GetExtractorHeights(conn, groups)
+	if err != nil {
+		return nil, err
+	}
+	cursors := make([]ExtractorCursor, len(groups))
+	for i, g := range groups {
+		cursors[i] = ExtractorCursor{
+			Name:    g.Name,
+			Version: g.Version,
+			Height:  heights[i],
+		}
+	}
+	return cursors, nil
+}
+
+// GroupsExtracted returns the groups which have extracted the given height
+func GroupsExtracted(groups []ExtractorGroup, heights []int64, height int64) []ExtractorGroup {
+	res := []ExtractorGroup{}
+	for i, g := range groups {
+		if heights[i] >= height {
+			res = append(res, g)
+		}
+	}
+	return res
+}
+
+// GroupsAt returns the groups which have extracted exactly up to the given height,
+// e.g. the groups tracking the latest height if the height is META_EXTRACTOR
+func GroupsAt(groups []ExtractorGroup, heights []int64, height int64) []ExtractorGroup {
+	res := []ExtractorGroup{}
+	for i, g := range groups {
+		if heights[i] == height {
+			res = append(res, g)
+		}
+	}
+	return res
+}
+
+// UpdateExtractorHeight updates the height of META_EXTRACTOR and the groups tracking the latest height
+func (batch *Batch) UpdateExtractorHeight(trackingGroups []ExtractorGroup, height int64) {
+	batch.UpdateMetaHeight(META_EXTRACTOR, height)
+	for _, g := range trackingGroups {
+		batch.UpsertMetaHeight(g.MetaKey(), height)
+	}
+}
+
+// Extract runs the groups tracking the latest height on the transactions not yet extracted, up to LIMIT heights at a time.
+// Groups behind them are left to ExtractLagging.
+// If ctx is done before all transactions are processed, the batch is discarded and nothing is committed.
+func Extract(ctx context.Context, conn *pgxpool.Conn, extractor GroupedExtractor) (finished bool, err error) {
+	groups := extractor.Groups()
+	prevSyncedHeight, heights, err := GetExtractorHeights(conn, groups)
 	if err != nil {
 		return false, fmt.Errorf("failed to get extractor synchonized height: %w", err)
 	}
@@ -56,72 +179,198 @@ func Extract(conn *pgxpool.Conn, extractor Extractor) (finished bool, err error)
 		finished = true
 	}
 
-	ctx, cancel := GetTimeoutContext()
+	trackingGroups := GroupsAt(groups, heights, prevSyncedHeight)
+	batch := NewBatch(conn, int(LIMIT))
+	if len(trackingGroups) > 0 {
+		err = ExtractRange(ctx, conn, &batch, prevSyncedHeight+1, latestSyncingHeight, extractor.ForGroups(trackingGroups))
+		if err != nil {
+			return false, err
+		}
+	}
+	batch.UpdateExtractorHeight(trackingGroups, latestSyncingHeight)
+	err = batch.Flush()
+	if err != nil {
+		return false, fmt.Errorf("send batch failed: %w", err)
+	}
+	logger.L.Infof("Extractor synced height: %d", latestSyncingHeight)
+	return finished, nil
+}
+
+// ExtractLagging runs the groups behind the tracking height on the transactions they have not extracted, up to LIMIT heights at a time,
+// starting from the group furthest behind, until they catch up with the tracking height and are extracted by Extract.
+// It returns finished = true if there is no lagging group.
+func ExtractLagging(ctx context.Context, conn *pgxpool.Conn, extractor GroupedExtractor) (finished bool, err error) {
+	groups := extractor.Groups()
+	trackingHeight, heights, err := GetExtractorHeights(conn, groups)
+	if err != nil {
+		return false, fmt.Errorf("failed to get extractor synchonized height: %w", err)
+	}
+	minHeight := trackingHeight
+	for _, h := range heights {
+		if h < minHeight {
+			minHeight = h
+		}
+	}
+	if minHeight == trackingHeight {
+		return true, nil
+	}
+	lagging := GroupsAt(groups, heights, minHeight)
+	to := minHeight + LIMIT
+	if to > trackingHeight {
+		to = trackingHeight
+	}
+
+	batch := NewBatch(conn, int(LIMIT))
+	err = ExtractRange(ctx, conn, &batch, minHeight+1, to, extractor.ForGroups(lagging))
+	if err != nil {
+		return false, err
+	}
+	for _, g := range lagging {
+		batch.UpsertMetaHeight(g.MetaKey(), to)
+	}
+	err = batch.Flush()
+	if err != nil {
+		return false, fmt.Errorf("send batch failed: %w", err)
+	}
+	logger.L.Infow("Extractor groups backfilled", "groups", lagging, "height", to, "tracking_height", trackingHeight)
+	return false, nil
+}
+
+// ExtractRange runs extractor on the transactions in heights [from, to] with the given batch, without flushing it.
+// If ctx is done before all transactions are read, an error is returned, and the batch should be discarded.
+func ExtractRange(ctx context.Context, conn *pgxpool.Conn, batch *Batch, from, to int64, extractor Extractor) error {
+	queryCtx, cancel := GetTimeoutContextFrom(ctx)
 	defer cancel()
 
-	sql := `
-	SELECT tx #> '{"tx", "body", "messages"}' AS messages, tx -> 'logs' AS logs, tx -> 'timestamp', tx -> 'txhash', tx -> 'tx' -> 'body' -> 'memo'
+	sql := fmt.Sprintf(`
+	SELECT %s
 	FROM txs
-	WHERE height > $1
+	WHERE height >= $1
 		AND height <= $2
-	ORDER BY height ASC;
-	`
+	ORDER BY height ASC, tx_index ASC;
+	`, eventContextColumns)
 
-	rows, err := conn.Query(ctx, sql, prevSyncedHeight, latestSyncingHeight)
+	rows, err := conn.Query(queryCtx, sql, from, to)
 	if err != nil {
-		return false, fmt.Errorf("failed to query unprocessed txs: %w", err)
-
+		return fmt.Errorf("failed to query unprocessed txs: %w", err)
 	}
 	defer rows.Close()
 
-	batch := NewBatch(conn, int(LIMIT))
-
 	for rows.Next() {
-		var messageData pgtype.JSONB
-		var eventData pgtype.JSONB
-		var timestamp time.Time
-		var txHash string
-		var memo string
-		err := rows.Scan(&messageData, &eventData, &timestamp, &txHash, &memo)
+		eventCtx, err := scanEventContext(rows, batch)
 		if err != nil {
-			return false, fmt.Errorf("failed to scan tx row on tx %s: %w", txHash, err)
+			return err
 		}
-
-		var messages []json.RawMessage
-		err = messageData.AssignTo(&messages)
-		if err != nil {
-			return false, fmt.Errorf("failed to unmarshal tx message on tx %s: %w", txHash, err)
-		}
-		var eventsList EventsList
-		err = eventData.AssignTo(&eventsList)
+		err = extractor(eventCtx)
 		if err != nil {
-			return false, fmt.Errorf("failed to unmarshal tx event on tx %s: %w", txHash, err)
+			HandleExtractError(eventCtx, err)
 		}
+	}
+	if err = rows.Err(); err != nil {
+		// e.g. interrupted by ctx, the heights must not be marked as extracted with only part of the transactions processed
+		return fmt.Errorf("failed to read unprocessed txs: %w", err)
+	}
+	return nil
+}
 
-		ctx := EventContext{
-			Batch:      &batch,
-			Messages:   messages,
-			EventsList: eventsList,
-			Timestamp:  timestamp,
-			TxHash:     strings.Trim(txHash, "\""),
-			Memo:       strings.Trim(memo, "\""),
-		}
-		err = extractor(ctx)
+const eventContextColumns = `height, tx #> '{"tx", "body", "messages"}' AS messages, tx -> 'logs' AS logs, tx -> 'timestamp', tx -> 'txhash', tx -> 'tx' -> 'body' -> 'memo', tx #> '{"tx", "auth_info", "fee"}' AS fee`
+
+func scanEventContext(row pgx.Row, batch *Batch) (EventContext, error) {
+	var height int64
+	var messageData pgtype.JSONB
+	var eventData pgtype.JSONB
+	var timestamp time.Time
+	var txHash string
+	var memo string
+	var feeData pgtype.JSONB
+	err := row.Scan(&height, &messageData, &eventData, &timestamp, &txHash, &memo, &feeData)
+	if err != nil {
+		return EventContext{}, fmt.Errorf("failed to scan tx row on tx %s: %w", txHash, err)
+	}
+
+	var messages []json.RawMessage
+	err = messageData.AssignTo(&messages)
+	if err != nil {
+		return EventContext{}, fmt.Errorf("failed to unmarshal tx message on tx %s: %w", txHash, err)
+	}
+	var eventsList EventsList
+	err = eventData.AssignTo(&eventsList)
+	if err != nil {
+		return EventContext{}, fmt.Errorf("failed to unmarshal tx event on tx %s: %w", txHash, err)
+	}
+	var fee TxFee
+	if feeData.Status == pgtype.Present {
+		err = feeData.AssignTo(&fee)
 		if err != nil {
-			logger.L.Errorw("Handle message failed", "error", err, "context", ctx)
+			return EventContext{}, fmt.Errorf("failed to unmarshal tx fee on tx %s: %w", txHash, err)
 		}
 	}
-	batch.UpdateMetaHeight(META_EXTRACTOR, latestSyncingHeight)
-	err = batch.Flush()
+
+	return EventContext{
+		Batch:      batch,
+		Height:     height,
+		Messages:   messages,
+		EventsList: eventsList,
+		Timestamp:  timestamp,
+		TxHash:     strings.Trim(txHash, "\""),
+		Memo:       strings.Trim(memo, "\""),
+		Fee:        fee,
+	}, nil
+}
+
+// GetEventContextOfTx reads the transaction from the txs table and builds its EventContext, e.g. for retrying failed extraction
+func GetEventContextOfTx(conn *pgxpool.Conn, batch *Batch, height int64, txHash string) (EventContext, error) {
+	sql := fmt.Sprintf(`
+	SELECT %s
+	FROM txs
+	WHERE height = $1
+		AND tx->>'txhash' = $2
+	`, eventContextColumns)
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	return scanEventContext(conn.QueryRow(ctx, sql, height, txHash), batch)
+}
+
+// NewEventContextFromTxResponse builds the EventContext of a transaction from its TxResponse,
+// in the same way as Extract does from the txs table, for extracting transactions which are not read from the database
+func NewEventContextFromTxResponse(batch *Batch, txRes *types.TxResponse) (EventContext, error) {
+	txResJSON, err := serializeTx(txRes)
 	if err != nil {
-		return false, fmt.Errorf("send batch failed: %w", err)
+		return EventContext{}, fmt.Errorf("failed to serialize tx %s: %w", txRes.TxHash, err)
 	}
-	logger.L.Infof("Extractor synced height: %d", latestSyncingHeight)
-	return finished, nil
+	var tx struct {
+		Tx struct {
+			Body struct {
+				Messages []json.RawMessage `json:"messages"`
+				Memo     string            `json:"memo"`
+			} `json:"body"`
+			AuthInfo struct {
+				Fee TxFee `json:"fee"`
+			} `json:"auth_info"`
+		} `json:"tx"`
+		Logs      EventsList `json:"logs"`
+		Timestamp time.Time  `json:"timestamp"`
+		TxHash    string     `json:"txhash"`
+	}
+	err = json.Unmarshal(txResJSON, &tx)
+	if err != nil {
+		return EventContext{}, fmt.Errorf("failed to unmarshal tx %s: %w", txRes.TxHash, err)
+	}
+	return EventContext{
+		Batch:      batch,
+		Height:     txRes.Height,
+		Messages:   tx.Tx.Body.Messages,
+		EventsList: tx.Logs,
+		Timestamp:  tx.Timestamp,
+		TxHash:     tx.TxHash,
+		Memo:       tx.Tx.Body.Memo,
+		Fee:        tx.Tx.AuthInfo.Fee,
+	}, nil
 }
 
 func GetMetaHeight(conn *pgxpool.Conn, key string) (int64, error) {
-	ctx, _ := GetTimeoutContext()
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
 	var height int64
 	err := conn.QueryRow(ctx, `SELECT height FROM meta WHERE id = $1`, key).Scan(&height)
 	return height, err
@@ -140,23 +389,45 @@ func (batch *Batch) InsertIscn(insert IscnInsert) {
 	if err == nil {
 		insert.Owner = convertedOwner
 	}
-	sql := `
+	onConflict := "ON CONFLICT DO NOTHING"
+	if batch.Overwrite {
+		onConflict = `ON CONFLICT (iscn_id) DO UPDATE SET
+			iscn_id_prefix = EXCLUDED.iscn_id_prefix,
+			version = EXCLUDED.version,
+			keywords = EXCLUDED.keywords,
+			fingerprints = EXCLUDED.fingerprints,
+			data = EXCLUDED.data,
+			timestamp = EXCLUDED.timestamp,
+			ipld = EXCLUDED.ipld,
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			url = EXCLUDED.url,
+			height = EXCLUDED.height,
+			tx_hash = EXCLUDED.tx_hash,
+			owner = CASE WHEN iscn.owner_height > EXCLUDED.owner_height THEN iscn.owner ELSE EXCLUDED.owner END,
+			owner_height = GREATEST(iscn.owner_height, EXCLUDED.owner_height)`
+		batch.Batch.Queue(`
+			DELETE FROM iscn_stakeholders
+			WHERE iscn_pid = (SELECT id FROM iscn WHERE iscn_id = $1)
+		`, insert.Iscn)
+	}
+	sql := fmt.Sprintf(`
 	WITH result AS (
 		INSERT INTO iscn
 		(
 			iscn_id, iscn_id_prefix, version, owner, keywords,
 			fingerprints, data, timestamp, ipld, name,
-			description, url
+			description, url, height, tx_hash, owner_height
 		)
 		VALUES
-		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		ON CONFLICT DO NOTHING
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $16, $17, $16)
+		%s
 		RETURNING id
 	)
 	INSERT INTO iscn_stakeholders (iscn_pid, sid, sname, data)
 	SELECT id, unnest($13::text[]), unnest($14::text[]), unnest($15::jsonb[])
 	FROM result;
-	`
+	`, onConflict)
 	batch.Batch.Queue(sql,
 		// $1 ~ $5
 		insert.Iscn, insert.IscnPrefix, insert.Version, insert.Owner, insert.Keywords,
@@ -164,6 +435,8 @@ func (batch *Batch) InsertIscn(insert IscnInsert) {
 		insert.Fingerprints, insert.Data, insert.Timestamp, insert.Ipld, insert.Name,
 		// $11 ~ $15
 		insert.Description, insert.Url, stakeholderIDs, stakeholderNames, stakeholderRawJSONs,
+		// $16 ~ $17
+		insert.Height, insert.TxHash,
 	)
 	sql = `
 		INSERT INTO iscn_latest_version AS t (iscn_id_prefix, latest_version)
@@ -176,31 +449,117 @@ func (batch *Batch) InsertIscn(insert IscnInsert) {
 	_ = pubsub.Publish("NewISCN", insert)
 }
 
+// InsertIscnEvent records the creation, update or ownership change of an ISCN record
+func (batch *Batch) InsertIscnEvent(e IscnEvent) {
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		iscn_id_prefix = EXCLUDED.iscn_id_prefix,
+		version = EXCLUDED.version,
+		sender = EXCLUDED.sender,
+		prev_owner = EXCLUDED.prev_owner,
+		new_owner = EXCLUDED.new_owner,
+		height = EXCLUDED.height,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO iscn_event (
+		action, iscn_id, iscn_id_prefix, version, sender,
+		prev_owner, new_owner, height, tx_hash, msg_index,
+		timestamp
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (tx_hash, msg_index, action, iscn_id) %s
+	`, conflict)
+	prevOwner := e.PrevOwner
+	if prevOwner != "" {
+		prevOwner = utils.NormalizeAddress(prevOwner, MainAddressPrefix)
+	}
+	batch.Batch.Queue(sql,
+		e.Action, e.IscnId, e.IscnIdPrefix, e.Version, utils.NormalizeAddress(e.Sender, MainAddressPrefix),
+		prevOwner, utils.NormalizeAddress(e.NewOwner, MainAddressPrefix), e.Height, e.TxHash, e.MsgIndex,
+		e.Timestamp,
+	)
+}
+
 func (batch *Batch) UpdateMetaHeight(key string, height int64) {
 	logger.L.Debugf("Update %s to %d\n", key, height)
 	batch.Batch.Queue(`UPDATE meta SET height = $2 WHERE id = $1`, key, height)
 }
 
-func (batch *Batch) InsertNftClass(c NftClass) {
+// UpsertMetaHeight is UpdateMetaHeight for keys which may not exist yet
+func (batch *Batch) UpsertMetaHeight(key string, height int64) {
+	logger.L.Debugf("Upsert %s to %d\n", key, height)
+	batch.Batch.Queue(`
+		INSERT INTO meta (id, height) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET height = EXCLUDED.height
+	`, key, height)
+}
+
+func (batch *Batch) DeleteMeta(key string) {
+	batch.Batch.Queue(`DELETE FROM meta WHERE id = $1`, key)
+}
+
+// DeleteNftIncomesInRange deletes the NFT incomes of the transactions in heights [from, to],
+// since incomes are not replaced by re-extraction if the receivers change
+func (batch *Batch) DeleteNftIncomesInRange(from, to int64) {
 	sql := `
+	DELETE FROM nft_income
+	WHERE tx_hash IN (
+		SELECT tx ->> 'txhash'
+		FROM txs
+		WHERE height >= $1 AND height <= $2
+	)
+	`
+	batch.Batch.Queue(sql, from, to)
+}
+
+func (batch *Batch) InsertNftClass(c NftClass) {
+	onConflict := "ON CONFLICT DO NOTHING"
+	if batch.Overwrite {
+		// the latest price is maintained by the NFT events,
+		// and the fields changed by MsgUpdateClass are updated below unless the class is updated later
+		onConflict = `ON CONFLICT (class_id) DO UPDATE SET
+			parent_type = EXCLUDED.parent_type,
+			parent_iscn_id_prefix = EXCLUDED.parent_iscn_id_prefix,
+			parent_account = EXCLUDED.parent_account,
+			created_at = EXCLUDED.created_at,
+			height = EXCLUDED.height,
+			tx_hash = EXCLUDED.tx_hash`
+	}
+	sql := fmt.Sprintf(`
 	INSERT INTO nft_class (
 		class_id, parent_type, parent_iscn_id_prefix, parent_account, name,
 		symbol, description, uri, uri_hash, metadata,
-		config, created_at, latest_price, price_updated_at
+		config, created_at, latest_price, price_updated_at, height,
+		tx_hash
 	)
 	VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-	ON CONFLICT DO NOTHING
-	`
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	%s
+	`, onConflict)
 	batch.Batch.Queue(sql,
 		c.Id, c.Parent.Type, c.Parent.IscnIdPrefix, c.Parent.Account, c.Name,
 		c.Symbol, c.Description, c.URI, c.URIHash, c.Metadata,
-		c.Config, c.CreatedAt, c.LatestPrice, c.PriceUpdatedAt,
+		c.Config, c.CreatedAt, c.LatestPrice, c.PriceUpdatedAt, c.Height,
+		c.TxHash,
 	)
+	if batch.Overwrite {
+		batch.updateNftClassFields(c, c.CreatedAt)
+	}
 	_ = pubsub.Publish("NewNFTClass", c)
 }
 
-func (batch *Batch) UpdateNftClass(c NftClass) {
+// UpdateNftClass updates the NFT class at the time of the transaction,
+// unless it is updated by a later transaction already, e.g. when backfilling older heights
+func (batch *Batch) UpdateNftClass(c NftClass, timestamp time.Time) {
+	batch.updateNftClassFields(c, timestamp)
+	_ = pubsub.Publish("UpdateNFTClass", c)
+}
+
+// updateNftClassFields sets the fields changed by MsgUpdateClass,
+// unless the class is updated by a transaction later than the timestamp
+func (batch *Batch) updateNftClassFields(c NftClass, timestamp time.Time) {
 	sql := `
 	UPDATE nft_class
 	SET name = $1, 
@@ -211,12 +570,17 @@ func (batch *Batch) UpdateNftClass(c NftClass) {
 		metadata = $6,
 		config = $7
 	WHERE class_id = $8
+		AND NOT EXISTS (
+			SELECT 1 FROM nft_event
+			WHERE class_id = $8
+				AND action = $10
+				AND timestamp > $9
+		)
 	`
 	batch.Batch.Queue(sql,
 		c.Name, c.Symbol, c.Description, c.URI, c.URIHash,
-		c.Metadata, c.Config, c.Id,
+		c.Metadata, c.Config, c.Id, timestamp, ACTION_UPDATE_CLASS,
 	)
-	_ = pubsub.Publish("UpdateNFTClass", c)
 }
 
 func (batch *Batch) InsertNft(n Nft) {
@@ -224,16 +588,73 @@ func (batch *Batch) InsertNft(n Nft) {
 	if err == nil {
 		n.Owner = convertedOwner
 	}
-	sql := `
+	// an NFT minted again after being burned replaces the burned one,
+	// while a burn or transfer after the mint is kept when overwriting
+	onConflict := `ON CONFLICT (class_id, nft_id) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			uri = EXCLUDED.uri,
+			uri_hash = EXCLUDED.uri_hash,
+			metadata = EXCLUDED.metadata,
+			height = EXCLUDED.height,
+			tx_hash = EXCLUDED.tx_hash,
+			burned_height = NULL,
+			owner_height = EXCLUDED.owner_height
+		WHERE nft.burned_height <= EXCLUDED.height`
+	if batch.Overwrite {
+		onConflict = `ON CONFLICT (class_id, nft_id) DO UPDATE SET
+			uri = EXCLUDED.uri,
+			uri_hash = EXCLUDED.uri_hash,
+			metadata = EXCLUDED.metadata,
+			height = EXCLUDED.height,
+			tx_hash = EXCLUDED.tx_hash,
+			burned_height = CASE WHEN nft.burned_height > EXCLUDED.height THEN nft.burned_height END,
+			owner = CASE WHEN nft.owner_height > EXCLUDED.owner_height THEN nft.owner ELSE EXCLUDED.owner END,
+			owner_height = GREATEST(nft.owner_height, EXCLUDED.owner_height)`
+	}
+	sql := fmt.Sprintf(`
 	INSERT INTO nft
-	(nft_id, class_id, owner, uri, uri_hash, metadata)
+	(nft_id, class_id, owner, uri, uri_hash, metadata, height, tx_hash, owner_height)
 	VALUES
-	($1, $2, $3, $4, $5, $6)
-	ON CONFLICT DO NOTHING`
-	batch.Batch.Queue(sql, n.NftId, n.ClassId, n.Owner, n.Uri, n.UriHash, n.Metadata)
+	($1, $2, $3, $4, $5, $6, $7, $8, $7)
+	%s`, onConflict)
+	batch.Batch.Queue(sql, n.NftId, n.ClassId, n.Owner, n.Uri, n.UriHash, n.Metadata, n.Height, n.TxHash)
 	_ = pubsub.Publish("NewNFT", n)
 }
 
+// TransferNft changes the owner of the NFT, unless it is changed by a later transaction already,
+// e.g. when re-extracting or backfilling older heights
+func (batch *Batch) TransferNft(classId, nftId, owner string, height int64) {
+	sql := `
+	UPDATE nft
+	SET owner = $3, owner_height = $4
+	WHERE class_id = $1 AND nft_id = $2
+		AND owner_height <= $4
+	`
+	batch.Batch.Queue(sql, classId, nftId, owner, height)
+}
+
+// TransferIscn changes the owner of the ISCN record, unless it is changed by a later transaction already
+func (batch *Batch) TransferIscn(iscnId, owner string, height int64) {
+	sql := `
+	UPDATE iscn
+	SET owner = $2, owner_height = $3
+	WHERE iscn_id = $1
+		AND owner_height <= $3
+	`
+	batch.Batch.Queue(sql, iscnId, owner, height)
+}
+
+// BurnNft marks the NFT as burned at the height, keeping its last owner
+func (batch *Batch) BurnNft(classId, nftId string, height int64) {
+	sql := `
+	UPDATE nft
+	SET burned_height = $3
+	WHERE class_id = $1 AND nft_id = $2
+		AND height <= $3
+	`
+	batch.Batch.Queue(sql, classId, nftId, height)
+}
+
 func (batch *Batch) InsertNftEvent(e NftEvent) {
 	convertedSender, err := utils.ConvertAddressPrefix(e.Sender, MainAddressPrefix)
 	if err == nil {
@@ -243,7 +664,18 @@ func (batch *Batch) InsertNftEvent(e NftEvent) {
 	if err == nil {
 		e.Receiver = convertedReceiver
 	}
-	sql := `
+	onConflict := "ON CONFLICT DO NOTHING"
+	if batch.Overwrite {
+		// the existing row is updated in place to keep its id, so the order of events is not changed
+		onConflict = `ON CONFLICT (action, class_id, nft_id, tx_hash) DO UPDATE SET
+			sender = EXCLUDED.sender,
+			receiver = EXCLUDED.receiver,
+			events = EXCLUDED.events,
+			timestamp = EXCLUDED.timestamp,
+			price = EXCLUDED.price,
+			memo = EXCLUDED.memo`
+	}
+	sql := fmt.Sprintf(`
 	INSERT INTO nft_event (
 		action, class_id, nft_id, sender, receiver,
 		events, tx_hash, timestamp, price, memo,
@@ -263,12 +695,13 @@ func (batch *Batch) InsertNftEvent(e NftEvent) {
 			LIMIT 1)
 		, '')
 	)
-	ON CONFLICT DO NOTHING`
+	%s`, onConflict)
 	batch.Batch.Queue(sql,
 		e.Action, e.ClassId, e.NftId, e.Sender, e.Receiver,
 		utils.GetEventStrings(e.Events), e.TxHash, e.Timestamp, e.Price, e.Memo,
 	)
 
+	// prices of transactions older than the latest price are skipped, e.g. when re-extracting or backfilling older heights
 	if e.Price > 0 {
 		nftSql := `
 			UPDATE nft
@@ -277,6 +710,7 @@ func (batch *Batch) InsertNftEvent(e NftEvent) {
 			WHERE
 				class_id = $3
 				AND nft_id = $4
+				AND (price_updated_at IS NULL OR price_updated_at <= $2)
 		`
 		batch.Batch.Queue(nftSql, e.Price, e.Timestamp, e.ClassId, e.NftId)
 		nftClassSql := `
@@ -285,42 +719,85 @@ func (batch *Batch) InsertNftEvent(e NftEvent) {
 				price_updated_at = $2
 			WHERE
 				class_id = $3
+				AND (price_updated_at IS NULL OR price_updated_at <= $2)
 		`
 		batch.Batch.Queue(nftClassSql, e.Price, e.Timestamp, e.ClassId)
 	}
 	_ = pubsub.Publish("NewNFTEvent", e)
 }
 
+// InsertNFTMarketplaceItem creates or updates the listing or offer, unless it is updated by a later transaction already,
+// e.g. when backfilling older heights
 func (batch *Batch) InsertNFTMarketplaceItem(item NftMarketplaceItem) {
 	sql := `
-	INSERT INTO nft_marketplace (type, class_id, nft_id, creator, price, expiration)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	INSERT INTO nft_marketplace (type, class_id, nft_id, creator, price, expiration, height, tx_hash)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	ON CONFLICT (type, class_id, nft_id, creator) DO UPDATE SET
 		price = EXCLUDED.price,
-		expiration = EXCLUDED.expiration
+		expiration = EXCLUDED.expiration,
+		height = EXCLUDED.height,
+		tx_hash = EXCLUDED.tx_hash
+	WHERE nft_marketplace.height <= EXCLUDED.height
 	`
-	batch.Batch.Queue(sql, item.Type, item.ClassId, item.NftId, item.Creator, item.Price, item.Expiration)
+	batch.Batch.Queue(sql,
+		item.Type, item.ClassId, item.NftId, item.Creator, item.Price,
+		item.Expiration, item.Height, item.TxHash,
+	)
 	_ = pubsub.Publish("NewNFTMarketplaceItem", item)
 }
 
 func (batch *Batch) InsertNftIncome(income NftIncome) {
 	sql := `
-	INSERT INTO nft_income (class_id, nft_id, tx_hash, address, amount, is_royalty)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	INSERT INTO nft_income (class_id, nft_id, tx_hash, address, amount, is_royalty, height)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	batch.Batch.Queue(sql, income.ClassId, income.NftId, income.TxHash, income.Address, income.Amount, income.IsRoyalty, income.Height)
+	_ = pubsub.Publish("NewNFTIncome", income)
+}
+
+// InsertGrantedNftIncome inserts the income only if the grant of the MsgExec paying it is in authz_grant,
+// including the grants queued earlier in the batch.
+// The grant must be neither revoked nor expired at the time of the income, except being used up by the MsgExec paying it.
+// If grantsMetaKey is empty, authz_grant must be extracted up to the transaction of the income, e.g. together in the same batch.
+// Otherwise the grant is verified only if the meta height of grantsMetaKey has reached the income, and the income is inserted unverified before that.
+func (batch *Batch) InsertGrantedNftIncome(income NftIncome, grant AuthzGrantKey, timestamp time.Time, grantsMetaKey string) {
+	sql := `
+	INSERT INTO nft_income (class_id, nft_id, tx_hash, address, amount, is_royalty, height)
+	SELECT $1, $2, $3, $4, $5, $6, $10
+	WHERE (
+		$12 != ''
+		AND NOT EXISTS (SELECT 1 FROM meta WHERE id = $12 AND height >= $10)
+	) OR EXISTS (
+		SELECT 1 FROM authz_grant
+		WHERE granter = $7 AND grantee = $8 AND msg_type_url = $9
+			AND (
+				revoked_height IS NULL
+				OR revoked_height > $10
+				OR (revoked_height = $10 AND revoked_tx_hash = $3)
+			)
+			AND (expiration IS NULL OR expiration > $11)
+	)
 	`
-	batch.Batch.Queue(sql, income.ClassId, income.NftId, income.TxHash, income.Address, income.Amount, income.IsRoyalty)
+	batch.Batch.Queue(sql,
+		income.ClassId, income.NftId, income.TxHash, income.Address, income.Amount, income.IsRoyalty,
+		utils.NormalizeAddress(grant.Granter, MainAddressPrefix), utils.NormalizeAddress(grant.Grantee, MainAddressPrefix), grant.MsgTypeUrl, income.Height,
+		timestamp, grantsMetaKey,
+	)
 	_ = pubsub.Publish("NewNFTIncome", income)
 }
 
+// DeleteNFTMarketplaceItemSilently deletes the listings or offers of the NFT created before the height of the item,
+// so those created by later transactions are kept, e.g. when backfilling older heights
 func (batch *Batch) DeleteNFTMarketplaceItemSilently(item NftMarketplaceItem) {
 	sql := `
 	DELETE FROM nft_marketplace
 	WHERE
 		type = $1 AND
 		class_id = $2 AND
-		nft_id = $3
+		nft_id = $3 AND
+		height <= $4
 	`
-	batch.Batch.Queue(sql, item.Type, item.ClassId, item.NftId)
+	batch.Batch.Queue(sql, item.Type, item.ClassId, item.NftId, item.Height)
 }
 
 func (batch *Batch) DeleteNFTMarketplaceItem(item NftMarketplaceItem) {
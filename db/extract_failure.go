@@ -0,0 +1,196 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+// ExtractError is the error returned by a processor when extracting an event of a message
+type ExtractError struct {
+	// index of the message in the transaction, for messages inside MsgExec it is the index of the MsgExec
+	MsgIndex int
+	// empty for processors running on every message
+	EventType string
+	Processor string
+	Err       error
+}
+
+func (e *ExtractError) Error() string {
+	return fmt.Sprintf("processor %s failed on event %s of message %d: %s", e.Processor, e.EventType, e.MsgIndex, e.Err.Error())
+}
+
+func (e *ExtractError) Unwrap() error {
+	return e.Err
+}
+
+// ExtractErrors collects the errors of all failed processors in a transaction, so one failed processor does not stop the others
+type ExtractErrors []*ExtractError
+
+func (errs ExtractErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// HandleExtractError logs the error returned by the extractor,
+// and records it in the extract_failures table through the batch of ctx, so it can be retried later
+func HandleExtractError(ctx EventContext, err error) {
+	logger.L.Errorw("Handle message failed", "error", err, "height", ctx.Height, "txhash", ctx.TxHash)
+	var extractErrs ExtractErrors
+	if errors.As(err, &extractErrs) {
+		for _, extractErr := range extractErrs {
+			ctx.Batch.InsertExtractFailure(ExtractFailure{
+				Height:    ctx.Height,
+				TxHash:    ctx.TxHash,
+				MsgIndex:  extractErr.MsgIndex,
+				EventType: extractErr.EventType,
+				Processor: extractErr.Processor,
+				Error:     extractErr.Err.Error(),
+			})
+		}
+		return
+	}
+	var extractErr *ExtractError
+	if errors.As(err, &extractErr) {
+		ctx.Batch.InsertExtractFailure(ExtractFailure{
+			Height:    ctx.Height,
+			TxHash:    ctx.TxHash,
+			MsgIndex:  extractErr.MsgIndex,
+			EventType: extractErr.EventType,
+			Processor: extractErr.Processor,
+			Error:     extractErr.Err.Error(),
+		})
+		return
+	}
+	// not from a specific processor, the whole transaction should be retried
+	ctx.Batch.InsertExtractFailure(ExtractFailure{
+		Height:   ctx.Height,
+		TxHash:   ctx.TxHash,
+		MsgIndex: -1,
+		Error:    err.Error(),
+	})
+}
+
+func (batch *Batch) InsertExtractFailure(f ExtractFailure) {
+	sql := `
+	INSERT INTO extract_failures (height, tx_hash, msg_index, event_type, processor, error)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (tx_hash, msg_index, event_type, processor) DO UPDATE SET
+		height = EXCLUDED.height,
+		error = EXCLUDED.error,
+		updated_at = NOW(),
+		resolved_at = NULL
+	`
+	batch.Batch.Queue(sql, f.Height, f.TxHash, f.MsgIndex, f.EventType, f.Processor, f.Error)
+}
+
+func (batch *Batch) ResolveExtractFailure(id int64) {
+	sql := `
+	UPDATE extract_failures
+	SET retry_count = retry_count + 1,
+		updated_at = NOW(),
+		resolved_at = NOW()
+	WHERE id = $1
+	`
+	batch.Batch.Queue(sql, id)
+}
+
+func (batch *Batch) UpdateExtractFailureError(id int64, errMsg string) {
+	sql := `
+	UPDATE extract_failures
+	SET retry_count = retry_count + 1,
+		error = $2,
+		updated_at = NOW()
+	WHERE id = $1
+	`
+	batch.Batch.Queue(sql, id, errMsg)
+}
+
+const extractFailureColumns = `id, height, tx_hash, msg_index, event_type, processor, error, retry_count, created_at, updated_at, resolved_at`
+
+func scanExtractFailure(row pgx.Row) (ExtractFailure, error) {
+	var f ExtractFailure
+	err := row.Scan(
+		&f.Id, &f.Height, &f.TxHash, &f.MsgIndex, &f.EventType,
+		&f.Processor, &f.Error, &f.RetryCount, &f.CreatedAt, &f.UpdatedAt,
+		&f.ResolvedAt,
+	)
+	return f, err
+}
+
+func GetExtractFailures(conn *pgxpool.Conn, q QueryExtractFailuresRequest, p PageRequest) (QueryExtractFailuresResponse, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM extract_failures
+		WHERE ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ((resolved_at IS NOT NULL) = $4)
+			AND ($5 = '' OR tx_hash = $5)
+			AND ($6 = '' OR processor = $6)
+		ORDER BY id %s
+		LIMIT $3
+	`, extractFailureColumns, p.Order())
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	rows, err := conn.Query(ctx, sql, p.After(), p.Before(), p.Limit, q.Resolved, q.TxHash, q.Processor)
+	if err != nil {
+		logger.L.Errorw("Failed to query extract failures", "error", err, "q", q)
+		return QueryExtractFailuresResponse{}, fmt.Errorf("query extract failures error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryExtractFailuresResponse{
+		Failures: make([]ExtractFailure, 0),
+	}
+	for rows.Next() {
+		f, err := scanExtractFailure(rows)
+		if err != nil {
+			logger.L.Errorw("Failed to scan extract failure", "error", err, "q", q)
+			return QueryExtractFailuresResponse{}, fmt.Errorf("scan extract failure error: %w", err)
+		}
+		res.Failures = append(res.Failures, f)
+		res.Pagination.NextKey = uint64(f.Id)
+	}
+	res.Pagination.Count = len(res.Failures)
+	return res, nil
+}
+
+// GetUnresolvedExtractFailures returns the unresolved failures with the given ids, or all unresolved failures if ids is empty
+func GetUnresolvedExtractFailures(conn *pgxpool.Conn, ids []int64) ([]ExtractFailure, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM extract_failures
+		WHERE resolved_at IS NULL
+			AND (cardinality($1::bigint[]) = 0 OR id = ANY($1))
+		ORDER BY id
+	`, extractFailureColumns)
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+	if ids == nil {
+		ids = []int64{}
+	}
+	rows, err := conn.Query(ctx, sql, ids)
+	if err != nil {
+		logger.L.Errorw("Failed to query unresolved extract failures", "error", err, "ids", ids)
+		return nil, fmt.Errorf("query unresolved extract failures error: %w", err)
+	}
+	defer rows.Close()
+
+	res := []ExtractFailure{}
+	for rows.Next() {
+		f, err := scanExtractFailure(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan extract failure error: %w", err)
+		}
+		res = append(res, f)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// InsertFeegrant inserts the allowance, replacing the previous allowance of the same granter and grantee as the chain does.
+// An allowance older than the existing one is skipped, so the allowances are not rolled back when re-extracting part of the heights.
+func (batch *Batch) InsertFeegrant(g Feegrant) {
+	sql := `
+	INSERT INTO feegrant (
+		granter, grantee, allowance_type, allowance, spend_limit,
+		expiration, height, tx_hash, timestamp
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (granter, grantee) DO UPDATE SET
+		allowance_type = EXCLUDED.allowance_type,
+		allowance = EXCLUDED.allowance,
+		spend_limit = EXCLUDED.spend_limit,
+		expiration = EXCLUDED.expiration,
+		height = EXCLUDED.height,
+		tx_hash = EXCLUDED.tx_hash,
+		timestamp = EXCLUDED.timestamp,
+		revoked_height = NULL,
+		revoked_tx_hash = '',
+		revoked_timestamp = NULL
+	WHERE feegrant.height <= EXCLUDED.height
+	`
+	var spendLimit []byte
+	if g.SpendLimit != nil {
+		var err error
+		spendLimit, err = json.Marshal(g.SpendLimit)
+		if err != nil {
+			logger.L.Errorw("Failed to marshal spend limit of fee allowance", "error", err, "tx_hash", g.TxHash, "spend_limit", g.SpendLimit)
+			return
+		}
+	}
+	granter := utils.NormalizeAddress(g.Granter, MainAddressPrefix)
+	grantee := utils.NormalizeAddress(g.Grantee, MainAddressPrefix)
+	batch.Batch.Queue(sql,
+		granter, grantee, g.AllowanceType, []byte(g.Allowance), spendLimit,
+		g.Expiration, g.Height, g.TxHash, g.Timestamp,
+	)
+	batch.updateFeegrantFeesSpent(granter, grantee)
+}
+
+// RevokeFeegrant marks the allowance as revoked by MsgRevokeAllowance
+func (batch *Batch) RevokeFeegrant(key FeegrantKey, height int64, txHash string, timestamp time.Time) {
+	sql := `
+	UPDATE feegrant
+	SET revoked_height = $3, revoked_tx_hash = $4, revoked_timestamp = $5
+	WHERE granter = $1 AND grantee = $2
+		AND height <= $3
+	`
+	batch.Batch.Queue(sql, utils.NormalizeAddress(key.Granter, MainAddressPrefix), utils.NormalizeAddress(key.Grantee, MainAddressPrefix), height, txHash, timestamp)
+}
+
+// InsertFeegrantUsage inserts the fee paid by the allowance and updates the fees spent of the allowance.
+// The fees spent are summed up again from feegrant_usage, so inserting the same usage again does not count it twice.
+func (batch *Batch) InsertFeegrantUsage(u FeegrantUsage) {
+	fee, err := json.Marshal(u.Fee)
+	if err != nil {
+		logger.L.Errorw("Failed to marshal fee of fee allowance usage", "error", err, "tx_hash", u.TxHash, "fee", u.Fee)
+		return
+	}
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		granter = EXCLUDED.granter,
+		grantee = EXCLUDED.grantee,
+		fee = EXCLUDED.fee,
+		height = EXCLUDED.height,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO feegrant_usage (granter, grantee, fee, height, tx_hash, timestamp)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (tx_hash) %s
+	`, conflict)
+	granter := utils.NormalizeAddress(u.Granter, MainAddressPrefix)
+	grantee := utils.NormalizeAddress(u.Grantee, MainAddressPrefix)
+	batch.Batch.Queue(sql, granter, grantee, fee, u.Height, u.TxHash, u.Timestamp)
+	batch.updateFeegrantFeesSpent(granter, grantee)
+}
+
+// updateFeegrantFeesSpent sums up the fees paid by the allowance after it is granted.
+// The fee of the transaction granting the allowance is deducted before the grant, so it is not counted.
+func (batch *Batch) updateFeegrantFeesSpent(granter, grantee string) {
+	sql := `
+	WITH usages AS (
+		SELECT u.id, u.fee, u.height, u.tx_hash, u.timestamp
+		FROM feegrant_usage u
+		JOIN feegrant g ON u.granter = g.granter AND u.grantee = g.grantee
+		WHERE g.granter = $1 AND g.grantee = $2 AND u.height > g.height
+	), last_used AS (
+		SELECT height, tx_hash, timestamp
+		FROM usages
+		ORDER BY height DESC, id DESC
+		LIMIT 1
+	), spent AS (
+		SELECT c->>'denom' AS denom, SUM((c->>'amount')::numeric) AS amount
+		FROM usages, jsonb_array_elements(usages.fee) AS c
+		GROUP BY c->>'denom'
+	)
+	UPDATE feegrant SET
+		fees_spent = (
+			SELECT COALESCE(jsonb_agg(jsonb_build_object('denom', denom, 'amount', amount::text) ORDER BY denom), '[]')
+			FROM spent
+		),
+		last_used_height = (SELECT height FROM last_used),
+		last_used_tx_hash = COALESCE((SELECT tx_hash FROM last_used), ''),
+		last_used_timestamp = (SELECT timestamp FROM last_used)
+	WHERE granter = $1 AND grantee = $2
+	`
+	batch.Batch.Queue(sql, granter, grantee)
+}
+
+// feegrantSpendLimitLeft returns the spend limit minus the fees spent, with 0 for the denoms used up
+func feegrantSpendLimitLeft(spendLimit, feesSpent types.Coins) types.Coins {
+	left := types.Coins{}
+	for _, coin := range spendLimit {
+		amount := coin.Amount.Sub(feesSpent.AmountOf(coin.Denom))
+		if amount.IsNegative() {
+			amount = types.ZeroInt()
+		}
+		left = append(left, types.Coin{Denom: coin.Denom, Amount: amount})
+	}
+	return left
+}
+
+// GetFeegrants returns the allowances neither revoked nor expired
+func GetFeegrants(conn *pgxpool.Conn, q QueryFeegrantsRequest, p PageRequest) (QueryFeegrantsResponse, error) {
+	granterVariations := utils.ConvertAddressPrefixes(q.Granter, AddressPrefixes)
+	granteeVariations := utils.ConvertAddressPrefixes(q.Grantee, AddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT id, granter, grantee, allowance_type, allowance,
+			spend_limit, fees_spent, expiration, height, tx_hash,
+			timestamp, last_used_height, last_used_tx_hash, last_used_timestamp
+		FROM feegrant
+		WHERE revoked_height IS NULL
+			AND (expiration IS NULL OR expiration > NOW())
+			AND ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR granter = ANY($4))
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR grantee = ANY($5))
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(ctx, sql, p.After(), p.Before(), p.Limit, granterVariations, granteeVariations)
+	if err != nil {
+		logger.L.Errorw("Failed to query fee allowances", "error", err, "q", q)
+		return QueryFeegrantsResponse{}, fmt.Errorf("query fee allowances error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryFeegrantsResponse{
+		Feegrants: make([]Feegrant, 0),
+	}
+	for rows.Next() {
+		var g Feegrant
+		var allowance, spendLimit, feesSpent []byte
+		if err = rows.Scan(
+			&res.Pagination.NextKey, &g.Granter, &g.Grantee, &g.AllowanceType, &allowance,
+			&spendLimit, &feesSpent, &g.Expiration, &g.Height, &g.TxHash,
+			&g.Timestamp, &g.LastUsedHeight, &g.LastUsedTxHash, &g.LastUsedTimestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan fee allowance", "error", err, "q", q)
+			return QueryFeegrantsResponse{}, fmt.Errorf("scan fee allowance error: %w", err)
+		}
+		g.Allowance = allowance
+		if err = json.Unmarshal(feesSpent, &g.FeesSpent); err != nil {
+			return QueryFeegrantsResponse{}, fmt.Errorf("unmarshal fees spent error: %w", err)
+		}
+		if spendLimit != nil {
+			if err = json.Unmarshal(spendLimit, &g.SpendLimit); err != nil {
+				return QueryFeegrantsResponse{}, fmt.Errorf("unmarshal spend limit error: %w", err)
+			}
+			g.SpendLimitLeft = feegrantSpendLimitLeft(g.SpendLimit, g.FeesSpent)
+		}
+		res.Feegrants = append(res.Feegrants, g)
+	}
+	res.Pagination.Count = len(res.Feegrants)
+	return res, nil
+}
@@ -0,0 +1,226 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// NormalizeVoteOption converts the option to the name of the enum, e.g. yes -> VOTE_OPTION_YES
+func NormalizeVoteOption(option string) string {
+	option = strings.ToUpper(option)
+	if option == "" || strings.HasPrefix(option, "VOTE_OPTION_") {
+		return option
+	}
+	return "VOTE_OPTION_" + strings.ReplaceAll(option, " ", "_")
+}
+
+func (batch *Batch) InsertGovProposal(p GovProposal) {
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		proposer = EXCLUDED.proposer,
+		proposal_types = EXCLUDED.proposal_types,
+		title = EXCLUDED.title,
+		description = EXCLUDED.description,
+		metadata = EXCLUDED.metadata,
+		content = EXCLUDED.content,
+		height = EXCLUDED.height,
+		tx_hash = EXCLUDED.tx_hash,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO gov_proposal (
+		proposal_id, proposer, proposal_types, title, description,
+		metadata, content, height, tx_hash, timestamp
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT (proposal_id) %s
+	`, conflict)
+	batch.Batch.Queue(sql,
+		p.ProposalId, utils.NormalizeAddress(p.Proposer, MainAddressPrefix), p.ProposalTypes, p.Title, p.Description,
+		p.Metadata, []byte(p.Content), p.Height, p.TxHash, p.Timestamp,
+	)
+}
+
+func (batch *Batch) InsertGovDeposit(d GovDeposit) {
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		amount = EXCLUDED.amount,
+		height = EXCLUDED.height,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO gov_deposit (proposal_id, depositor, denom, amount, height, tx_hash, msg_index, timestamp)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (tx_hash, msg_index, proposal_id, depositor, denom) %s
+	`, conflict)
+	batch.Batch.Queue(sql, d.ProposalId, utils.NormalizeAddress(d.Depositor, MainAddressPrefix), d.Denom, d.Amount, d.Height, d.TxHash, d.MsgIndex, d.Timestamp)
+}
+
+func (batch *Batch) InsertGovVote(v GovVote) {
+	options, err := json.Marshal(v.Options)
+	if err != nil {
+		// should not happen for plain strings
+		logger.L.Errorw("Failed to marshal vote options", "error", err, "vote", v)
+		return
+	}
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		options = EXCLUDED.options,
+		height = EXCLUDED.height,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO gov_vote (proposal_id, voter, options, height, tx_hash, msg_index, timestamp)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (tx_hash, msg_index, proposal_id, voter) %s
+	`, conflict)
+	batch.Batch.Queue(sql, v.ProposalId, utils.NormalizeAddress(v.Voter, MainAddressPrefix), options, v.Height, v.TxHash, v.MsgIndex, v.Timestamp)
+}
+
+func GetGovProposals(conn *pgxpool.Conn, q QueryGovProposalsRequest, p PageRequest) (QueryGovProposalsResponse, error) {
+	proposerVariations := utils.ConvertAddressPrefixes(q.Proposer, AddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT proposal_id, proposer, proposal_types, title, description,
+			metadata, content, height, tx_hash, timestamp
+		FROM gov_proposal
+		WHERE ($1 = 0 OR proposal_id > $1)
+			AND ($2 = 0 OR proposal_id < $2)
+			AND ($4 = 0 OR proposal_id = $4)
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR proposer = ANY($5))
+		ORDER BY proposal_id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(ctx, sql, p.After(), p.Before(), p.Limit, q.ProposalId, proposerVariations)
+	if err != nil {
+		logger.L.Errorw("Failed to query gov proposals", "error", err, "q", q)
+		return QueryGovProposalsResponse{}, fmt.Errorf("query gov proposals error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryGovProposalsResponse{
+		Proposals: make([]GovProposal, 0),
+	}
+	for rows.Next() {
+		var proposal GovProposal
+		var content []byte
+		if err = rows.Scan(
+			&proposal.ProposalId, &proposal.Proposer, &proposal.ProposalTypes, &proposal.Title, &proposal.Description,
+			&proposal.Metadata, &content, &proposal.Height, &proposal.TxHash, &proposal.Timestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan gov proposal", "error", err, "q", q)
+			return QueryGovProposalsResponse{}, fmt.Errorf("scan gov proposal error: %w", err)
+		}
+		proposal.Content = content
+		res.Proposals = append(res.Proposals, proposal)
+		res.Pagination.NextKey = proposal.ProposalId
+	}
+	res.Pagination.Count = len(res.Proposals)
+	return res, nil
+}
+
+func GetGovDeposits(conn *pgxpool.Conn, q QueryGovDepositsRequest, p PageRequest) (QueryGovDepositsResponse, error) {
+	depositorVariations := utils.ConvertAddressPrefixes(q.Depositor, AddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT id, proposal_id, depositor, denom, amount::text,
+			height, tx_hash, msg_index, timestamp
+		FROM gov_deposit
+		WHERE ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4 = 0 OR proposal_id = $4)
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR depositor = ANY($5))
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(ctx, sql, p.After(), p.Before(), p.Limit, q.ProposalId, depositorVariations)
+	if err != nil {
+		logger.L.Errorw("Failed to query gov deposits", "error", err, "q", q)
+		return QueryGovDepositsResponse{}, fmt.Errorf("query gov deposits error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryGovDepositsResponse{
+		Deposits: make([]GovDeposit, 0),
+	}
+	for rows.Next() {
+		var d GovDeposit
+		if err = rows.Scan(
+			&res.Pagination.NextKey, &d.ProposalId, &d.Depositor, &d.Denom, &d.Amount,
+			&d.Height, &d.TxHash, &d.MsgIndex, &d.Timestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan gov deposit", "error", err, "q", q)
+			return QueryGovDepositsResponse{}, fmt.Errorf("scan gov deposit error: %w", err)
+		}
+		res.Deposits = append(res.Deposits, d)
+	}
+	res.Pagination.Count = len(res.Deposits)
+	return res, nil
+}
+
+func GetGovVotes(conn *pgxpool.Conn, q QueryGovVotesRequest, p PageRequest) (QueryGovVotesResponse, error) {
+	voterVariations := utils.ConvertAddressPrefixes(q.Voter, AddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT id, proposal_id, voter, options, height,
+			tx_hash, msg_index, timestamp
+		FROM gov_vote
+		WHERE ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4 = 0 OR proposal_id = $4)
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR voter = ANY($5))
+			AND ($6 = '' OR options @> jsonb_build_array(jsonb_build_object('option', $6::text)))
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(
+		ctx, sql,
+		p.After(), p.Before(), p.Limit, q.ProposalId, voterVariations,
+		NormalizeVoteOption(q.Option),
+	)
+	if err != nil {
+		logger.L.Errorw("Failed to query gov votes", "error", err, "q", q)
+		return QueryGovVotesResponse{}, fmt.Errorf("query gov votes error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryGovVotesResponse{
+		Votes: make([]GovVote, 0),
+	}
+	for rows.Next() {
+		var v GovVote
+		var options []byte
+		if err = rows.Scan(
+			&res.Pagination.NextKey, &v.ProposalId, &v.Voter, &options, &v.Height,
+			&v.TxHash, &v.MsgIndex, &v.Timestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan gov vote", "error", err, "q", q)
+			return QueryGovVotesResponse{}, fmt.Errorf("scan gov vote error: %w", err)
+		}
+		if err = json.Unmarshal(options, &v.Options); err != nil {
+			logger.L.Errorw("Failed to parse vote options", "error", err, "options", string(options))
+			return QueryGovVotesResponse{}, fmt.Errorf("parse vote options error: %w", err)
+		}
+		res.Votes = append(res.Votes, v)
+	}
+	res.Pagination.Count = len(res.Votes)
+	return res, nil
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// InsertIbcTransfer inserts the transfer together with the trace of its denom.
+// When overwriting, the status of outgoing transfers already acknowledged or timed out is kept.
+func (batch *Batch) InsertIbcTransfer(t IbcTransfer) {
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		status = CASE WHEN EXCLUDED.status = 'pending' THEN ibc_transfer.status ELSE EXCLUDED.status END,
+		error = CASE WHEN EXCLUDED.status = 'pending' THEN ibc_transfer.error ELSE EXCLUDED.error END,
+		sender = EXCLUDED.sender,
+		receiver = EXCLUDED.receiver,
+		denom = EXCLUDED.denom,
+		denom_path = EXCLUDED.denom_path,
+		base_denom = EXCLUDED.base_denom,
+		amount = EXCLUDED.amount,
+		memo = EXCLUDED.memo,
+		height = EXCLUDED.height,
+		tx_hash = EXCLUDED.tx_hash,
+		msg_index = EXCLUDED.msg_index,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO ibc_transfer (
+		direction, status, sequence, src_port, src_channel,
+		dst_port, dst_channel, sender, receiver, denom,
+		denom_path, base_denom, amount, memo, error,
+		height, tx_hash, msg_index, timestamp
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	ON CONFLICT (direction, src_port, src_channel, dst_port, dst_channel, sequence) %s
+	`, conflict)
+	batch.Batch.Queue(sql,
+		t.Direction, t.Status, t.Sequence, t.SrcPort, t.SrcChannel,
+		t.DstPort, t.DstChannel, t.Sender, t.Receiver, t.Denom,
+		t.DenomPath, t.BaseDenom, t.Amount, t.Memo, t.Error,
+		t.Height, t.TxHash, t.MsgIndex, t.Timestamp,
+	)
+	if t.DenomPath != "" {
+		batch.InsertIbcDenomTrace(IbcDenomTrace{
+			Denom:     t.Denom,
+			Path:      t.DenomPath,
+			BaseDenom: t.BaseDenom,
+		})
+	}
+}
+
+func (batch *Batch) InsertIbcDenomTrace(trace IbcDenomTrace) {
+	sql := `
+	INSERT INTO ibc_denom_trace (denom, path, base_denom)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (denom) DO NOTHING
+	`
+	batch.Batch.Queue(sql, trace.Denom, trace.Path, trace.BaseDenom)
+}
+
+// UpdateIbcTransferStatus sets the status of the outgoing transfer of the packet, on acknowledgement or timeout
+func (batch *Batch) UpdateIbcTransferStatus(packet IbcPacket, status IbcTransferStatus, errorMessage string, height int64, txHash string, timestamp time.Time) {
+	sql := `
+	UPDATE ibc_transfer
+	SET status = $1, error = $2, completed_height = $3, completed_tx_hash = $4, completed_timestamp = $5
+	WHERE direction = 'outgoing'
+		AND sequence = $6
+		AND src_port = $7
+		AND src_channel = $8
+		AND dst_port = $9
+		AND dst_channel = $10
+	`
+	batch.Batch.Queue(sql,
+		status, errorMessage, height, txHash, timestamp,
+		packet.Sequence, packet.SrcPort, packet.SrcChannel, packet.DstPort, packet.DstChannel,
+	)
+}
+
+// ibcAddressVariations includes the address itself, since the address on the other chain has a prefix not in AddressPrefixes
+func ibcAddressVariations(addr string) []string {
+	if addr == "" {
+		return nil
+	}
+	variations := utils.ConvertAddressPrefixes(addr, AddressPrefixes)
+	for _, variation := range variations {
+		if variation == addr {
+			return variations
+		}
+	}
+	return append(variations, addr)
+}
+
+func GetIbcTransfers(conn *pgxpool.Conn, q QueryIbcTransfersRequest, p PageRequest) (QueryIbcTransfersResponse, error) {
+	sql := fmt.Sprintf(`
+		SELECT id, direction, status, sequence, src_port,
+			src_channel, dst_port, dst_channel, sender, receiver,
+			denom, denom_path, base_denom, amount::text, memo,
+			error, height, tx_hash, msg_index, timestamp,
+			completed_height, completed_tx_hash, completed_timestamp
+		FROM ibc_transfer
+		WHERE ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR sender = ANY($4) OR receiver = ANY($4))
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR sender = ANY($5))
+			AND ($6::text[] IS NULL OR cardinality($6::text[]) = 0 OR receiver = ANY($6))
+			AND ($7 = '' OR direction = $7)
+			AND ($8::text[] IS NULL OR cardinality($8::text[]) = 0 OR status = ANY($8))
+			AND ($9 = '' OR src_channel = $9 OR dst_channel = $9)
+			AND ($10 = '' OR denom = $10 OR base_denom = $10)
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(
+		ctx, sql,
+		p.After(), p.Before(), p.Limit, ibcAddressVariations(q.Address), ibcAddressVariations(q.Sender),
+		ibcAddressVariations(q.Receiver), q.Direction, q.Status, q.Channel, q.Denom,
+	)
+	if err != nil {
+		logger.L.Errorw("Failed to query IBC transfers", "error", err, "q", q)
+		return QueryIbcTransfersResponse{}, fmt.Errorf("query IBC transfers error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryIbcTransfersResponse{
+		Transfers: make([]IbcTransfer, 0),
+	}
+	for rows.Next() {
+		var t IbcTransfer
+		if err = rows.Scan(
+			&res.Pagination.NextKey, &t.Direction, &t.Status, &t.Sequence, &t.SrcPort,
+			&t.SrcChannel, &t.DstPort, &t.DstChannel, &t.Sender, &t.Receiver,
+			&t.Denom, &t.DenomPath, &t.BaseDenom, &t.Amount, &t.Memo,
+			&t.Error, &t.Height, &t.TxHash, &t.MsgIndex, &t.Timestamp,
+			&t.CompletedHeight, &t.CompletedTxHash, &t.CompletedTimestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan IBC transfer", "error", err, "q", q)
+			return QueryIbcTransfersResponse{}, fmt.Errorf("scan IBC transfer error: %w", err)
+		}
+		res.Transfers = append(res.Transfers, t)
+	}
+	res.Pagination.Count = len(res.Transfers)
+	return res, nil
+}
+
+func GetIbcDenomTraces(conn *pgxpool.Conn, q QueryIbcDenomTracesRequest) (QueryIbcDenomTracesResponse, error) {
+	denom := q.Denom
+	if denom != "" {
+		denom = "ibc/" + strings.ToUpper(strings.TrimPrefix(denom, "ibc/"))
+	}
+	sql := `
+		SELECT denom, path, base_denom
+		FROM ibc_denom_trace
+		WHERE ($1 = '' OR denom = $1)
+			AND ($2 = '' OR base_denom = $2)
+		ORDER BY base_denom, path
+	`
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(ctx, sql, denom, q.BaseDenom)
+	if err != nil {
+		logger.L.Errorw("Failed to query IBC denom traces", "error", err, "q", q)
+		return QueryIbcDenomTracesResponse{}, fmt.Errorf("query IBC denom traces error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryIbcDenomTracesResponse{
+		DenomTraces: make([]IbcDenomTrace, 0),
+	}
+	for rows.Next() {
+		var trace IbcDenomTrace
+		if err = rows.Scan(&trace.Denom, &trace.Path, &trace.BaseDenom); err != nil {
+			logger.L.Errorw("Failed to scan IBC denom trace", "error", err, "q", q)
+			return QueryIbcDenomTracesResponse{}, fmt.Errorf("scan IBC denom trace error: %w", err)
+		}
+		res.DenomTraces = append(res.DenomTraces, trace)
+	}
+	return res, nil
+}
@@ -1,8 +1,10 @@
 package db
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
@@ -17,7 +19,7 @@ func QueryIscn(conn *pgxpool.Conn, query IscnQuery, page PageRequest) (IscnRespo
 	ownerVariations := utils.ConvertAddressPrefixes(query.Owner, AddressPrefixes)
 	stakeholderIdVariataions := utils.ConvertAddressPrefixes(query.StakeholderId, AddressPrefixes)
 	sql := fmt.Sprintf(`
-			SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, iscn.data
+			SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, iscn.data, height, tx_hash
 			FROM iscn
 			JOIN iscn_latest_version
 			ON iscn.iscn_id_prefix = iscn_latest_version.iscn_id_prefix
@@ -34,6 +36,8 @@ func QueryIscn(conn *pgxpool.Conn, query IscnQuery, page PageRequest) (IscnRespo
 				AND ($7 = '' OR sname = $7)
 				AND ($8 = 0 OR id > $8)
 				AND ($9 = 0 OR id < $9)
+				AND ($11 = 0 OR height >= $11)
+				AND ($12 = 0 OR height <= $12)
 			ORDER BY id %s, timestamp
 			LIMIT %d;
 		`, page.Order(), MAX_LIMIT)
@@ -45,7 +49,7 @@ func QueryIscn(conn *pgxpool.Conn, query IscnQuery, page PageRequest) (IscnRespo
 		ctx, sql,
 		query.IscnId, query.IscnIdPrefix, ownerVariations, query.Keywords,
 		query.Fingerprints, stakeholderIdVariataions, query.StakeholderName,
-		page.After(), page.Before(), query.AllIscnVersions,
+		page.After(), page.Before(), query.AllIscnVersions, query.MinHeight, query.MaxHeight,
 	)
 	if err != nil {
 		logger.L.Errorw("query ISCN failed", "error", err, "iscn_query", query)
@@ -61,7 +65,7 @@ func QueryIscnList(conn *pgxpool.Conn, pagination PageRequest, allIscnVersions b
 	defer cancel()
 
 	sql := fmt.Sprintf(`
-		SELECT id, iscn_id, owner, timestamp, ipld, data
+		SELECT id, iscn_id, owner, timestamp, ipld, data, height, tx_hash
 		FROM iscn
 		JOIN iscn_latest_version
 		ON iscn.iscn_id_prefix = iscn_latest_version.iscn_id_prefix
@@ -83,10 +87,10 @@ func QueryIscnList(conn *pgxpool.Conn, pagination PageRequest, allIscnVersions b
 func QueryIscnSearch(conn *pgxpool.Conn, term string, pagination PageRequest, allIscnVersions bool) (IscnResponse, error) {
 	order := pagination.Order()
 	sql := fmt.Sprintf(`
-		SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, data
+		SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, data, height, tx_hash
 		FROM (
 			(
-				SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, iscn.data
+				SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, iscn.data, height, tx_hash
 				FROM iscn
 				JOIN iscn_latest_version
 				ON iscn.iscn_id_prefix = iscn_latest_version.iscn_id_prefix
@@ -105,7 +109,7 @@ func QueryIscnSearch(conn *pgxpool.Conn, term string, pagination PageRequest, al
 			)
 			UNION ALL
 			(
-				SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, iscn.data
+				SELECT DISTINCT ON (id) id, iscn_id, owner, timestamp, ipld, iscn.data, height, tx_hash
 				FROM iscn
 				JOIN iscn_latest_version
 				ON iscn.iscn_id_prefix = iscn_latest_version.iscn_id_prefix
@@ -149,7 +153,9 @@ func parseIscn(rows pgx.Rows, limit int) (IscnResponse, error) {
 		var iscn iscnResponseData
 		var ipld string
 		var data pgtype.JSONB
-		err := rows.Scan(&res.Pagination.NextKey, &iscn.Id, &iscn.Owner, &iscn.RecordTimestamp, &ipld, &data)
+		var height int64
+		var txHash string
+		err := rows.Scan(&res.Pagination.NextKey, &iscn.Id, &iscn.Owner, &iscn.RecordTimestamp, &ipld, &data, &height, &txHash)
 		if err != nil {
 			logger.L.Errorw("scan ISCN row failed", "error", err)
 			return res, fmt.Errorf("scan ISCN failed: %w", err)
@@ -161,10 +167,220 @@ func parseIscn(rows pgx.Rows, limit int) (IscnResponse, error) {
 		}
 
 		res.Records = append(res.Records, iscnResponseRecord{
-			Ipld: ipld,
-			Data: iscn,
+			Ipld:   ipld,
+			Data:   iscn,
+			Height: height,
+			TxHash: txHash,
 		})
 	}
 	res.Pagination.Count = len(res.Records)
 	return res, nil
 }
+
+// GetIscnProvenance returns all the events of the ISCN ID prefix in chronological order
+func GetIscnProvenance(conn *pgxpool.Conn, iscnIdPrefix string) (QueryIscnProvenanceResponse, error) {
+	sql := `
+		SELECT action, iscn_id, iscn_id_prefix, version, sender,
+			prev_owner, new_owner, height, tx_hash, msg_index,
+			timestamp
+		FROM iscn_event
+		WHERE iscn_id_prefix = $1
+		ORDER BY height, id
+	`
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(ctx, sql, iscnIdPrefix)
+	if err != nil {
+		logger.L.Errorw("Failed to query ISCN events", "error", err, "iscn_id_prefix", iscnIdPrefix)
+		return QueryIscnProvenanceResponse{}, fmt.Errorf("query ISCN events error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryIscnProvenanceResponse{
+		IscnIdPrefix: iscnIdPrefix,
+		Events:       make([]IscnEvent, 0),
+	}
+	for rows.Next() {
+		var e IscnEvent
+		if err = rows.Scan(
+			&e.Action, &e.IscnId, &e.IscnIdPrefix, &e.Version, &e.Sender,
+			&e.PrevOwner, &e.NewOwner, &e.Height, &e.TxHash, &e.MsgIndex,
+			&e.Timestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan ISCN event", "error", err, "iscn_id_prefix", iscnIdPrefix)
+			return QueryIscnProvenanceResponse{}, fmt.Errorf("scan ISCN event error: %w", err)
+		}
+		res.Owner = e.NewOwner
+		res.Events = append(res.Events, e)
+	}
+	return res, nil
+}
+
+// GetIscnVersions returns all the versions of the ISCN ID prefix in order, each with the changes from the previous version
+func GetIscnVersions(conn *pgxpool.Conn, iscnIdPrefix string) (QueryIscnVersionsResponse, error) {
+	sql := `
+		SELECT i.iscn_id, i.version, i.owner, i.timestamp, i.ipld,
+			COALESCE(NULLIF(i.height, 0), e.height, 0), COALESCE(NULLIF(i.tx_hash, ''), e.tx_hash, ''), i.data, v.latest_version
+		FROM iscn AS i
+		JOIN iscn_latest_version AS v
+			ON i.iscn_id_prefix = v.iscn_id_prefix
+		-- for the records not backfilled with height and tx hash yet,
+		-- taking the earliest event only so each version is returned once
+		LEFT JOIN LATERAL (
+			SELECT height, tx_hash
+			FROM iscn_event
+			WHERE iscn_id = i.iscn_id AND action IN ('create', 'update')
+			ORDER BY height, id
+			LIMIT 1
+		) AS e ON TRUE
+		WHERE i.iscn_id_prefix = $1
+		ORDER BY i.version
+	`
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(ctx, sql, iscnIdPrefix)
+	if err != nil {
+		logger.L.Errorw("Failed to query ISCN versions", "error", err, "iscn_id_prefix", iscnIdPrefix)
+		return QueryIscnVersionsResponse{}, fmt.Errorf("query ISCN versions error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryIscnVersionsResponse{
+		IscnIdPrefix: iscnIdPrefix,
+		Versions:     make([]IscnVersion, 0),
+	}
+	var prevRecord *iscnResponseData
+	for rows.Next() {
+		var v IscnVersion
+		var data pgtype.JSONB
+		if err = rows.Scan(
+			&v.IscnId, &v.Version, &v.Owner, &v.Timestamp, &v.Ipld,
+			&v.Height, &v.TxHash, &data, &res.LatestVersion,
+		); err != nil {
+			logger.L.Errorw("Failed to scan ISCN version", "error", err, "iscn_id_prefix", iscnIdPrefix)
+			return QueryIscnVersionsResponse{}, fmt.Errorf("scan ISCN version error: %w", err)
+		}
+		var record iscnResponseData
+		if err = json.Unmarshal(data.Bytes, &record); err != nil {
+			logger.L.Errorw("Failed to unmarshal ISCN data", "error", err, "iscn_id", v.IscnId)
+			return QueryIscnVersionsResponse{}, fmt.Errorf("unmarshal ISCN data error: %w", err)
+		}
+		if prevRecord != nil {
+			v.Diff, err = diffIscnRecords(*prevRecord, record)
+			if err != nil {
+				logger.L.Errorw("Failed to diff ISCN versions", "error", err, "iscn_id", v.IscnId)
+				return QueryIscnVersionsResponse{}, fmt.Errorf("diff ISCN versions error: %w", err)
+			}
+		}
+		prevRecord = &record
+		res.Versions = append(res.Versions, v)
+	}
+	return res, nil
+}
+
+func diffIscnRecords(prev, curr iscnResponseData) (*IscnVersionDiff, error) {
+	metadataDiff, err := diffJSONObjects(prev.ContentMetadata, curr.ContentMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("contentMetadata: %w", err)
+	}
+	stakeholdersDiff, err := diffJSONLists(prev.Stakeholders, curr.Stakeholders)
+	if err != nil {
+		return nil, fmt.Errorf("stakeholders: %w", err)
+	}
+	fingerprintsDiff, err := diffJSONLists(prev.ContentFingerprints, curr.ContentFingerprints)
+	if err != nil {
+		return nil, fmt.Errorf("contentFingerprints: %w", err)
+	}
+	return &IscnVersionDiff{
+		ContentMetadata:     metadataDiff,
+		Stakeholders:        stakeholdersDiff,
+		ContentFingerprints: fingerprintsDiff,
+	}, nil
+}
+
+// jsonEqual compares the JSON values regardless of the formatting and the order of object fields
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func diffJSONObjects(prev, curr json.RawMessage) (IscnObjectDiff, error) {
+	diff := IscnObjectDiff{
+		Added:   map[string]json.RawMessage{},
+		Removed: map[string]json.RawMessage{},
+		Changed: map[string]IscnValueChange{},
+	}
+	prevFields := map[string]json.RawMessage{}
+	currFields := map[string]json.RawMessage{}
+	if len(prev) > 0 {
+		if err := json.Unmarshal(prev, &prevFields); err != nil {
+			return diff, err
+		}
+	}
+	if len(curr) > 0 {
+		if err := json.Unmarshal(curr, &currFields); err != nil {
+			return diff, err
+		}
+	}
+	for key, currValue := range currFields {
+		prevValue, ok := prevFields[key]
+		if !ok {
+			diff.Added[key] = currValue
+		} else if !jsonEqual(prevValue, currValue) {
+			diff.Changed[key] = IscnValueChange{From: prevValue, To: currValue}
+		}
+	}
+	for key, prevValue := range prevFields {
+		if _, ok := currFields[key]; !ok {
+			diff.Removed[key] = prevValue
+		}
+	}
+	return diff, nil
+}
+
+func diffJSONLists(prev, curr json.RawMessage) (IscnListDiff, error) {
+	diff := IscnListDiff{
+		Added:   []json.RawMessage{},
+		Removed: []json.RawMessage{},
+	}
+	prevElements := []json.RawMessage{}
+	currElements := []json.RawMessage{}
+	if len(prev) > 0 {
+		if err := json.Unmarshal(prev, &prevElements); err != nil {
+			return diff, err
+		}
+	}
+	if len(curr) > 0 {
+		if err := json.Unmarshal(curr, &currElements); err != nil {
+			return diff, err
+		}
+	}
+	// each element of the previous version matches at most one element of the current version, so duplicates are counted
+	matched := make([]bool, len(prevElements))
+	for _, currElement := range currElements {
+		found := false
+		for i, prevElement := range prevElements {
+			if !matched[i] && jsonEqual(prevElement, currElement) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.Added = append(diff.Added, currElement)
+		}
+	}
+	for i, prevElement := range prevElements {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, prevElement)
+		}
+	}
+	return diff, nil
+}
@@ -320,3 +320,80 @@ func TestIscnPagination(t *testing.T) {
 		prevTimestamp = timestamp
 	}
 }
+
+func TestIscnVersions(t *testing.T) {
+	defer CleanupTestData(Conn)
+	prefix := "iscn://testing/versions"
+	iscns := []IscnInsert{
+		{
+			Iscn:  prefix + "/1",
+			Owner: ADDR_01_LIKE,
+			Data:  []byte(`{"contentMetadata":{"name":"v1","url":"https://a"},"stakeholders":[{"entity":{"id":"@a"},"rewardProportion":1}],"contentFingerprints":["hash://a","hash://b"]}`),
+		},
+		{
+			Iscn:  prefix + "/2",
+			Owner: ADDR_01_LIKE,
+			Data:  []byte(`{"contentMetadata":{"name":"v2","url":"https://a","keywords":"k"},"stakeholders":[{"rewardProportion":1,"entity":{"id":"@a"}},{"entity":{"id":"@b"},"rewardProportion":1}],"contentFingerprints":["hash://b"]}`),
+		},
+		{
+			Iscn:  prefix + "/3",
+			Owner: ADDR_02_LIKE,
+			Data:  []byte(`{"contentMetadata":{"name":"v2"},"stakeholders":[{"entity":{"id":"@b"},"rewardProportion":1}],"contentFingerprints":["hash://b","hash://c"]}`),
+		},
+	}
+	events := []IscnEvent{
+		{Action: ISCN_ACTION_CREATE, IscnId: prefix + "/1", IscnIdPrefix: prefix, Version: 1, Sender: ADDR_01_LIKE, NewOwner: ADDR_01_LIKE, Height: 1, TxHash: "TX1"},
+		{Action: ISCN_ACTION_UPDATE, IscnId: prefix + "/2", IscnIdPrefix: prefix, Version: 2, Sender: ADDR_01_LIKE, PrevOwner: ADDR_01_LIKE, NewOwner: ADDR_01_LIKE, Height: 2, TxHash: "TX2"},
+		// the ownership change of version 2 is not the transaction of the version
+		{Action: ISCN_ACTION_CHANGE_OWNERSHIP, IscnId: prefix + "/2", IscnIdPrefix: prefix, Version: 2, Sender: ADDR_01_LIKE, PrevOwner: ADDR_01_LIKE, NewOwner: ADDR_02_LIKE, Height: 3, TxHash: "TX3"},
+	}
+	InsertTestData(DBTestData{Iscns: iscns, IscnEvents: events})
+
+	res, err := GetIscnVersions(Conn, prefix)
+	require.NoError(t, err)
+	require.Equal(t, prefix, res.IscnIdPrefix)
+	require.Equal(t, 3, res.LatestVersion)
+	require.Len(t, res.Versions, 3)
+
+	v1 := res.Versions[0]
+	require.Equal(t, prefix+"/1", v1.IscnId)
+	require.Equal(t, 1, v1.Version)
+	require.Equal(t, ADDR_01_LIKE, v1.Owner)
+	require.Equal(t, "TX1", v1.TxHash)
+	require.Nil(t, v1.Diff)
+
+	v2 := res.Versions[1]
+	require.Equal(t, "TX2", v2.TxHash)
+	require.NotNil(t, v2.Diff)
+	require.Len(t, v2.Diff.ContentMetadata.Added, 1)
+	require.JSONEq(t, `"k"`, string(v2.Diff.ContentMetadata.Added["keywords"]))
+	require.Empty(t, v2.Diff.ContentMetadata.Removed)
+	require.Len(t, v2.Diff.ContentMetadata.Changed, 1)
+	require.JSONEq(t, `"v1"`, string(v2.Diff.ContentMetadata.Changed["name"].From))
+	require.JSONEq(t, `"v2"`, string(v2.Diff.ContentMetadata.Changed["name"].To))
+	// the order of fields does not matter
+	require.Len(t, v2.Diff.Stakeholders.Added, 1)
+	require.JSONEq(t, `{"entity":{"id":"@b"},"rewardProportion":1}`, string(v2.Diff.Stakeholders.Added[0]))
+	require.Empty(t, v2.Diff.Stakeholders.Removed)
+	require.Empty(t, v2.Diff.ContentFingerprints.Added)
+	require.Len(t, v2.Diff.ContentFingerprints.Removed, 1)
+	require.JSONEq(t, `"hash://a"`, string(v2.Diff.ContentFingerprints.Removed[0]))
+
+	v3 := res.Versions[2]
+	require.Equal(t, ADDR_02_LIKE, v3.Owner)
+	require.Equal(t, "", v3.TxHash)
+	require.NotNil(t, v3.Diff)
+	require.Empty(t, v3.Diff.ContentMetadata.Added)
+	require.Len(t, v3.Diff.ContentMetadata.Removed, 2)
+	require.Empty(t, v3.Diff.ContentMetadata.Changed)
+	require.Empty(t, v3.Diff.Stakeholders.Added)
+	require.Len(t, v3.Diff.Stakeholders.Removed, 1)
+	require.JSONEq(t, `{"entity":{"id":"@a"},"rewardProportion":1}`, string(v3.Diff.Stakeholders.Removed[0]))
+	require.Len(t, v3.Diff.ContentFingerprints.Added, 1)
+	require.JSONEq(t, `"hash://c"`, string(v3.Diff.ContentFingerprints.Added[0]))
+	require.Empty(t, v3.Diff.ContentFingerprints.Removed)
+
+	res, err = GetIscnVersions(Conn, "iscn://testing/notfound")
+	require.NoError(t, err)
+	require.Empty(t, res.Versions)
+}
@@ -22,6 +22,7 @@ func GetNftMarketplaceItems(conn *pgxpool.Conn, q QueryNftMarketplaceItemsReques
 	sql := fmt.Sprintf(`
 		SELECT
 			m.type, m.class_id, m.nft_id, m.creator, m.price, m.expiration,
+			m.height, m.tx_hash,
 			c.metadata AS class_metadata,
 			n.metadata AS nft_metadata
 		FROM nft_marketplace m
@@ -36,6 +37,8 @@ func GetNftMarketplaceItems(conn *pgxpool.Conn, q QueryNftMarketplaceItemsReques
 			AND ($8 = '' OR m.class_id = $8)
 			AND ($9 = '' OR m.nft_id = $9)
 			AND ($10 = '' OR m.creator = $10)
+			AND ($12 = 0 OR m.height >= $12)
+			AND ($13 = 0 OR m.height <= $13)
 		ORDER BY price %s
 		LIMIT $6
 	`, p.Order())
@@ -45,8 +48,8 @@ func GetNftMarketplaceItems(conn *pgxpool.Conn, q QueryNftMarketplaceItemsReques
 		ctx, sql,
 		// $1 ~ $7
 		blockTime, after, afterTime, before, beforeTime, p.Limit, q.Type,
-		// $8 ~ $11
-		q.ClassId, q.NftId, q.Creator, q.Expand,
+		// $8 ~ $13
+		q.ClassId, q.NftId, q.Creator, q.Expand, q.MinHeight, q.MaxHeight,
 	)
 	if err != nil {
 		logger.L.Errorw("Failed to query database query for GetMarketplaceItems", "error", err, "q", q)
@@ -58,6 +61,7 @@ func GetNftMarketplaceItems(conn *pgxpool.Conn, q QueryNftMarketplaceItemsReques
 		var item NftMarketplaceItemResponse
 		if err = rows.Scan(
 			&item.Type, &item.ClassId, &item.NftId, &item.Creator, &item.Price, &item.Expiration,
+			&item.Height, &item.TxHash,
 			&item.ClassMetadata, &item.NftMetadata,
 		); err != nil {
 			logger.L.Errorw("Failed to scan row into NftMarketplaceItemResponse", "error", err)
@@ -19,6 +19,7 @@ func GetClasses(conn *pgxpool.Conn, q QueryClassRequest, p PageRequest) (QueryCl
 		SELECT n.class_id, COUNT(*) AS nft_owned_count
 		FROM nft AS n
 		WHERE ($8::text[] IS NOT NULL AND cardinality($8::text[]) > 0 AND n.owner = ANY($8))
+			AND ($11 = true OR n.burned_height IS NULL)
 		GROUP BY n.class_id
 	),
 	last_owned_events AS (
@@ -36,7 +37,8 @@ func GetClasses(conn *pgxpool.Conn, q QueryClassRequest, p PageRequest) (QueryCl
 		c.id, c.class_id, c.name, c.description, c.symbol,
 		c.uri, c.uri_hash, c.config, c.metadata, c.latest_price,
 		c.parent_type, c.parent_iscn_id_prefix, c.parent_account, c.created_at, c.price_updated_at,
-		i.owner, owner_nfts.nft_owned_count, last_owned_events.nft_id, last_owned_events.timestamp
+		i.owner, owner_nfts.nft_owned_count, last_owned_events.nft_id, last_owned_events.timestamp, c.height,
+		c.tx_hash
 	FROM nft_class as c
 	LEFT JOIN iscn AS i ON i.iscn_id_prefix = c.parent_iscn_id_prefix
 	LEFT JOIN iscn_latest_version
@@ -45,6 +47,7 @@ func GetClasses(conn *pgxpool.Conn, q QueryClassRequest, p PageRequest) (QueryCl
 		-- this is for optimizing out a left join when nft data is not needed
 		ON ($8::text[] IS NOT NULL AND cardinality($8::text[]) > 0)
 			AND n.class_id = c.class_id
+			AND ($11 = true OR n.burned_height IS NULL)
 	LEFT JOIN owner_nfts 
 		ON c.class_id = owner_nfts.class_id
 	LEFT JOIN last_owned_events 
@@ -54,6 +57,8 @@ func GetClasses(conn *pgxpool.Conn, q QueryClassRequest, p PageRequest) (QueryCl
 		AND ($6::text[] IS NULL OR cardinality($6::text[]) = 0 OR i.owner = ANY($6))
 		AND ($8::text[] IS NULL OR cardinality($8::text[]) = 0 OR n.owner = ANY($8))
 		AND ($7 = true OR i.version = iscn_latest_version.latest_version)
+		AND ($9 = 0 OR c.height >= $9)
+		AND ($10 = 0 OR c.height <= $10)
 		AND ($1 = 0 OR c.id > $1)
 		AND ($2 = 0 OR c.id < $2)
 	ORDER BY c.id %s
@@ -64,7 +69,8 @@ func GetClasses(conn *pgxpool.Conn, q QueryClassRequest, p PageRequest) (QueryCl
 	rows, err := conn.Query(
 		ctx, sql,
 		p.After(), p.Before(), p.Limit, q.IscnIdPrefix, accountVariations,
-		iscnOwnerVariations, q.AllIscnVersions, ownerVariations)
+		iscnOwnerVariations, q.AllIscnVersions, ownerVariations, q.MinHeight, q.MaxHeight,
+		q.IncludeBurned)
 	if err != nil {
 		logger.L.Errorw("Failed to query nft class by iscn id prefix", "error", err, "q", q)
 		return QueryClassResponse{}, fmt.Errorf("query nft class by iscn id prefix error: %w", err)
@@ -79,7 +85,8 @@ func GetClasses(conn *pgxpool.Conn, q QueryClassRequest, p PageRequest) (QueryCl
 			&res.Pagination.NextKey, &c.Id, &c.Name, &c.Description, &c.Symbol,
 			&c.URI, &c.URIHash, &c.Config, &c.Metadata, &c.LatestPrice,
 			&c.Parent.Type, &c.Parent.IscnIdPrefix, &c.Parent.Account, &c.CreatedAt, &c.PriceUpdatedAt,
-			&c.Owner, &c.NftOwnedCount, &c.LastOwnedNftId, &c.NftLastOwnedAt,
+			&c.Owner, &c.NftOwnedCount, &c.LastOwnedNftId, &c.NftLastOwnedAt, &c.Height,
+			&c.TxHash,
 		); err != nil {
 			logger.L.Errorw("failed to scan nft class", "error", err)
 			return QueryClassResponse{}, fmt.Errorf("query nft class data failed: %w", err)
@@ -120,6 +127,7 @@ func GetClassesRanking(conn *pgxpool.Conn, q QueryRankingRequest, p PageRequest)
 			WHERE
 				($3::text[] IS NULL OR cardinality($3::text[]) = 0 OR n.owner != ALL($3))
 				AND ($8::text[] IS NULL OR cardinality($8::text[]) = 0 OR n.owner = ANY($8))
+				AND ($14 = true OR n.burned_height IS NULL)
 				AND e.action = '/cosmos.nft.v1beta1.MsgSend'
 				AND ($11 = 0 OR (e.timestamp IS NOT NULL AND e.timestamp > to_timestamp($11)))
 				AND ($12 = 0 OR (e.timestamp IS NOT NULL AND e.timestamp < to_timestamp($12)))
@@ -170,8 +178,8 @@ func GetClassesRanking(conn *pgxpool.Conn, q QueryRankingRequest, p PageRequest)
 		p.Limit, q.IncludeOwner, ignoreListVariations, creatorVariations, q.Type,
 		// $6 ~ $10
 		stakeholderIdVariataions, q.StakeholderName, collectorVariations, q.CreatedAfter, q.CreatedBefore,
-		// $11 ~ $13
-		q.After, q.Before, ApiAddressesVariations,
+		// $11 ~ $14
+		q.After, q.Before, ApiAddressesVariations, q.IncludeBurned,
 	)
 	if err != nil {
 		logger.L.Errorw("Failed to query nft class ranking", "error", err, "q", q)
@@ -204,7 +212,8 @@ func GetNfts(conn *pgxpool.Conn, q QueryNftRequest, p PageRequest) (QueryNftResp
 		n.uri_hash, n.metadata, e.timestamp, c.name, c.description,
 		c.symbol, c.uri, c.uri_hash, c.config, c.metadata,
 		c.latest_price, c.parent_type, c.parent_iscn_id_prefix, c.parent_account, c.created_at,
-		c.price_updated_at
+		c.price_updated_at, c.height, c.tx_hash, n.height, n.tx_hash,
+		n.burned_height
 	FROM nft as n
 	JOIN nft_class as c
 	ON n.class_id = c.class_id
@@ -216,6 +225,9 @@ func GetNfts(conn *pgxpool.Conn, q QueryNftRequest, p PageRequest) (QueryNftResp
 	) e
 	ON n.nft_id = e.nft_id
 	WHERE owner = ANY($4)
+		AND ($5 = 0 OR n.height >= $5)
+		AND ($6 = 0 OR n.height <= $6)
+		AND ($7 = true OR n.burned_height IS NULL)
 		AND ($1 = 0 OR n.id > $1)
 		AND ($2 = 0 OR n.id < $2)
 	ORDER BY n.id %s
@@ -223,7 +235,7 @@ func GetNfts(conn *pgxpool.Conn, q QueryNftRequest, p PageRequest) (QueryNftResp
 	`, p.Order())
 	ctx, cancel := GetTimeoutContext()
 	defer cancel()
-	rows, err := conn.Query(ctx, sql, p.After(), p.Before(), p.Limit, ownerVariations)
+	rows, err := conn.Query(ctx, sql, p.After(), p.Before(), p.Limit, ownerVariations, q.MinHeight, q.MaxHeight, q.IncludeBurned)
 	if err != nil {
 		logger.L.Errorw("Failed to query nft by owner", "error", err, "q", q)
 		return QueryNftResponse{}, fmt.Errorf("query nft class error: %w", err)
@@ -239,7 +251,8 @@ func GetNfts(conn *pgxpool.Conn, q QueryNftRequest, p PageRequest) (QueryNftResp
 			&n.UriHash, &n.Metadata, &n.Timestamp, &c.Name, &c.Description,
 			&c.Symbol, &c.URI, &c.URIHash, &c.Config, &c.Metadata,
 			&c.LatestPrice, &n.ClassParent.Type, &n.ClassParent.IscnIdPrefix, &n.ClassParent.Account, &c.CreatedAt,
-			&c.PriceUpdatedAt,
+			&c.PriceUpdatedAt, &c.Height, &c.TxHash, &n.Height, &n.TxHash,
+			&n.BurnedHeight,
 		); err != nil {
 			logger.L.Errorw("failed to scan nft", "error", err, "q", q)
 			return QueryNftResponse{}, fmt.Errorf("query nft failed: %w", err)
@@ -271,12 +284,13 @@ func GetOwners(conn *pgxpool.Conn, q QueryOwnerRequest) (QueryOwnerResponse, err
 	WHERE n.class_id = $1
 		AND ($2 = false OR n.owner != i.owner)
 		AND ($3::text[] IS NULL OR cardinality($3::text[]) = 0 OR n.owner != ALL($3))
+		AND ($4 = true OR n.burned_height IS NULL)
 	GROUP BY n.owner
 	`
 	ctx, cancel := GetTimeoutContext()
 	defer cancel()
 
-	rows, err := conn.Query(ctx, sql, q.ClassId, q.ExcludeIscnOwner, ignoreListVariations)
+	rows, err := conn.Query(ctx, sql, q.ClassId, q.ExcludeIscnOwner, ignoreListVariations, q.IncludeBurned)
 	if err != nil {
 		logger.L.Errorw("Failed to query owner", "error", err)
 		return QueryOwnerResponse{}, fmt.Errorf("query owner error: %w", err)
@@ -586,6 +600,7 @@ func GetCollector(conn *pgxpool.Conn, q QueryCollectorRequest, p PageRequest) (r
 		JOIN nft_class AS c ON i.iscn_id_prefix = c.parent_iscn_id_prefix
 		JOIN nft AS n ON c.class_id = n.class_id
 			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR n.owner != ALL($4))
+			AND ($7 = true OR n.burned_height IS NULL)
 		JOIN LATERAL (
 			SELECT nft_id, receiver, MAX(id) AS max_id
 			FROM nft_event
@@ -615,7 +630,7 @@ func GetCollector(conn *pgxpool.Conn, q QueryCollectorRequest, p PageRequest) (r
 
 	rows, err := conn.Query(ctx, sql,
 		creatorVariations, p.Offset, p.Limit, ignoreListVariations, q.AllIscnVersions,
-		q.IncludeOwner)
+		q.IncludeOwner, q.IncludeBurned)
 	if err != nil {
 		logger.L.Errorw("failed to query collectors", "error", err, "q", q)
 		err = fmt.Errorf("query supporters error: %w", err)
@@ -654,6 +669,7 @@ func GetCreators(conn *pgxpool.Conn, q QueryCreatorRequest, p PageRequest) (res
 		JOIN nft_class AS c ON i.iscn_id_prefix = c.parent_iscn_id_prefix
 		JOIN nft AS n ON c.class_id = n.class_id
 			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR n.owner != ALL($4))
+			AND ($7 = true OR n.burned_height IS NULL)
 		JOIN LATERAL (
 			SELECT nft_id, receiver, MAX(id) AS max_id
 			FROM nft_event
@@ -683,7 +699,7 @@ func GetCreators(conn *pgxpool.Conn, q QueryCreatorRequest, p PageRequest) (res
 
 	rows, err := conn.Query(ctx, sql,
 		collectorVariations, p.Offset, p.Limit, ignoreListVariations, q.AllIscnVersions,
-		q.IncludeOwner)
+		q.IncludeOwner, q.IncludeBurned)
 	if err != nil {
 		logger.L.Errorw("failed to query creators", "error", err, "q", q)
 		err = fmt.Errorf("query creators error: %w", err)
@@ -727,9 +743,10 @@ func GetUserStat(conn *pgxpool.Conn, q QueryUserStatRequest) (res QueryUserStatR
 	FROM nft_class as c
 	JOIN nft AS n ON c.class_id = n.class_id
 	WHERE n.owner = $1
+		AND ($2 = true OR n.burned_height IS NULL)
 	GROUP BY c.class_id
 	`
-	rows, err := conn.Query(ctx, sql, q.User)
+	rows, err := conn.Query(ctx, sql, q.User, q.IncludeBurned)
 	if err != nil {
 		logger.L.Errorw("failed to query collected classes", "error", err, "q", q)
 		err = fmt.Errorf("query collected classes error: %w", err)
@@ -772,10 +789,11 @@ func GetUserStat(conn *pgxpool.Conn, q QueryUserStatRequest) (res QueryUserStatR
 	JOIN nft_class AS c ON i.iscn_id_prefix = c.parent_iscn_id_prefix
 	JOIN nft AS n ON c.class_id = n.class_id
 		AND ($2::text[] IS NULL OR n.owner != ALL($2))
+		AND ($4 = true OR n.burned_height IS NULL)
 	WHERE i.owner = $1
 	`
 
-	row = conn.QueryRow(ctx, sql, q.User, q.IgnoreList, q.AllIscnVersions)
+	row = conn.QueryRow(ctx, sql, q.User, q.IgnoreList, q.AllIscnVersions, q.IncludeBurned)
 
 	err = row.Scan(&res.CollectorCount)
 	if err != nil {
@@ -832,6 +850,7 @@ func GetCollectorTopRankedCreators(conn *pgxpool.Conn, q QueryCollectorTopRanked
 		JOIN nft_class as c ON i.iscn_id_prefix = c.parent_iscn_id_prefix
 		JOIN nft AS n ON c.class_id = n.class_id
 			AND ($3::text[] IS NULL OR cardinality($3::text[]) = 0 OR n.owner != ALL($3))
+			AND ($6 = true OR n.burned_height IS NULL)
 		WHERE 
 			($5 = true OR n.owner != i.owner)
 		GROUP BY creator, collector
@@ -847,6 +866,7 @@ func GetCollectorTopRankedCreators(conn *pgxpool.Conn, q QueryCollectorTopRanked
 
 	rows, err := conn.Query(ctx, sql,
 		collectorVariations, q.Top, ignoreListVariations, q.AllIscnVersions, q.IncludeOwner,
+		q.IncludeBurned,
 	)
 	if err != nil {
 		logger.L.Errorw("failed to query collector top ranked creators list", "error", err, "q", q)
@@ -871,12 +891,13 @@ func GetClassesOwners(conn *pgxpool.Conn, q QueryClassesOwnersRequest) (QueryCla
 		FROM nft
 		WHERE class_id = ANY($1)
 			AND ($2::text[] IS NULL OR cardinality($2::text[]) = 0 OR owner = ANY($2))
+			AND ($3 = true OR burned_height IS NULL)
 		ORDER BY owner, class_id
 ;
 	`
 	ctx, cancel := GetTimeoutContext()
 	defer cancel()
-	rows, err := conn.Query(ctx, sql, q.ClassIds, ownersVariations)
+	rows, err := conn.Query(ctx, sql, q.ClassIds, ownersVariations, q.IncludeBurned)
 	if err != nil {
 		logger.L.Errorw("Failed to query nft classes owners", "error", err, "q", q)
 		return QueryClassesOwnersResponse{}, fmt.Errorf("error on query nft classes owners: %w", err)
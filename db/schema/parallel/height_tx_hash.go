@@ -0,0 +1,175 @@
+package parallel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+// heightTxHashMigrations backfill the height and tx hash of the rows in batches of id,
+// with $1 and $2 being the head (inclusive) and the end (exclusive) of the batch
+var heightTxHashMigrations = []struct {
+	table string
+	sql   string
+}{
+	{
+		// the earliest transaction emitting the ISCN ID, since ownership changes emit the ISCN ID of the latest version again
+		table: "iscn",
+		sql: `
+			UPDATE iscn AS i
+			SET height = t.height, tx_hash = t.tx ->> 'txhash'
+			FROM txs AS t
+			WHERE
+				i.height = 0
+				AND i.id >= $1
+				AND i.id < $2
+				AND t.id = (
+					SELECT id
+					FROM txs
+					WHERE events @> ARRAY['iscn_record.iscn_id="' || i.iscn_id || '"']::varchar[]
+					ORDER BY height, tx_index
+					LIMIT 1
+				)
+		`,
+	},
+	{
+		table: "nft_class",
+		sql: `
+			UPDATE nft_class AS c
+			SET height = t.height, tx_hash = e.tx_hash
+			FROM nft_event AS e
+			JOIN txs AS t ON t.tx ->> 'txhash' = e.tx_hash
+			WHERE
+				c.height = 0
+				AND c.id >= $1
+				AND c.id < $2
+				AND e.action = 'new_class'
+				AND e.class_id = c.class_id
+		`,
+	},
+	{
+		table: "nft",
+		sql: `
+			UPDATE nft AS n
+			SET height = t.height, tx_hash = e.tx_hash
+			FROM nft_event AS e
+			JOIN txs AS t ON t.tx ->> 'txhash' = e.tx_hash
+			WHERE
+				n.height = 0
+				AND n.id >= $1
+				AND n.id < $2
+				AND e.action = 'mint_nft'
+				AND e.class_id = n.class_id
+				AND e.nft_id = n.nft_id
+		`,
+	},
+	{
+		table: "nft_income",
+		sql: `
+			UPDATE nft_income AS i
+			SET height = t.height
+			FROM txs AS t
+			WHERE
+				i.height = 0
+				AND i.id >= $1
+				AND i.id < $2
+				AND t.tx ->> 'txhash' = i.tx_hash
+		`,
+	},
+}
+
+// nftMarketplaceHeightTxHashSQL backfills the listings or offers with the latest transaction creating or updating them.
+// nft_marketplace has no id column, but keeps only one row for each NFT and creator, so it is updated in one go.
+// The values of the typed events are JSON strings, hence the double quotes.
+const nftMarketplaceHeightTxHashSQL = `
+	UPDATE nft_marketplace AS m
+	SET height = t.height, tx_hash = t.tx ->> 'txhash'
+	FROM txs AS t
+	WHERE
+		m.height = 0
+		AND m.type = $1
+		AND t.id = (
+			SELECT id
+			FROM txs
+			WHERE events @> ARRAY[
+					'likechain.likenft.v1.EventCreate' || $2 || '.class_id=""' || m.class_id || '""',
+					'likechain.likenft.v1.EventCreate' || $2 || '.nft_id=""' || m.nft_id || '""',
+					'likechain.likenft.v1.EventCreate' || $2 || '.' || $3 || '=""' || m.creator || '""'
+				]::varchar[]
+				OR events @> ARRAY[
+					'likechain.likenft.v1.EventUpdate' || $2 || '.class_id=""' || m.class_id || '""',
+					'likechain.likenft.v1.EventUpdate' || $2 || '.nft_id=""' || m.nft_id || '""',
+					'likechain.likenft.v1.EventUpdate' || $2 || '.' || $3 || '=""' || m.creator || '""'
+				]::varchar[]
+			ORDER BY height DESC, tx_index DESC
+			LIMIT 1
+		)
+`
+
+// updateInIdBatches runs the UPDATE statement on the table in batches of id, with $1 and $2 being the head (inclusive) and the end (exclusive) of the batch
+func updateInIdBatches(conn *pgxpool.Conn, table string, sql string, batchSize uint64, progressMsg string) error {
+	var maxID int64
+	row := conn.QueryRow(context.Background(), fmt.Sprintf(`SELECT COALESCE(max(id), 0) FROM %s`, table))
+	err := row.Scan(&maxID)
+	if err != nil {
+		logger.L.Errorw("Error when querying max ID", "table", table, "error", err)
+		return err
+	}
+	for batchHead := int64(0); batchHead <= maxID; batchHead += int64(batchSize) {
+		batchUntil := batchHead + int64(batchSize)
+		_, err = conn.Exec(context.Background(), sql, batchHead, batchUntil)
+		if err != nil {
+			logger.L.Errorw(
+				"Error when executing UPDATE statement",
+				"table", table,
+				"batch_head", batchHead,
+				"max_id", maxID,
+				"batch_size", batchSize,
+				"error", err,
+			)
+			return err
+		}
+		logger.L.Infow(
+			progressMsg,
+			"table", table,
+			"batch_head", batchHead,
+			"batch_size", batchSize,
+			"max_id", maxID,
+		)
+	}
+	return nil
+}
+
+func MigrateHeightTxHash(conn *pgxpool.Conn, batchSize uint64) error {
+	err := checkBatchSize(batchSize)
+	if err != nil {
+		return err
+	}
+	err = checkMinSchemaVersion(conn, 29)
+	if err != nil {
+		return err
+	}
+	logger.L.Info("Start migrating height and tx hash")
+	for _, m := range heightTxHashMigrations {
+		err = updateInIdBatches(conn, m.table, m.sql, batchSize, "Height and tx hash migration progress")
+		if err != nil {
+			return err
+		}
+	}
+	for _, m := range []struct{ itemType, eventName, creatorKey string }{
+		{"listing", "Listing", "seller"},
+		{"offer", "Offer", "buyer"},
+	} {
+		_, err = conn.Exec(context.Background(), nftMarketplaceHeightTxHashSQL, m.itemType, m.eventName, m.creatorKey)
+		if err != nil {
+			logger.L.Errorw("Error when executing UPDATE statement on nft_marketplace table", "type", m.itemType, "error", err)
+			return err
+		}
+		logger.L.Infow("Height and tx hash migration progress", "table", "nft_marketplace", "type", m.itemType)
+	}
+	logger.L.Info("Migration for height and tx hash done")
+	return nil
+}
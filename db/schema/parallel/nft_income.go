@@ -18,7 +18,7 @@ func MigrateNftIncome(conn *pgxpool.Conn, batchSize uint64) error {
 	if err != nil {
 		return err
 	}
-	err = checkMinSchemaVersion(conn, 14)
+	err = checkMinSchemaVersion(conn, 29)
 	if err != nil {
 		return err
 	}
@@ -28,7 +28,7 @@ func MigrateNftIncome(conn *pgxpool.Conn, batchSize uint64) error {
 		// that way we can skip most unused `mint_nft` actions mixed in the `/cosmos.nft.v1beta1.MsgSend` actions
 		_, err := dbTx.Exec(context.Background(), `
 			DECLARE nft_income_migration_cursor CURSOR FOR
-				SELECT s.id, s.tx_hash, txs.tx -> 'logs' AS events
+				SELECT s.id, s.tx_hash, txs.height, txs.tx -> 'logs' AS events
 				FROM (
 					SELECT e.id, e.tx_hash, ROW_NUMBER() OVER (PARTITION BY e.tx_hash ORDER BY e.id DESC) AS rn
 					FROM nft_event AS e
@@ -59,8 +59,9 @@ func MigrateNftIncome(conn *pgxpool.Conn, batchSize uint64) error {
 			var txIncomes []db.NftIncome
 			for rows.Next() {
 				var txHash string
+				var height int64
 				var eventData pgtype.JSONB
-				err = rows.Scan(&pkeyId, &txHash, &eventData)
+				err = rows.Scan(&pkeyId, &txHash, &height, &eventData)
 				if err != nil {
 					logger.L.Errorw("Error when scanning row", "error", err)
 					return err
@@ -78,10 +79,14 @@ func MigrateNftIncome(conn *pgxpool.Conn, batchSize uint64) error {
 					msgAction := utils.GetEventsValue(msgEvents, "message", "action")
 					msgIncomes := []db.NftIncome{}
 					if msgAction == string(db.ACTION_SEND) {
-						msgIncomes = extractor.GetIncomesFromSendNftMsgs(eventsList, i, txHash)
+						// authz_grant is not yet available in this migration, so the grants are not verified
+						msgIncomes, _ = extractor.GetIncomesFromSendNftMsgs(eventsList, i, txHash)
 					} else if msgAction == string(db.ACTION_BUY) || msgAction == string(db.ACTION_SELL) {
 						msgIncomes = extractor.GetIncomesFromBuySellNftMsg(msgEvents, txHash)
 					}
+					for j := range msgIncomes {
+						msgIncomes[j].Height = height
+					}
 					txIncomes = append(txIncomes, msgIncomes...)
 				}
 			}
@@ -99,11 +104,11 @@ func MigrateNftIncome(conn *pgxpool.Conn, batchSize uint64) error {
 			}
 			for _, income := range txIncomes {
 				_, err = dbTx.Exec(context.Background(), `
-						INSERT INTO nft_income (class_id, nft_id, tx_hash, address, amount, is_royalty)
-						VALUES ($1, $2, $3, $4, $5, $6) 
+						INSERT INTO nft_income (class_id, nft_id, tx_hash, address, amount, is_royalty, height)
+						VALUES ($1, $2, $3, $4, $5, $6, $7) 
 						ON CONFLICT (class_id, nft_id, tx_hash, address) DO UPDATE
-						SET amount = excluded.amount, is_royalty = excluded.is_royalty
-					`, income.ClassId, income.NftId, income.TxHash, income.Address, income.Amount, income.IsRoyalty)
+						SET amount = excluded.amount, is_royalty = excluded.is_royalty, height = excluded.height
+					`, income.ClassId, income.NftId, income.TxHash, income.Address, income.Amount, income.IsRoyalty, income.Height)
 				if err != nil {
 					logger.L.Errorw("Error when inserting into nft_income", "error", err)
 					return err
@@ -0,0 +1,69 @@
+package parallel
+
+import (
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+// ownerHeightMigrations backfill the height of the latest ownership change in batches of id,
+// falling back to the height creating the row, which is 0 for rows not yet backfilled by `migrate height-tx-hash`
+var ownerHeightMigrations = []struct {
+	table string
+	sql   string
+}{
+	{
+		table: "nft",
+		sql: `
+			UPDATE nft AS n
+			SET owner_height = GREATEST(n.height, COALESCE((
+				SELECT MAX(t.height)
+				FROM nft_event AS e
+				JOIN txs AS t ON t.tx ->> 'txhash' = e.tx_hash
+				WHERE e.class_id = n.class_id
+					AND e.nft_id = n.nft_id
+					AND e.action IN ('/cosmos.nft.v1beta1.MsgSend', 'buy_nft', 'sell_nft')
+			), 0))
+			WHERE
+				n.owner_height = 0
+				AND n.id >= $1
+				AND n.id < $2
+		`,
+	},
+	{
+		table: "iscn",
+		sql: `
+			UPDATE iscn AS i
+			SET owner_height = GREATEST(i.height, COALESCE((
+				SELECT MAX(e.height)
+				FROM iscn_event AS e
+				WHERE e.iscn_id = i.iscn_id
+					AND e.action = 'change_ownership'
+			), 0))
+			WHERE
+				i.owner_height = 0
+				AND i.id >= $1
+				AND i.id < $2
+		`,
+	},
+}
+
+func MigrateOwnerHeight(conn *pgxpool.Conn, batchSize uint64) error {
+	err := checkBatchSize(batchSize)
+	if err != nil {
+		return err
+	}
+	err = checkMinSchemaVersion(conn, 31)
+	if err != nil {
+		return err
+	}
+	logger.L.Info("Start migrating owner height")
+	for _, m := range ownerHeightMigrations {
+		err = updateInIdBatches(conn, m.table, m.sql, batchSize, "Owner height migration progress")
+		if err != nil {
+			return err
+		}
+	}
+	logger.L.Info("Migration for owner height done")
+	return nil
+}
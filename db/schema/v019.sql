@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS blocks (
+  height BIGINT PRIMARY KEY,
+  hash TEXT NOT NULL,
+  proposer_address TEXT NOT NULL,
+  time TIMESTAMP NOT NULL,
+  tx_count INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocks_time ON blocks (time);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS extract_failures (
+  id BIGSERIAL PRIMARY KEY,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  msg_index INTEGER NOT NULL,
+  event_type TEXT NOT NULL,
+  processor TEXT NOT NULL,
+  error TEXT NOT NULL,
+  retry_count INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  resolved_at TIMESTAMP,
+  UNIQUE (tx_hash, msg_index, event_type, processor)
+);
+
+CREATE INDEX IF NOT EXISTS idx_extract_failures_unresolved ON extract_failures (id) WHERE resolved_at IS NULL;
@@ -0,0 +1,12 @@
+-- each extractor group tracks its own progress as `extractor_<name>_v<version>`,
+-- the existing groups start from the height extracted before
+INSERT INTO meta (id, height)
+SELECT group_key, height
+FROM meta, unnest(ARRAY[
+  'extractor_iscn_v1',
+  'extractor_nft_v1',
+  'extractor_marketplace_v1',
+  'extractor_income_v1'
+]) AS group_key
+WHERE id = 'extractor_v1'
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS token_transfer (
+  id BIGSERIAL PRIMARY KEY,
+  sender TEXT NOT NULL,
+  receiver TEXT NOT NULL,
+  denom TEXT NOT NULL,
+  amount NUMERIC NOT NULL,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  msg_index INTEGER NOT NULL,
+  memo TEXT NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  UNIQUE (tx_hash, msg_index, sender, receiver, denom)
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_transfer_sender ON token_transfer (sender, id);
+CREATE INDEX IF NOT EXISTS idx_token_transfer_receiver ON token_transfer (receiver, id);
+CREATE INDEX IF NOT EXISTS idx_token_transfer_timestamp ON token_transfer (timestamp);
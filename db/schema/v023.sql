@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS staking_event (
+  id BIGSERIAL PRIMARY KEY,
+  action TEXT NOT NULL,
+  delegator TEXT NOT NULL,
+  validator TEXT NOT NULL,
+  -- destination of redelegation, empty for the other actions
+  dst_validator TEXT NOT NULL DEFAULT '',
+  denom TEXT NOT NULL,
+  amount NUMERIC NOT NULL,
+  completion_time TIMESTAMP,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  msg_index INTEGER NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  UNIQUE (tx_hash, msg_index, action, delegator, validator, denom)
+);
+
+CREATE INDEX IF NOT EXISTS idx_staking_event_delegator ON staking_event (delegator, id);
+CREATE INDEX IF NOT EXISTS idx_staking_event_validator ON staking_event (validator, id);
+CREATE INDEX IF NOT EXISTS idx_staking_event_dst_validator ON staking_event (dst_validator) WHERE dst_validator != '';
+
+-- current delegations, summed up from staking_event
+CREATE TABLE IF NOT EXISTS staking_delegation (
+  id BIGSERIAL PRIMARY KEY,
+  delegator TEXT NOT NULL,
+  validator TEXT NOT NULL,
+  amount NUMERIC NOT NULL,
+  height BIGINT NOT NULL,
+  UNIQUE (delegator, validator)
+);
+
+CREATE INDEX IF NOT EXISTS idx_staking_delegation_validator ON staking_delegation (validator, id);
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS gov_proposal (
+  proposal_id BIGINT PRIMARY KEY,
+  proposer TEXT NOT NULL,
+  -- type URL of the legacy content, or of the messages of the proposal
+  proposal_types TEXT[] NOT NULL,
+  title TEXT NOT NULL,
+  description TEXT NOT NULL,
+  metadata TEXT NOT NULL,
+  -- the legacy content, or the messages of the proposal
+  content JSONB NOT NULL,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  timestamp TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_gov_proposal_proposer ON gov_proposal (proposer);
+
+CREATE TABLE IF NOT EXISTS gov_deposit (
+  id BIGSERIAL PRIMARY KEY,
+  proposal_id BIGINT NOT NULL,
+  depositor TEXT NOT NULL,
+  denom TEXT NOT NULL,
+  amount NUMERIC NOT NULL,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  msg_index INTEGER NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  UNIQUE (tx_hash, msg_index, proposal_id, depositor, denom)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gov_deposit_proposal_id ON gov_deposit (proposal_id, id);
+CREATE INDEX IF NOT EXISTS idx_gov_deposit_depositor ON gov_deposit (depositor, id);
+
+CREATE TABLE IF NOT EXISTS gov_vote (
+  id BIGSERIAL PRIMARY KEY,
+  proposal_id BIGINT NOT NULL,
+  voter TEXT NOT NULL,
+  -- [{"option": "VOTE_OPTION_YES", "weight": "1.000000000000000000"}, ...]
+  options JSONB NOT NULL,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  msg_index INTEGER NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  UNIQUE (tx_hash, msg_index, proposal_id, voter)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gov_vote_proposal_id ON gov_vote (proposal_id, id);
+CREATE INDEX IF NOT EXISTS idx_gov_vote_voter ON gov_vote (voter, id);
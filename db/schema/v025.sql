@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS ibc_transfer (
+  id BIGSERIAL PRIMARY KEY,
+  direction TEXT NOT NULL,
+  status TEXT NOT NULL,
+  sequence BIGINT NOT NULL,
+  src_port TEXT NOT NULL,
+  src_channel TEXT NOT NULL,
+  dst_port TEXT NOT NULL,
+  dst_channel TEXT NOT NULL,
+  sender TEXT NOT NULL,
+  receiver TEXT NOT NULL,
+  -- denom on this chain, e.g. nanolike or ibc/...
+  denom TEXT NOT NULL,
+  -- trace path of the denom on this chain, empty for native denoms
+  denom_path TEXT NOT NULL,
+  base_denom TEXT NOT NULL,
+  amount NUMERIC NOT NULL,
+  memo TEXT NOT NULL DEFAULT '',
+  error TEXT NOT NULL DEFAULT '',
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  msg_index INTEGER NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  -- acknowledgement or timeout of outgoing transfers
+  completed_height BIGINT,
+  completed_tx_hash TEXT NOT NULL DEFAULT '',
+  completed_timestamp TIMESTAMP,
+  UNIQUE (direction, src_port, src_channel, dst_port, dst_channel, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ibc_transfer_sender ON ibc_transfer (sender, id);
+CREATE INDEX IF NOT EXISTS idx_ibc_transfer_receiver ON ibc_transfer (receiver, id);
+CREATE INDEX IF NOT EXISTS idx_ibc_transfer_tx_hash ON ibc_transfer (tx_hash);
+
+CREATE TABLE IF NOT EXISTS ibc_denom_trace (
+  -- ibc/<hash>
+  denom TEXT PRIMARY KEY,
+  path TEXT NOT NULL,
+  base_denom TEXT NOT NULL
+);
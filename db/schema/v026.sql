@@ -0,0 +1,27 @@
+-- the latest grant of each granter, grantee and message type
+CREATE TABLE IF NOT EXISTS authz_grant (
+  id BIGSERIAL PRIMARY KEY,
+  granter TEXT NOT NULL,
+  grantee TEXT NOT NULL,
+  msg_type_url TEXT NOT NULL,
+  -- e.g. /cosmos.bank.v1beta1.SendAuthorization
+  authorization_type TEXT NOT NULL,
+  authorization JSONB NOT NULL,
+  -- spend limit of SendAuthorization or max tokens of StakeAuthorization when granted, NULL for the others
+  spend_limit JSONB,
+  expiration TIMESTAMP,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  -- the latest MsgExec executing the grant
+  last_exec_height BIGINT,
+  last_exec_tx_hash TEXT NOT NULL DEFAULT '',
+  last_exec_timestamp TIMESTAMP,
+  -- revoked by MsgRevoke, or deleted after the spend limit is used up
+  revoked_height BIGINT,
+  revoked_tx_hash TEXT NOT NULL DEFAULT '',
+  revoked_timestamp TIMESTAMP,
+  UNIQUE (granter, grantee, msg_type_url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_authz_grant_grantee ON authz_grant (grantee, id);
@@ -0,0 +1,40 @@
+-- the latest fee allowance of each granter and grantee
+CREATE TABLE IF NOT EXISTS feegrant (
+  id BIGSERIAL PRIMARY KEY,
+  granter TEXT NOT NULL,
+  grantee TEXT NOT NULL,
+  -- e.g. /cosmos.feegrant.v1beta1.BasicAllowance
+  allowance_type TEXT NOT NULL,
+  allowance JSONB NOT NULL,
+  -- total spend limit of the allowance, NULL for unlimited
+  spend_limit JSONB,
+  expiration TIMESTAMP,
+  -- fees paid by the allowance since granted, summed up from feegrant_usage
+  fees_spent JSONB NOT NULL DEFAULT '[]',
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  last_used_height BIGINT,
+  last_used_tx_hash TEXT NOT NULL DEFAULT '',
+  last_used_timestamp TIMESTAMP,
+  -- revoked by MsgRevokeAllowance
+  revoked_height BIGINT,
+  revoked_tx_hash TEXT NOT NULL DEFAULT '',
+  revoked_timestamp TIMESTAMP,
+  UNIQUE (granter, grantee)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feegrant_grantee ON feegrant (grantee, id);
+
+-- transactions with fees paid by fee allowances
+CREATE TABLE IF NOT EXISTS feegrant_usage (
+  id BIGSERIAL PRIMARY KEY,
+  granter TEXT NOT NULL,
+  grantee TEXT NOT NULL,
+  fee JSONB NOT NULL,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL UNIQUE,
+  timestamp TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_feegrant_usage_granter_grantee ON feegrant_usage (granter, grantee, height);
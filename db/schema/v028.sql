@@ -0,0 +1,21 @@
+-- creation, updates and ownership changes of ISCN records, for the provenance of an ISCN ID prefix
+CREATE TABLE IF NOT EXISTS iscn_event (
+  id BIGSERIAL PRIMARY KEY,
+  -- create, update or change_ownership
+  action TEXT NOT NULL,
+  iscn_id TEXT NOT NULL,
+  iscn_id_prefix TEXT NOT NULL,
+  version INT NOT NULL,
+  -- signer of the message, i.e. the granter if executed by MsgExec
+  sender TEXT NOT NULL,
+  -- empty for creation
+  prev_owner TEXT NOT NULL DEFAULT '',
+  new_owner TEXT NOT NULL,
+  height BIGINT NOT NULL,
+  tx_hash TEXT NOT NULL,
+  msg_index INT NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  UNIQUE (tx_hash, msg_index, action, iscn_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_iscn_event_iscn_id_prefix ON iscn_event (iscn_id_prefix, height, id);
@@ -0,0 +1,33 @@
+-- the block height and transaction creating the row, 0 and '' until the existing rows are backfilled by `migrate height-tx-hash`
+ALTER TABLE iscn
+  ADD COLUMN height BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN tx_hash TEXT NOT NULL DEFAULT ''
+;
+
+CREATE INDEX IF NOT EXISTS idx_iscn_height ON iscn (height);
+
+ALTER TABLE nft_class
+  ADD COLUMN height BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN tx_hash TEXT NOT NULL DEFAULT ''
+;
+
+CREATE INDEX IF NOT EXISTS idx_nft_class_height ON nft_class (height);
+
+-- the mint transaction
+ALTER TABLE nft
+  ADD COLUMN height BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN tx_hash TEXT NOT NULL DEFAULT ''
+;
+
+CREATE INDEX IF NOT EXISTS idx_nft_height ON nft (height);
+
+-- nft_income has tx_hash already
+ALTER TABLE nft_income
+  ADD COLUMN height BIGINT NOT NULL DEFAULT 0
+;
+
+-- the transaction creating or updating the listing or offer most recently
+ALTER TABLE nft_marketplace
+  ADD COLUMN height BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN tx_hash TEXT NOT NULL DEFAULT ''
+;
@@ -0,0 +1,4 @@
+-- burned NFTs are kept with their last owner, and excluded from the queries unless requested
+ALTER TABLE nft
+  ADD COLUMN burned_height BIGINT
+;
@@ -0,0 +1,9 @@
+-- the height of the latest ownership change, so replaying older transactions (re-extraction or backfill) does not roll back the owner,
+-- 0 until the existing rows are backfilled by `migrate owner-height`
+ALTER TABLE nft
+  ADD COLUMN owner_height BIGINT NOT NULL DEFAULT 0
+;
+
+ALTER TABLE iscn
+  ADD COLUMN owner_height BIGINT NOT NULL DEFAULT 0
+;
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// ValidatorAddressPrefixes are the prefixes of validator operator addresses
+var ValidatorAddressPrefixes = func() []string {
+	prefixes := make([]string, len(AddressPrefixes))
+	for i, prefix := range AddressPrefixes {
+		prefixes[i] = prefix + "valoper"
+	}
+	return prefixes
+}()
+
+// InsertStakingEvent inserts the event and updates the delegations it changes.
+// The delegations are summed up again from staking_event, so inserting the same event again does not count it twice.
+// Addresses are normalized, so the delegations of the same address with different prefixes are summed up together.
+func (batch *Batch) InsertStakingEvent(e StakingEvent) {
+	e.Delegator = utils.NormalizeAddress(e.Delegator, MainAddressPrefix)
+	e.Validator = utils.NormalizeAddress(e.Validator, ValidatorAddressPrefixes[0])
+	e.DstValidator = utils.NormalizeAddress(e.DstValidator, ValidatorAddressPrefixes[0])
+	conflict := "DO NOTHING"
+	if batch.Overwrite {
+		conflict = `DO UPDATE SET
+		dst_validator = EXCLUDED.dst_validator,
+		amount = EXCLUDED.amount,
+		completion_time = EXCLUDED.completion_time,
+		height = EXCLUDED.height,
+		timestamp = EXCLUDED.timestamp`
+	}
+	sql := fmt.Sprintf(`
+	INSERT INTO staking_event (
+		action, delegator, validator, dst_validator, denom,
+		amount, completion_time, height, tx_hash, msg_index,
+		timestamp
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (tx_hash, msg_index, action, delegator, validator, denom) %s
+	`, conflict)
+	batch.Batch.Queue(sql,
+		e.Action, e.Delegator, e.Validator, e.DstValidator, e.Denom,
+		e.Amount, e.CompletionTime, e.Height, e.TxHash, e.MsgIndex,
+		e.Timestamp,
+	)
+	switch e.Action {
+	case STAKING_DELEGATE, STAKING_UNDELEGATE, STAKING_CANCEL_UNBONDING:
+		batch.updateDelegation(e.Delegator, e.Validator, e.Height)
+	case STAKING_REDELEGATE:
+		batch.updateDelegation(e.Delegator, e.Validator, e.Height)
+		batch.updateDelegation(e.Delegator, e.DstValidator, e.Height)
+	}
+}
+
+// updateDelegation sums up the indexed staking events of the delegator and validator into the net delegated amount.
+// It is not reconciled with the chain state, so slashing and the delegations in the genesis file are not counted.
+func (batch *Batch) updateDelegation(delegator, validator string, height int64) {
+	sql := `
+	INSERT INTO staking_delegation (delegator, validator, amount, height)
+	SELECT $1, $2, COALESCE(SUM(
+		CASE
+			WHEN action IN ('delegate', 'cancel_unbonding') AND validator = $2 THEN amount
+			WHEN action IN ('undelegate', 'redelegate') AND validator = $2 THEN -amount
+			WHEN action = 'redelegate' AND dst_validator = $2 THEN amount
+			ELSE 0
+		END
+	), 0), $3
+	FROM staking_event
+	WHERE delegator = $1 AND (validator = $2 OR dst_validator = $2)
+	ON CONFLICT (delegator, validator) DO UPDATE SET
+		amount = EXCLUDED.amount,
+		height = GREATEST(staking_delegation.height, EXCLUDED.height)
+	`
+	batch.Batch.Queue(sql, delegator, validator, height)
+}
+
+func GetStakingEvents(conn *pgxpool.Conn, q QueryStakingEventsRequest, p PageRequest) (QueryStakingEventsResponse, error) {
+	delegatorVariations := utils.ConvertAddressPrefixes(q.Delegator, AddressPrefixes)
+	validatorVariations := utils.ConvertAddressPrefixes(q.Validator, ValidatorAddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT id, action, delegator, validator, dst_validator,
+			denom, amount::text, completion_time, height, tx_hash,
+			msg_index, timestamp
+		FROM staking_event
+		WHERE ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR delegator = ANY($4))
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR validator = ANY($5) OR dst_validator = ANY($5))
+			AND ($6::text[] IS NULL OR cardinality($6::text[]) = 0 OR action = ANY($6))
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(
+		ctx, sql,
+		p.After(), p.Before(), p.Limit, delegatorVariations, validatorVariations,
+		q.Action,
+	)
+	if err != nil {
+		logger.L.Errorw("Failed to query staking events", "error", err, "q", q)
+		return QueryStakingEventsResponse{}, fmt.Errorf("query staking events error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryStakingEventsResponse{
+		Events: make([]StakingEvent, 0),
+	}
+	for rows.Next() {
+		var e StakingEvent
+		if err = rows.Scan(
+			&res.Pagination.NextKey, &e.Action, &e.Delegator, &e.Validator, &e.DstValidator,
+			&e.Denom, &e.Amount, &e.CompletionTime, &e.Height, &e.TxHash,
+			&e.MsgIndex, &e.Timestamp,
+		); err != nil {
+			logger.L.Errorw("Failed to scan staking event", "error", err, "q", q)
+			return QueryStakingEventsResponse{}, fmt.Errorf("scan staking event error: %w", err)
+		}
+		res.Events = append(res.Events, e)
+	}
+	res.Pagination.Count = len(res.Events)
+	return res, nil
+}
+
+// GetDelegations returns the delegations with positive net amount, i.e. the net sum of the indexed staking events,
+// which does not reflect slashing or the delegations in the genesis file
+func GetDelegations(conn *pgxpool.Conn, q QueryDelegationsRequest, p PageRequest) (QueryDelegationsResponse, error) {
+	delegatorVariations := utils.ConvertAddressPrefixes(q.Delegator, AddressPrefixes)
+	validatorVariations := utils.ConvertAddressPrefixes(q.Validator, ValidatorAddressPrefixes)
+	sql := fmt.Sprintf(`
+		SELECT id, delegator, validator, amount::text, height
+		FROM staking_delegation
+		WHERE amount > 0
+			AND ($1 = 0 OR id > $1)
+			AND ($2 = 0 OR id < $2)
+			AND ($4::text[] IS NULL OR cardinality($4::text[]) = 0 OR delegator = ANY($4))
+			AND ($5::text[] IS NULL OR cardinality($5::text[]) = 0 OR validator = ANY($5))
+		ORDER BY id %s
+		LIMIT $3
+	`, p.Order())
+
+	ctx, cancel := GetTimeoutContext()
+	defer cancel()
+
+	rows, err := conn.Query(
+		ctx, sql,
+		p.After(), p.Before(), p.Limit, delegatorVariations, validatorVariations,
+	)
+	if err != nil {
+		logger.L.Errorw("Failed to query delegations", "error", err, "q", q)
+		return QueryDelegationsResponse{}, fmt.Errorf("query delegations error: %w", err)
+	}
+	defer rows.Close()
+
+	res := QueryDelegationsResponse{
+		Delegations: make([]Delegation, 0),
+	}
+	for rows.Next() {
+		var d Delegation
+		if err = rows.Scan(&res.Pagination.NextKey, &d.Delegator, &d.Validator, &d.NetAmount, &d.Height); err != nil {
+			logger.L.Errorw("Failed to scan delegation", "error", err, "q", q)
+			return QueryDelegationsResponse{}, fmt.Errorf("scan delegation error: %w", err)
+		}
+		res.Delegations = append(res.Delegations, d)
+	}
+	res.Pagination.Count = len(res.Delegations)
+	return res, nil
+}
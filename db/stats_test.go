@@ -303,6 +303,7 @@ func TestNftCreatorCount(t *testing.T) {
 
 func TestNftOwnerCount(t *testing.T) {
 	defer CleanupTestData(Conn)
+	burnedHeight := int64(1)
 	nfts := []Nft{
 		{
 			NftId: "testing-nft-1123123098",
@@ -328,12 +329,21 @@ func TestNftOwnerCount(t *testing.T) {
 			NftId: "testing-nft-1123123103",
 			Owner: ADDR_03_LIKE,
 		},
+		{
+			NftId:        "testing-nft-1123123104",
+			Owner:        ADDR_04_LIKE,
+			BurnedHeight: &burnedHeight,
+		},
 	}
 	InsertTestData(DBTestData{Nfts: nfts})
 
-	res, err := GetNftOwnerCount(Conn)
+	res, err := GetNftOwnerCount(Conn, QueryNftOwnersRequest{})
 	require.NoError(t, err)
 	require.Equal(t, uint64(3), res.Count)
+
+	res, err = GetNftOwnerCount(Conn, QueryNftOwnersRequest{IncludeBurned: true})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), res.Count)
 }
 
 func TestNftOwnerList(t *testing.T) {
@@ -393,7 +403,7 @@ func TestNftOwnerList(t *testing.T) {
 	}
 
 	for i, testCase := range testCases {
-		res, err := GetNftOwnerList(Conn, testCase.pagination)
+		res, err := GetNftOwnerList(Conn, QueryNftOwnersRequest{}, testCase.pagination)
 		require.NoError(t, err)
 		require.Equal(t, len(testCase.owners), len(res.Owners), "test case #%02d: %s", i, testCase.name)
 		for j, resOwner := range res.Owners {
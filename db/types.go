@@ -49,6 +49,8 @@ type IscnInsert struct {
 	Fingerprints []string
 	Stakeholders []Stakeholder
 	Data         []byte
+	Height       int64
+	TxHash       string
 }
 
 type IscnQuery struct {
@@ -61,6 +63,8 @@ type IscnQuery struct {
 	StakeholderId   string   `form:"stakeholder.id"`
 	StakeholderName string   `form:"stakeholder.name"`
 	AllIscnVersions bool     `form:"all_iscn_versions"`
+	MinHeight       int64    `form:"min_height"`
+	MaxHeight       int64    `form:"max_height"`
 }
 
 func (q IscnQuery) Empty() bool {
@@ -70,7 +74,83 @@ func (q IscnQuery) Empty() bool {
 		len(q.Keywords) == 0 &&
 		len(q.Fingerprints) == 0 &&
 		q.StakeholderId == "" &&
-		q.StakeholderName == ""
+		q.StakeholderName == "" &&
+		q.MinHeight == 0 &&
+		q.MaxHeight == 0
+}
+
+type IscnEventAction string
+
+const (
+	ISCN_ACTION_CREATE           IscnEventAction = "create"
+	ISCN_ACTION_UPDATE           IscnEventAction = "update"
+	ISCN_ACTION_CHANGE_OWNERSHIP IscnEventAction = "change_ownership"
+)
+
+type IscnEvent struct {
+	Action       IscnEventAction `json:"action"`
+	IscnId       string          `json:"iscn_id"`
+	IscnIdPrefix string          `json:"iscn_id_prefix"`
+	Version      int             `json:"version"`
+	// signer of the message, i.e. the granter if executed by MsgExec
+	Sender string `json:"sender"`
+	// empty for ISCN_ACTION_CREATE, the same as NewOwner for ISCN_ACTION_UPDATE
+	PrevOwner string    `json:"prev_owner"`
+	NewOwner  string    `json:"new_owner"`
+	Height    int64     `json:"height"`
+	TxHash    string    `json:"tx_hash"`
+	MsgIndex  int       `json:"msg_index"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type IscnVersion struct {
+	IscnId    string    `json:"iscn_id"`
+	Version   int       `json:"version"`
+	Owner     string    `json:"owner"`
+	Timestamp time.Time `json:"timestamp"`
+	Ipld      string    `json:"ipld"`
+	// 0 and empty if the record is not backfilled yet and the transaction is not extracted into iscn_event
+	Height int64  `json:"height"`
+	TxHash string `json:"tx_hash"`
+	// changes from the previous version, nil for the first version
+	Diff *IscnVersionDiff `json:"diff,omitempty"`
+}
+
+type IscnVersionDiff struct {
+	ContentMetadata     IscnObjectDiff `json:"contentMetadata"`
+	Stakeholders        IscnListDiff   `json:"stakeholders"`
+	ContentFingerprints IscnListDiff   `json:"contentFingerprints"`
+}
+
+// IscnObjectDiff is the difference between the fields of two JSON objects
+type IscnObjectDiff struct {
+	Added   map[string]json.RawMessage `json:"added"`
+	Removed map[string]json.RawMessage `json:"removed"`
+	Changed map[string]IscnValueChange `json:"changed"`
+}
+
+type IscnValueChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// IscnListDiff is the difference between the elements of two JSON arrays regardless of their order
+type IscnListDiff struct {
+	Added   []json.RawMessage `json:"added"`
+	Removed []json.RawMessage `json:"removed"`
+}
+
+type QueryIscnVersionsResponse struct {
+	IscnIdPrefix  string        `json:"iscn_id_prefix"`
+	LatestVersion int           `json:"latest_version"`
+	Versions      []IscnVersion `json:"versions"`
+}
+
+type QueryIscnProvenanceResponse struct {
+	IscnIdPrefix string `json:"iscn_id_prefix"`
+	// owner after the latest event
+	Owner  string      `json:"owner"`
+	Events []IscnEvent `json:"events"`
 }
 
 type NftClass struct {
@@ -86,6 +166,8 @@ type NftClass struct {
 	CreatedAt      time.Time       `json:"created_at"`
 	LatestPrice    uint64          `json:"latest_price,omitempty"`
 	PriceUpdatedAt *time.Time      `json:"price_updated_at,omitempty"`
+	Height         int64           `json:"height"`
+	TxHash         string          `json:"tx_hash"`
 }
 
 type NftClassParent struct {
@@ -119,6 +201,9 @@ type Nft struct {
 	Timestamp      time.Time       `json:"timestamp"`
 	LatestPrice    uint64          `json:"latest_price,omitempty"`
 	PriceUpdatedAt *NoTimeZoneTime `json:"price_updated_at,omitempty"`
+	Height         int64           `json:"height"`
+	TxHash         string          `json:"tx_hash"`
+	BurnedHeight   *int64          `json:"burned_height,omitempty"`
 }
 
 type NftEventAction string
@@ -130,6 +215,7 @@ const (
 	ACTION_UPDATE_CLASS NftEventAction = "update_class"
 	ACTION_BUY          NftEventAction = "buy_nft"
 	ACTION_SELL         NftEventAction = "sell_nft"
+	ACTION_BURN         NftEventAction = "burn_nft"
 )
 
 type NftEvent struct {
@@ -152,6 +238,8 @@ type NftMarketplaceItem struct {
 	Creator    string    `json:"creator"`
 	Price      uint64    `json:"price,omitempty"`
 	Expiration time.Time `json:"expiration,omitempty"`
+	Height     int64     `json:"height"`
+	TxHash     string    `json:"tx_hash"`
 }
 
 type NftIncome struct {
@@ -161,6 +249,7 @@ type NftIncome struct {
 	Address   string `json:"address"`
 	Amount    uint64 `json:"amount"`
 	IsRoyalty bool   `json:"is_royalty"`
+	Height    int64  `json:"height"`
 }
 
 type LegacyPageRequest struct {
@@ -210,8 +299,10 @@ type IscnResponse struct {
 }
 
 type iscnResponseRecord struct {
-	Ipld string           `json:"ipld,omitempty"`
-	Data iscnResponseData `json:"data,omitempty"`
+	Ipld   string           `json:"ipld,omitempty"`
+	Data   iscnResponseData `json:"data,omitempty"`
+	Height int64            `json:"height"`
+	TxHash string           `json:"tx_hash"`
 }
 
 type iscnResponseData struct {
@@ -230,6 +321,9 @@ type QueryClassRequest struct {
 	IscnOwner       []string `form:"iscn_owner"`
 	Owner           string   `form:"owner"`
 	AllIscnVersions bool     `form:"all_iscn_versions"`
+	MinHeight       int64    `form:"min_height"`
+	MaxHeight       int64    `form:"max_height"`
+	IncludeBurned   bool     `form:"include_burned"`
 }
 
 type QueryClassResponse struct {
@@ -248,6 +342,9 @@ type NftClassResponse struct {
 type QueryNftRequest struct {
 	Owner         string `form:"owner" binding:"required"`
 	ExpandClasses bool   `form:"expand_classes"`
+	MinHeight     int64  `form:"min_height"`
+	MaxHeight     int64  `form:"max_height"`
+	IncludeBurned bool   `form:"include_burned"`
 }
 
 type QueryNftResponse struct {
@@ -265,6 +362,7 @@ type QueryOwnerRequest struct {
 	ClassId          string   `form:"class_id" binding:"required"`
 	ExcludeIscnOwner bool     `form:"exclude_iscn_owner"`
 	IgnoreList       []string `form:"ignore_list"`
+	IncludeBurned    bool     `form:"include_burned"`
 }
 
 type QueryOwnerResponse struct {
@@ -345,6 +443,7 @@ type QueryRankingRequest struct {
 	After           int64    `form:"after"`
 	Before          int64    `form:"before"`
 	OrderBy         string   `form:"order_by"`
+	IncludeBurned   bool     `form:"include_burned"`
 }
 
 type QueryRankingResponse struct {
@@ -366,6 +465,7 @@ type QueryCollectorRequest struct {
 	IncludeOwner    bool     `form:"include_owner,default=true"`
 	PriceBy         string   `form:"price_by,default=nft"`
 	OrderBy         string   `form:"order_by,default=price"`
+	IncludeBurned   bool     `form:"include_burned"`
 }
 
 type QueryCollectorResponse struct {
@@ -380,6 +480,7 @@ type QueryCreatorRequest struct {
 	IncludeOwner    bool     `form:"include_owner,default=true"`
 	PriceBy         string   `form:"price_by,default=nft"`
 	OrderBy         string   `form:"order_by,default=price"`
+	IncludeBurned   bool     `form:"include_burned"`
 }
 
 type QueryCreatorResponse struct {
@@ -405,6 +506,7 @@ type QueryUserStatRequest struct {
 	User            string   `form:"user"`
 	IgnoreList      []string `form:"ignore_list"`
 	AllIscnVersions bool     `form:"all_iscn_versions"`
+	IncludeBurned   bool     `form:"include_burned"`
 }
 
 type QueryUserStatResponse struct {
@@ -442,8 +544,9 @@ type QueryNftReturningCreatorCountResponse struct {
 }
 
 type QueryNftCountRequest struct {
-	IncludeOwner bool     `form:"include_owner"`
-	IgnoreList   []string `form:"ignore_list"`
+	IncludeOwner  bool     `form:"include_owner"`
+	IgnoreList    []string `form:"ignore_list"`
+	IncludeBurned bool     `form:"include_burned"`
 }
 
 type QueryNftTradeStatsRequest struct {
@@ -454,17 +557,23 @@ type QueryNftTradeStatsResponse struct {
 	TotalVolume uint64 `json:"total_volume"`
 }
 
+type QueryNftOwnersRequest struct {
+	IncludeBurned bool `form:"include_burned"`
+}
+
 type QueryNftOwnerListResponse struct {
 	Owners     []OwnerResponse `json:"owners"`
 	Pagination PageResponse    `json:"pagination"`
 }
 
 type QueryNftMarketplaceItemsRequest struct {
-	Type    string `form:"type"`
-	ClassId string `form:"class_id"`
-	NftId   string `form:"nft_id"`
-	Creator string `form:"creator"`
-	Expand  bool   `form:"expand"`
+	Type      string `form:"type"`
+	ClassId   string `form:"class_id"`
+	NftId     string `form:"nft_id"`
+	Creator   string `form:"creator"`
+	Expand    bool   `form:"expand"`
+	MinHeight int64  `form:"min_height"`
+	MaxHeight int64  `form:"max_height"`
 }
 
 type NftMarketplaceItemResponse struct {
@@ -484,6 +593,7 @@ type QueryCollectorTopRankedCreatorsRequest struct {
 	AllIscnVersions bool     `form:"all_iscn_versions"`
 	IncludeOwner    bool     `form:"include_owner,default=true"`
 	Top             uint     `form:"top,default=5"`
+	IncludeBurned   bool     `form:"include_burned"`
 }
 
 type CollectorTopRankedCreator struct {
@@ -496,11 +606,409 @@ type QueryCollectorTopRankedCreatorsResponse struct {
 }
 
 type QueryClassesOwnersRequest struct {
-	ClassIds []string `form:"class_ids" binding:"required"`
-	Owners   []string `form:"owners"`
+	ClassIds      []string `form:"class_ids" binding:"required"`
+	Owners        []string `form:"owners"`
+	IncludeBurned bool     `form:"include_burned"`
 }
 
 type QueryClassesOwnersResponse struct {
 	// key: owner address, value: class IDs
 	Owners map[string][]string `json:"owners"`
 }
+
+type Block struct {
+	Height          int64     `json:"height"`
+	Hash            string    `json:"hash"`
+	ProposerAddress string    `json:"proposer_address"`
+	Time            time.Time `json:"time"`
+	TxCount         int       `json:"tx_count"`
+}
+
+type QueryBlocksRequest struct {
+	After           int64  `form:"after"`
+	Before          int64  `form:"before"`
+	Empty           *bool  `form:"empty"`
+	ProposerAddress string `form:"proposer_address"`
+}
+
+type QueryBlocksResponse struct {
+	Blocks     []Block      `json:"blocks"`
+	Pagination PageResponse `json:"pagination"`
+}
+
+type QueryBlockAtTimeRequest struct {
+	Time int64 `form:"time" binding:"required"`
+}
+
+type HeightTxCount struct {
+	Height int64
+	// number of transactions recorded in the blocks table, nil if the block header is not indexed
+	BlockTxCount *int
+	// number of rows in the txs table
+	TxCount int
+}
+
+type ExtractFailure struct {
+	Id         int64      `json:"id"`
+	Height     int64      `json:"height"`
+	TxHash     string     `json:"tx_hash"`
+	MsgIndex   int        `json:"msg_index"`
+	EventType  string     `json:"event_type"`
+	Processor  string     `json:"processor"`
+	Error      string     `json:"error"`
+	RetryCount int        `json:"retry_count"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+type QueryExtractFailuresRequest struct {
+	Resolved  bool   `form:"resolved"`
+	TxHash    string `form:"tx_hash"`
+	Processor string `form:"processor"`
+}
+
+type QueryExtractFailuresResponse struct {
+	Failures   []ExtractFailure `json:"failures"`
+	Pagination PageResponse     `json:"pagination"`
+}
+
+type RetryExtractFailuresRequest struct {
+	Ids []int64 `json:"ids" form:"ids"`
+}
+
+type RetryExtractFailuresResponse struct {
+	Resolved []int64 `json:"resolved"`
+	Failed   []int64 `json:"failed"`
+}
+
+type ExtractorCursor struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	Height  int64  `json:"height"`
+}
+
+type TokenTransfer struct {
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Denom    string `json:"denom"`
+	// integer amount of denom in decimal string, which may exceed uint64
+	Amount    string    `json:"amount"`
+	Height    int64     `json:"height"`
+	TxHash    string    `json:"tx_hash"`
+	MsgIndex  int       `json:"msg_index"`
+	Memo      string    `json:"memo"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type QueryTokenTransfersRequest struct {
+	// either sender or receiver
+	Address  string `form:"address"`
+	Sender   string `form:"sender"`
+	Receiver string `form:"receiver"`
+	Denom    string `form:"denom"`
+	After    int64  `form:"after"`
+	Before   int64  `form:"before"`
+}
+
+type QueryTokenTransfersResponse struct {
+	Pagination PageResponse    `json:"pagination"`
+	Transfers  []TokenTransfer `json:"transfers"`
+}
+
+type StakingEventAction string
+
+const (
+	STAKING_DELEGATE         StakingEventAction = "delegate"
+	STAKING_UNDELEGATE       StakingEventAction = "undelegate"
+	STAKING_REDELEGATE       StakingEventAction = "redelegate"
+	STAKING_CANCEL_UNBONDING StakingEventAction = "cancel_unbonding"
+	STAKING_WITHDRAW_REWARD  StakingEventAction = "withdraw_reward"
+)
+
+type StakingEvent struct {
+	Action    StakingEventAction `json:"action"`
+	Delegator string             `json:"delegator"`
+	// source validator for redelegation
+	Validator    string `json:"validator"`
+	DstValidator string `json:"dst_validator,omitempty"`
+	Denom        string `json:"denom"`
+	// integer amount of denom in decimal string, the rewards withdrawn for STAKING_WITHDRAW_REWARD
+	Amount string `json:"amount"`
+	// for undelegation and redelegation
+	CompletionTime *time.Time `json:"completion_time,omitempty"`
+	Height         int64      `json:"height"`
+	TxHash         string     `json:"tx_hash"`
+	MsgIndex       int        `json:"msg_index"`
+	Timestamp      time.Time  `json:"timestamp"`
+}
+
+type Delegation struct {
+	Delegator string `json:"delegator"`
+	Validator string `json:"validator"`
+	// net sum of the delegated tokens in the indexed staking events, in the bond denom.
+	// Slashing and the delegations in the genesis file are not counted, so it may differ from the delegation on chain.
+	NetAmount string `json:"net_amount"`
+	// height of the last change
+	Height int64 `json:"height"`
+}
+
+type QueryStakingEventsRequest struct {
+	Delegator string `form:"delegator"`
+	// either source or destination validator
+	Validator string               `form:"validator"`
+	Action    []StakingEventAction `form:"action"`
+}
+
+type QueryStakingEventsResponse struct {
+	Pagination PageResponse   `json:"pagination"`
+	Events     []StakingEvent `json:"events"`
+}
+
+type QueryDelegationsRequest struct {
+	Delegator string `form:"delegator"`
+	Validator string `form:"validator"`
+}
+
+type QueryDelegationsResponse struct {
+	Pagination  PageResponse `json:"pagination"`
+	Delegations []Delegation `json:"delegations"`
+}
+
+type GovProposal struct {
+	ProposalId    uint64          `json:"proposal_id"`
+	Proposer      string          `json:"proposer"`
+	ProposalTypes []string        `json:"proposal_types"`
+	Title         string          `json:"title"`
+	Description   string          `json:"description"`
+	Metadata      string          `json:"metadata"`
+	Content       json.RawMessage `json:"content"`
+	Height        int64           `json:"height"`
+	TxHash        string          `json:"tx_hash"`
+	Timestamp     time.Time       `json:"timestamp"`
+}
+
+type GovDeposit struct {
+	ProposalId uint64 `json:"proposal_id"`
+	Depositor  string `json:"depositor"`
+	Denom      string `json:"denom"`
+	// integer amount of denom in decimal string
+	Amount    string    `json:"amount"`
+	Height    int64     `json:"height"`
+	TxHash    string    `json:"tx_hash"`
+	MsgIndex  int       `json:"msg_index"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type GovVoteOption struct {
+	Option string `json:"option"`
+	Weight string `json:"weight"`
+}
+
+type GovVote struct {
+	ProposalId uint64 `json:"proposal_id"`
+	Voter      string `json:"voter"`
+	// single option with weight 1 for non-weighted votes
+	Options   []GovVoteOption `json:"options"`
+	Height    int64           `json:"height"`
+	TxHash    string          `json:"tx_hash"`
+	MsgIndex  int             `json:"msg_index"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+type QueryGovProposalsRequest struct {
+	ProposalId uint64 `form:"proposal_id"`
+	Proposer   string `form:"proposer"`
+}
+
+type QueryGovProposalsResponse struct {
+	Pagination PageResponse  `json:"pagination"`
+	Proposals  []GovProposal `json:"proposals"`
+}
+
+type QueryGovDepositsRequest struct {
+	ProposalId uint64 `form:"proposal_id"`
+	Depositor  string `form:"depositor"`
+}
+
+type QueryGovDepositsResponse struct {
+	Pagination PageResponse `json:"pagination"`
+	Deposits   []GovDeposit `json:"deposits"`
+}
+
+type QueryGovVotesRequest struct {
+	ProposalId uint64 `form:"proposal_id"`
+	Voter      string `form:"voter"`
+	// e.g. VOTE_OPTION_YES or yes, matching any option of weighted votes
+	Option string `form:"option"`
+}
+
+type QueryGovVotesResponse struct {
+	Pagination PageResponse `json:"pagination"`
+	Votes      []GovVote    `json:"votes"`
+}
+
+type IbcTransferDirection string
+
+const (
+	IBC_TRANSFER_OUTGOING IbcTransferDirection = "outgoing"
+	IBC_TRANSFER_INCOMING IbcTransferDirection = "incoming"
+)
+
+type IbcTransferStatus string
+
+const (
+	// outgoing transfer waiting for the acknowledgement
+	IBC_TRANSFER_PENDING   IbcTransferStatus = "pending"
+	IBC_TRANSFER_COMPLETED IbcTransferStatus = "completed"
+	// rejected by the receiving chain, the tokens of outgoing transfers are refunded
+	IBC_TRANSFER_FAILED IbcTransferStatus = "failed"
+	// outgoing transfer timed out and refunded
+	IBC_TRANSFER_TIMEOUT IbcTransferStatus = "timeout"
+)
+
+// IbcPacket identifies a packet, from the source chain to the destination chain
+type IbcPacket struct {
+	Sequence   uint64 `json:"sequence"`
+	SrcPort    string `json:"src_port"`
+	SrcChannel string `json:"src_channel"`
+	DstPort    string `json:"dst_port"`
+	DstChannel string `json:"dst_channel"`
+}
+
+type IbcTransfer struct {
+	IbcPacket
+	Direction IbcTransferDirection `json:"direction"`
+	Status    IbcTransferStatus    `json:"status"`
+	Sender    string               `json:"sender"`
+	Receiver  string               `json:"receiver"`
+	// denom on this chain, e.g. nanolike or ibc/...
+	Denom string `json:"denom"`
+	// trace path of the denom on this chain, e.g. transfer/channel-0, empty for native denoms
+	DenomPath string `json:"denom_path"`
+	BaseDenom string `json:"base_denom"`
+	// integer amount of denom in decimal string
+	Amount    string    `json:"amount"`
+	Memo      string    `json:"memo"`
+	Error     string    `json:"error,omitempty"`
+	Height    int64     `json:"height"`
+	TxHash    string    `json:"tx_hash"`
+	MsgIndex  int       `json:"msg_index"`
+	Timestamp time.Time `json:"timestamp"`
+	// the acknowledgement or timeout of outgoing transfers
+	CompletedHeight    *int64     `json:"completed_height,omitempty"`
+	CompletedTxHash    string     `json:"completed_tx_hash,omitempty"`
+	CompletedTimestamp *time.Time `json:"completed_timestamp,omitempty"`
+}
+
+type IbcDenomTrace struct {
+	// ibc/<hash>
+	Denom     string `json:"denom"`
+	Path      string `json:"path"`
+	BaseDenom string `json:"base_denom"`
+}
+
+type QueryIbcTransfersRequest struct {
+	// either sender or receiver
+	Address   string               `form:"address"`
+	Sender    string               `form:"sender"`
+	Receiver  string               `form:"receiver"`
+	Direction IbcTransferDirection `form:"direction"`
+	Status    []IbcTransferStatus  `form:"status"`
+	// channel on either side
+	Channel string `form:"channel"`
+	// either denom on this chain or base denom
+	Denom string `form:"denom"`
+}
+
+type QueryIbcTransfersResponse struct {
+	Pagination PageResponse  `json:"pagination"`
+	Transfers  []IbcTransfer `json:"transfers"`
+}
+
+type QueryIbcDenomTracesRequest struct {
+	// ibc/<hash> or <hash>
+	Denom     string `form:"denom"`
+	BaseDenom string `form:"base_denom"`
+}
+
+type QueryIbcDenomTracesResponse struct {
+	DenomTraces []IbcDenomTrace `json:"denom_traces"`
+}
+
+// AuthzGrantKey identifies a grant, there is at most one grant of a message type from a granter to a grantee
+type AuthzGrantKey struct {
+	Granter    string `json:"granter"`
+	Grantee    string `json:"grantee"`
+	MsgTypeUrl string `json:"msg_type_url"`
+}
+
+type AuthzGrant struct {
+	AuthzGrantKey
+	AuthorizationType string          `json:"authorization_type"`
+	Authorization     json.RawMessage `json:"authorization"`
+	// spend limit of SendAuthorization or max tokens of StakeAuthorization when granted, in the form of [{"denom":...,"amount":...}]
+	SpendLimit json.RawMessage `json:"spend_limit,omitempty"`
+	Expiration *time.Time      `json:"expiration,omitempty"`
+	Height     int64           `json:"height"`
+	TxHash     string          `json:"tx_hash"`
+	Timestamp  time.Time       `json:"timestamp"`
+	// the latest MsgExec executing the grant
+	LastExecHeight    *int64     `json:"last_exec_height,omitempty"`
+	LastExecTxHash    string     `json:"last_exec_tx_hash,omitempty"`
+	LastExecTimestamp *time.Time `json:"last_exec_timestamp,omitempty"`
+}
+
+type QueryAuthzGrantsRequest struct {
+	Granter    string `form:"granter"`
+	Grantee    string `form:"grantee"`
+	MsgTypeUrl string `form:"msg_type_url"`
+}
+
+type QueryAuthzGrantsResponse struct {
+	Pagination PageResponse `json:"pagination"`
+	Grants     []AuthzGrant `json:"grants"`
+}
+
+// FeegrantKey identifies a fee allowance, there is at most one allowance from a granter to a grantee
+type FeegrantKey struct {
+	Granter string `json:"granter"`
+	Grantee string `json:"grantee"`
+}
+
+type Feegrant struct {
+	FeegrantKey
+	AllowanceType string          `json:"allowance_type"`
+	Allowance     json.RawMessage `json:"allowance"`
+	// total spend limit of the allowance, nil for unlimited
+	SpendLimit types.Coins `json:"spend_limit,omitempty"`
+	// spend limit minus the fees spent, nil for unlimited
+	SpendLimitLeft types.Coins `json:"spend_limit_left,omitempty"`
+	FeesSpent      types.Coins `json:"fees_spent"`
+	Expiration     *time.Time  `json:"expiration,omitempty"`
+	Height         int64       `json:"height"`
+	TxHash         string      `json:"tx_hash"`
+	Timestamp      time.Time   `json:"timestamp"`
+	// the latest transaction with the fee paid by the allowance
+	LastUsedHeight    *int64     `json:"last_used_height,omitempty"`
+	LastUsedTxHash    string     `json:"last_used_tx_hash,omitempty"`
+	LastUsedTimestamp *time.Time `json:"last_used_timestamp,omitempty"`
+}
+
+type FeegrantUsage struct {
+	FeegrantKey
+	Fee       types.Coins
+	Height    int64
+	TxHash    string
+	Timestamp time.Time
+}
+
+type QueryFeegrantsRequest struct {
+	Granter string `form:"granter"`
+	Grantee string `form:"grantee"`
+}
+
+type QueryFeegrantsResponse struct {
+	Pagination PageResponse `json:"pagination"`
+	Feegrants  []Feegrant   `json:"feegrants"`
+}
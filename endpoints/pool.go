@@ -0,0 +1,307 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+const LatestBlockPath = "/cosmos/base/tendermint/v1beta1/blocks/latest"
+
+// endpoints lagging behind the highest one by more than this number of blocks are skipped
+var maxHeightLag = int64(utils.EnvInt("LCD_MAX_HEIGHT_LAG", 5))
+
+// number of consecutive errors before an endpoint is considered down
+var maxConsecutiveErrors = utils.EnvInt("LCD_MAX_CONSECUTIVE_ERRORS", 3)
+var downDuration = time.Duration(utils.EnvInt("LCD_DOWN_DURATION", 30)) * time.Second
+
+// weight of the latest sample in the moving averages of error rate and latency
+const ewmaWeight = 0.2
+
+type StatusCodeError struct {
+	StatusCode int
+}
+
+func (e *StatusCodeError) Error() string {
+	return fmt.Sprintf("non-200 code returned: %d", e.StatusCode)
+}
+
+// isNodeError tells whether the error is caused by the node being unhealthy,
+// rather than the request itself (e.g. querying a non-existing transaction)
+func isNodeError(err error) bool {
+	statusErr, ok := err.(*StatusCodeError)
+	if !ok {
+		return true
+	}
+	return statusErr.StatusCode >= 500
+}
+
+type Endpoint struct {
+	URL string
+
+	mu                sync.Mutex
+	errorRate         float64
+	latency           time.Duration
+	latestHeight      int64
+	consecutiveErrors int
+	downUntil         time.Time
+}
+
+type EndpointStatus struct {
+	URL          string        `json:"url"`
+	ErrorRate    float64       `json:"error_rate"`
+	Latency      time.Duration `json:"latency"`
+	LatestHeight int64         `json:"latest_height"`
+	Healthy      bool          `json:"healthy"`
+}
+
+// Report records the result of a request sent to the endpoint
+func (e *Endpoint) Report(latency time.Duration, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	failed := err != nil && isNodeError(err)
+	sample := 0.0
+	if failed {
+		sample = 1.0
+	}
+	e.errorRate = e.errorRate*(1-ewmaWeight) + sample*ewmaWeight
+	if !failed {
+		if e.latency == 0 {
+			e.latency = latency
+		} else {
+			e.latency = time.Duration(float64(e.latency)*(1-ewmaWeight) + float64(latency)*ewmaWeight)
+		}
+		e.consecutiveErrors = 0
+		return
+	}
+	e.consecutiveErrors++
+	if e.consecutiveErrors >= maxConsecutiveErrors {
+		e.downUntil = time.Now().Add(downDuration)
+		logger.L.Warnw("LCD endpoint is marked as down", "url", e.URL, "error", err, "down_until", e.downUntil)
+	}
+}
+
+func (e *Endpoint) ReportHeight(height int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if height > e.latestHeight {
+		e.latestHeight = height
+	}
+}
+
+func (e *Endpoint) status(maxHeight int64, now time.Time) EndpointStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	lagging := e.latestHeight > 0 && maxHeight-e.latestHeight > maxHeightLag
+	return EndpointStatus{
+		URL:          e.URL,
+		ErrorRate:    e.errorRate,
+		Latency:      e.latency,
+		LatestHeight: e.latestHeight,
+		Healthy:      !lagging && !now.Before(e.downUntil),
+	}
+}
+
+// score is used for ordering endpoints, lower is better
+func (s EndpointStatus) score() float64 {
+	// add a base latency, so endpoints failing before any successful request are not always preferred
+	return float64(s.Latency+time.Millisecond) * (1 + 10*s.ErrorRate)
+}
+
+// Pool routes requests to a set of LCD endpoints serving the same chain,
+// preferring healthy endpoints with low error rate and latency, and skipping endpoints lagging behind the others.
+type Pool struct {
+	client    *http.Client
+	endpoints []*Endpoint
+}
+
+func NewPool(client *http.Client, urls []string) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no LCD endpoint is given")
+	}
+	endpoints := make([]*Endpoint, 0, len(urls))
+	for _, u := range urls {
+		u = strings.TrimSuffix(strings.TrimSpace(u), "/")
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse LCD endpoint %s: %w", u, err)
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid LCD endpoint: %s", u)
+		}
+		endpoints = append(endpoints, &Endpoint{URL: u})
+	}
+	return &Pool{client: client, endpoints: endpoints}, nil
+}
+
+func (p *Pool) Endpoints() []*Endpoint {
+	return p.endpoints
+}
+
+func (p *Pool) maxHeight() int64 {
+	maxHeight := int64(0)
+	for _, e := range p.endpoints {
+		e.mu.Lock()
+		if e.latestHeight > maxHeight {
+			maxHeight = e.latestHeight
+		}
+		e.mu.Unlock()
+	}
+	return maxHeight
+}
+
+func (p *Pool) Status() []EndpointStatus {
+	maxHeight := p.maxHeight()
+	now := time.Now()
+	statuses := make([]EndpointStatus, len(p.endpoints))
+	for i, e := range p.endpoints {
+		statuses[i] = e.status(maxHeight, now)
+	}
+	return statuses
+}
+
+// Candidates returns the endpoints in the order they should be tried,
+// healthy endpoints come first, unhealthy ones are still returned as the last resort
+func (p *Pool) Candidates() []*Endpoint {
+	statuses := p.Status()
+	indices := make([]int, len(p.endpoints))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		sa, sb := statuses[indices[a]], statuses[indices[b]]
+		if sa.Healthy != sb.Healthy {
+			return sa.Healthy
+		}
+		return sa.score() < sb.score()
+	})
+	candidates := make([]*Endpoint, len(indices))
+	for i, index := range indices {
+		candidates[i] = p.endpoints[index]
+	}
+	return candidates
+}
+
+// Pick returns the best endpoint at the moment
+func (p *Pool) Pick() *Endpoint {
+	return p.Candidates()[0]
+}
+
+// GetFrom sends a GET request to the endpoint and records the result
+func (p *Pool) GetFrom(e *Endpoint, path string) ([]byte, error) {
+	start := time.Now()
+	body, err := p.get(e.URL + path)
+	e.Report(time.Since(start), err)
+	return body, err
+}
+
+func (p *Pool) get(url string) ([]byte, error) {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, &StatusCodeError{StatusCode: resp.StatusCode}
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Get sends a GET request to the best endpoint, and fails over to the next one on error.
+// The error from the last endpoint is returned if all endpoints fail.
+func (p *Pool) Get(path string) ([]byte, error) {
+	var err error
+	for _, e := range p.Candidates() {
+		var body []byte
+		body, err = p.GetFrom(e, path)
+		if err == nil {
+			return body, nil
+		}
+		logger.L.Debugw("LCD request failed", "url", e.URL, "path", path, "error", err)
+	}
+	return nil, err
+}
+
+type latestBlockResponse struct {
+	Block struct {
+		Header struct {
+			Height string `json:"height"`
+		} `json:"header"`
+	} `json:"block"`
+}
+
+func (p *Pool) getLatestHeight(e *Endpoint) (int64, error) {
+	body, err := p.GetFrom(e, LatestBlockPath)
+	if err != nil {
+		return 0, err
+	}
+	res := latestBlockResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseInt(res.Block.Header.Height, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse latest height: %w", err)
+	}
+	e.ReportHeight(height)
+	return height, nil
+}
+
+// RefreshHeights queries the latest height of all endpoints,
+// and returns the highest one which a healthy endpoint is at
+func (p *Pool) RefreshHeights() (int64, error) {
+	errs := make([]error, len(p.endpoints))
+	wg := sync.WaitGroup{}
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, e *Endpoint) {
+			defer wg.Done()
+			_, errs[i] = p.getLatestHeight(e)
+			if errs[i] != nil {
+				logger.L.Warnw("Cannot get latest height from LCD endpoint", "url", e.URL, "error", errs[i])
+			}
+		}(i, e)
+	}
+	wg.Wait()
+	latestHeight := int64(0)
+	for i, s := range p.Status() {
+		if errs[i] == nil && s.Healthy && s.LatestHeight > latestHeight {
+			latestHeight = s.LatestHeight
+		}
+	}
+	if latestHeight == 0 {
+		for _, err := range errs {
+			if err != nil {
+				return 0, fmt.Errorf("cannot get latest height from any LCD endpoint: %w", err)
+			}
+		}
+		return 0, fmt.Errorf("no healthy LCD endpoint")
+	}
+	return latestHeight, nil
+}
+
+// StartHealthCheck refreshes the latest heights of the endpoints periodically in background until ctx is done
+func (p *Pool) StartHealthCheck(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			_, _ = p.RefreshHeights()
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
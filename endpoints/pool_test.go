@@ -0,0 +1,211 @@
+package endpoints
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.SetupLogger(zapcore.DebugLevel, []string{"stdout"}, "console")
+	os.Exit(m.Run())
+}
+
+type fakeLcd struct {
+	server   *httptest.Server
+	height   int64
+	fail     int32
+	requests int32
+}
+
+func newFakeLcd(name string, height int64) *fakeLcd {
+	lcd := &fakeLcd{height: height}
+	lcd.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&lcd.requests, 1)
+		if atomic.LoadInt32(&lcd.fail) != 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Path == LatestBlockPath {
+			fmt.Fprintf(w, `{"block":{"header":{"height":"%d"}}}`, lcd.height)
+			return
+		}
+		fmt.Fprintf(w, "%s %s", name, r.URL.EscapedPath())
+	}))
+	return lcd
+}
+
+func newTestPool(t *testing.T, lcds ...*fakeLcd) *Pool {
+	urls := []string{}
+	for _, lcd := range lcds {
+		urls = append(urls, lcd.server.URL+"/")
+	}
+	pool, err := NewPool(http.DefaultClient, urls)
+	require.NoError(t, err)
+	return pool
+}
+
+func TestNewPool(t *testing.T) {
+	_, err := NewPool(http.DefaultClient, nil)
+	require.Error(t, err)
+	_, err = NewPool(http.DefaultClient, []string{"localhost:1317"})
+	require.Error(t, err)
+	pool, err := NewPool(http.DefaultClient, []string{"http://localhost:1317/"})
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:1317", pool.Endpoints()[0].URL)
+}
+
+func TestPoolFailover(t *testing.T) {
+	a := newFakeLcd("a", 100)
+	defer a.server.Close()
+	b := newFakeLcd("b", 100)
+	defer b.server.Close()
+	pool := newTestPool(t, a, b)
+	// b is slower
+	pool.Endpoints()[1].Report(time.Second, nil)
+
+	body, err := pool.Get("/test")
+	require.NoError(t, err)
+	require.Equal(t, "a /test", string(body))
+
+	atomic.StoreInt32(&a.fail, 1)
+	for i := 0; i < maxConsecutiveErrors; i++ {
+		body, err = pool.Get("/test")
+		require.NoError(t, err)
+		require.Equal(t, "b /test", string(body))
+	}
+	require.Equal(t, int32(maxConsecutiveErrors+1), atomic.LoadInt32(&a.requests))
+	// a is marked as down, so requests go to b directly
+	body, err = pool.Get("/test")
+	require.NoError(t, err)
+	require.Equal(t, "b /test", string(body))
+	require.Equal(t, int32(maxConsecutiveErrors+1), atomic.LoadInt32(&a.requests))
+	require.Equal(t, b.server.URL, pool.Pick().URL)
+	statuses := pool.Status()
+	require.False(t, statuses[0].Healthy)
+	require.Greater(t, statuses[0].ErrorRate, 0.0)
+	require.True(t, statuses[1].Healthy)
+
+	// all endpoints failing
+	atomic.StoreInt32(&b.fail, 1)
+	_, err = pool.Get("/test")
+	require.Error(t, err)
+	require.Equal(t, &StatusCodeError{StatusCode: 500}, err)
+}
+
+func TestEndpointDown(t *testing.T) {
+	e := &Endpoint{URL: "http://localhost:1317"}
+	for i := 0; i < maxConsecutiveErrors-1; i++ {
+		e.Report(0, fmt.Errorf("connection refused"))
+	}
+	require.True(t, e.status(0, time.Now()).Healthy)
+	e.Report(0, fmt.Errorf("connection refused"))
+	require.False(t, e.status(0, time.Now()).Healthy)
+	require.True(t, e.status(0, time.Now().Add(downDuration)).Healthy)
+}
+
+func TestPoolClientErrorNotCounted(t *testing.T) {
+	e := &Endpoint{URL: "http://localhost:1317"}
+	for i := 0; i < maxConsecutiveErrors*2; i++ {
+		e.Report(0, &StatusCodeError{StatusCode: 404})
+	}
+	status := e.status(0, e.downUntil)
+	require.True(t, status.Healthy)
+	require.Equal(t, 0.0, status.ErrorRate)
+}
+
+func TestPoolSkipLaggingEndpoints(t *testing.T) {
+	a := newFakeLcd("a", 90)
+	defer a.server.Close()
+	b := newFakeLcd("b", 100)
+	defer b.server.Close()
+	c := newFakeLcd("c", 100)
+	defer c.server.Close()
+	pool := newTestPool(t, a, b, c)
+
+	height, err := pool.RefreshHeights()
+	require.NoError(t, err)
+	require.Equal(t, int64(100), height)
+
+	statuses := pool.Status()
+	require.False(t, statuses[0].Healthy)
+	require.Equal(t, int64(90), statuses[0].LatestHeight)
+	require.True(t, statuses[1].Healthy)
+	require.True(t, statuses[2].Healthy)
+	candidates := pool.Candidates()
+	require.Equal(t, a.server.URL, candidates[2].URL)
+
+	body, err := pool.Get("/test")
+	require.NoError(t, err)
+	require.NotEqual(t, "a /test", string(body))
+
+	// the highest endpoint failing should not stall the others
+	atomic.StoreInt32(&b.fail, 1)
+	atomic.StoreInt32(&c.fail, 1)
+	a.height = 101
+	height, err = pool.RefreshHeights()
+	require.NoError(t, err)
+	require.Equal(t, int64(101), height)
+}
+
+func TestReverseProxy(t *testing.T) {
+	a := newFakeLcd("a", 100)
+	defer a.server.Close()
+	b := newFakeLcd("b", 100)
+	defer b.server.Close()
+	pool := newTestPool(t, a, b)
+	for _, e := range pool.Endpoints()[:1] {
+		for i := 0; i < maxConsecutiveErrors; i++ {
+			e.Report(0, fmt.Errorf("connection refused"))
+		}
+	}
+	proxy := httptest.NewServer(pool.ReverseProxy())
+	defer proxy.Close()
+
+	res, err := http.Get(proxy.URL + "/cosmos/bank/v1beta1/balances/like1abc?x=1")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "b /cosmos/bank/v1beta1/balances/like1abc", string(body))
+
+	// escaped slashes are kept in a single path segment
+	res, err = http.Get(proxy.URL + "/cosmos/bank/v1beta1/denoms_metadata/ibc%2F1234")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "b /cosmos/bank/v1beta1/denoms_metadata/ibc%2F1234", string(body))
+}
+
+func TestJoinURLPath(t *testing.T) {
+	for _, tc := range []struct {
+		target, path, expected string
+	}{
+		{"http://lcd", "/cosmos/x", "/cosmos/x"},
+		{"http://lcd/", "/cosmos/x", "/cosmos/x"},
+		{"http://lcd/api", "/cosmos/x", "/api/cosmos/x"},
+		{"http://lcd/api/", "/cosmos/x", "/api/cosmos/x"},
+		{"http://lcd/api/", "/denoms/ibc%2F1234", "/api/denoms/ibc%2F1234"},
+	} {
+		target, err := url.Parse(tc.target)
+		require.NoError(t, err)
+		u, err := url.Parse(tc.path)
+		require.NoError(t, err)
+		u.Path, u.RawPath = joinURLPath(target, u)
+		require.Equal(t, tc.expected, u.EscapedPath(), tc.target+tc.path)
+	}
+}
@@ -0,0 +1,90 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+type proxyContextKey struct{}
+
+type proxyRequest struct {
+	endpoint *Endpoint
+	start    time.Time
+}
+
+// singleJoiningSlash and joinURLPath are the same as the unexported ones in net/http/httputil,
+// so escaped paths like IBC denoms with %2F are forwarded as they are
+func singleJoiningSlash(a, b string) string {
+	aslash := strings.HasSuffix(a, "/")
+	bslash := strings.HasPrefix(b, "/")
+	switch {
+	case aslash && bslash:
+		return a + b[1:]
+	case !aslash && !bslash:
+		return a + "/" + b
+	}
+	return a + b
+}
+
+func joinURLPath(a, b *url.URL) (path, rawpath string) {
+	if a.RawPath == "" && b.RawPath == "" {
+		return singleJoiningSlash(a.Path, b.Path), ""
+	}
+	apath := a.EscapedPath()
+	bpath := b.EscapedPath()
+	aslash := strings.HasSuffix(apath, "/")
+	bslash := strings.HasPrefix(bpath, "/")
+	switch {
+	case aslash && bslash:
+		return a.Path + b.Path[1:], apath + bpath[1:]
+	case !aslash && !bslash:
+		return a.Path + "/" + b.Path, apath + "/" + bpath
+	}
+	return a.Path + b.Path, apath + bpath
+}
+
+// ReverseProxy returns a reverse proxy which forwards each request to the best endpoint at the moment
+func (p *Pool) ReverseProxy() *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			e := p.Pick()
+			target, err := url.Parse(e.URL)
+			if err != nil {
+				// should not happen since the URLs are validated in NewPool
+				logger.L.Errorw("Cannot parse LCD endpoint URL", "url", e.URL, "error", err)
+				return
+			}
+			*req = *req.WithContext(context.WithValue(req.Context(), proxyContextKey{}, &proxyRequest{
+				endpoint: e,
+				start:    time.Now(),
+			}))
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.URL.Path, req.URL.RawPath = joinURLPath(target, req.URL)
+			req.Host = target.Host
+		},
+		ModifyResponse: func(res *http.Response) error {
+			if r, ok := res.Request.Context().Value(proxyContextKey{}).(*proxyRequest); ok {
+				var err error
+				if res.StatusCode >= 500 {
+					err = &StatusCodeError{StatusCode: res.StatusCode}
+				}
+				r.endpoint.Report(time.Since(r.start), err)
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			if r, ok := req.Context().Value(proxyContextKey{}).(*proxyRequest); ok {
+				r.endpoint.Report(time.Since(r.start), err)
+				logger.L.Warnw("Proxy request to LCD endpoint failed", "url", r.endpoint.URL, "path", req.URL.Path, "error", err)
+			}
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+}
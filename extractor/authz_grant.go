@@ -0,0 +1,134 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+type authzGrantMessage struct {
+	Grant struct {
+		Authorization json.RawMessage `json:"authorization"`
+		Expiration    *time.Time      `json:"expiration"`
+	} `json:"grant"`
+}
+
+type authzAuthorization struct {
+	Type string `json:"@type"`
+	// SendAuthorization
+	SpendLimit []bankCoin `json:"spend_limit"`
+	// StakeAuthorization
+	MaxTokens *bankCoin `json:"max_tokens"`
+}
+
+// signerFields are the fields of the signer in common messages, in the order of precedence
+var signerFields = []string{
+	"from_address", "delegator_address", "sender", "from", "creator", "owner",
+	"granter", "voter", "depositor", "proposer", "grantee",
+}
+
+// messageSigner returns the signer of the message, or empty string if it is not found in signerFields
+func messageSigner(msg json.RawMessage) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &fields); err != nil {
+		return ""
+	}
+	for _, field := range signerFields {
+		var signer string
+		if err := json.Unmarshal(fields[field], &signer); err == nil && signer != "" {
+			return signer
+		}
+	}
+	return ""
+}
+
+func authzGrantKeyFromEvent(event *types.StringEvent) db.AuthzGrantKey {
+	return db.AuthzGrantKey{
+		Granter:    utils.GetEventValue(event, "granter"),
+		Grantee:    utils.GetEventValue(event, "grantee"),
+		MsgTypeUrl: utils.GetEventValue(event, "msg_type_url"),
+	}
+}
+
+func grantAuthz(payload *Payload, event *types.StringEvent) error {
+	var message authzGrantMessage
+	if err := json.Unmarshal(payload.GetMessage(), &message); err != nil {
+		return fmt.Errorf("failed to unmarshal MsgGrant: %w", err)
+	}
+	var authorization authzAuthorization
+	if err := json.Unmarshal(message.Grant.Authorization, &authorization); err != nil {
+		return fmt.Errorf("failed to unmarshal authorization: %w", err)
+	}
+	grant := db.AuthzGrant{
+		AuthzGrantKey:     authzGrantKeyFromEvent(event),
+		AuthorizationType: authorization.Type,
+		Authorization:     message.Grant.Authorization,
+		Expiration:        message.Grant.Expiration,
+		Height:            payload.Height,
+		TxHash:            payload.TxHash,
+		Timestamp:         payload.Timestamp,
+	}
+	var spendLimit []bankCoin
+	if authorization.SpendLimit != nil {
+		spendLimit = authorization.SpendLimit
+	} else if authorization.MaxTokens != nil {
+		spendLimit = []bankCoin{*authorization.MaxTokens}
+	}
+	if spendLimit != nil {
+		var err error
+		grant.SpendLimit, err = json.Marshal(spendLimit)
+		if err != nil {
+			return fmt.Errorf("failed to marshal spend limit: %w", err)
+		}
+	}
+	payload.Batch.InsertAuthzGrant(grant)
+	return nil
+}
+
+// revokeAuthz handles MsgRevoke, and the grants deleted when executed, e.g. SendAuthorization with the spend limit used up
+func revokeAuthz(payload *Payload, event *types.StringEvent) error {
+	payload.Batch.RevokeAuthzGrant(authzGrantKeyFromEvent(event), payload.Height, payload.TxHash, payload.Timestamp)
+	return nil
+}
+
+// execAuthz records the execution of the grant by the messages inside MsgExec
+func execAuthz(payload *Payload, _ *types.StringEvent) error {
+	if payload.AuthzParent == nil {
+		return nil
+	}
+	var msgExec struct {
+		Grantee string `json:"grantee"`
+	}
+	if err := json.Unmarshal(payload.AuthzParent.Messages[payload.AuthzMsgIndex], &msgExec); err != nil {
+		return fmt.Errorf("failed to unmarshal MsgExec: %w", err)
+	}
+	var msg struct {
+		Type string `json:"@type"`
+	}
+	if err := json.Unmarshal(payload.GetMessage(), &msg); err != nil {
+		return fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	granter := messageSigner(payload.GetMessage())
+	if granter == "" || granter == msgExec.Grantee {
+		// unknown signer, or executed by the signer itself without a grant
+		return nil
+	}
+	key := db.AuthzGrantKey{
+		Granter:    granter,
+		Grantee:    msgExec.Grantee,
+		MsgTypeUrl: msg.Type,
+	}
+	payload.Batch.UpdateAuthzGrantExec(key, payload.Height, payload.TxHash, payload.Timestamp)
+	return nil
+}
+
+func init() {
+	authzGroup.RegisterType("cosmos.authz.v1beta1.EventGrant", grantAuthz)
+	authzGroup.RegisterType("cosmos.authz.v1beta1.EventRevoke", revokeAuthz)
+	authzGroup.RegisterAll(execAuthz)
+}
@@ -0,0 +1,260 @@
+package extractor_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+// authzGrantTx builds a transaction with the messages and the events of each message
+func authzGrantTx(height int, timestamp time.Time, msgs []string, msgEvents ...[]string) string {
+	logs := []string{}
+	for i, events := range msgEvents {
+		logs = append(logs, fmt.Sprintf(`{"msg_index":%d,"log":"","events":[%s]}`, i, strings.Join(events, ",")))
+	}
+	return fmt.Sprintf(
+		`{"height":"%[1]d","txhash":"AUTHZ%[1]d","tx":{"body":{"messages":[%[2]s],"memo":""}},"logs":[%[3]s],"timestamp":"%[4]s"}`,
+		height, strings.Join(msgs, ","), strings.Join(logs, ","), timestamp.Format(time.RFC3339),
+	)
+}
+
+// authzGrantEvent builds EventGrant or EventRevoke, with the JSON encoded values as typed events
+func authzGrantEvent(eventType, granter, grantee, msgTypeUrl string) string {
+	return fmt.Sprintf(
+		`{"type":"cosmos.authz.v1beta1.%s","attributes":[{"key":"grantee","value":"\"%s\""},{"key":"granter","value":"\"%s\""},{"key":"msg_type_url","value":"\"%s\""}]}`,
+		eventType, grantee, granter, msgTypeUrl,
+	)
+}
+
+func TestAuthzGrant(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	sendTypeUrl := "/cosmos.bank.v1beta1.MsgSend"
+	voteTypeUrl := "/cosmos.gov.v1beta1.MsgVote"
+	delegateTypeUrl := "/cosmos.staking.v1beta1.MsgDelegate"
+	grantMsg := `{"@type":"/cosmos.authz.v1beta1.MsgGrant","granter":"%s","grantee":"%s","grant":{"authorization":%s,"expiration":%s}}`
+	sendAuthorization := `{"@type":"/cosmos.bank.v1beta1.SendAuthorization","spend_limit":[{"denom":"nanolike","amount":"100"}]}`
+	voteAuthorization := `{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"` + voteTypeUrl + `"}`
+	stakeAuthorization := `{"@type":"/cosmos.staking.v1beta1.StakeAuthorization","max_tokens":{"denom":"nanolike","amount":"500"},"allow_list":{"address":["likevaloper1"]},"authorization_type":"AUTHORIZATION_TYPE_DELEGATE"}`
+	sendMsg := `{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s","amount":[{"denom":"nanolike","amount":"%d"}]}`
+	execMsg := `{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[%s]}`
+	execEvents := func(action string, events ...string) []string {
+		return append(events, fmt.Sprintf(
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"},{"key":"authz_msg_index","value":"0"},{"key":"module","value":"%s"},{"key":"authz_msg_index","value":"0"}]}`,
+			action,
+		))
+	}
+
+	txs := []string{
+		authzGrantTx(1, timestamp,
+			[]string{
+				fmt.Sprintf(grantMsg, ADDR_01_LIKE, ADDR_02_LIKE, sendAuthorization, `"2100-01-01T00:00:00Z"`),
+				fmt.Sprintf(grantMsg, ADDR_01_LIKE, ADDR_03_LIKE, voteAuthorization, `null`),
+				fmt.Sprintf(grantMsg, ADDR_01_LIKE, ADDR_04_LIKE, stakeAuthorization, `"2000-01-01T00:00:00Z"`),
+			},
+			[]string{authzGrantEvent("EventGrant", ADDR_01_LIKE, ADDR_02_LIKE, sendTypeUrl)},
+			[]string{authzGrantEvent("EventGrant", ADDR_01_LIKE, ADDR_03_LIKE, voteTypeUrl)},
+			[]string{authzGrantEvent("EventGrant", ADDR_01_LIKE, ADDR_04_LIKE, delegateTypeUrl)},
+		),
+		authzGrantTx(2, timestamp.Add(time.Hour),
+			[]string{fmt.Sprintf(execMsg, ADDR_02_LIKE, fmt.Sprintf(sendMsg, ADDR_01_LIKE, ADDR_02_LIKE, 30))},
+			execEvents("bank",
+				`{"type":"transfer","attributes":[{"key":"recipient","value":"`+ADDR_02_LIKE+`"},{"key":"sender","value":"`+ADDR_01_LIKE+`"},{"key":"amount","value":"30nanolike"},{"key":"authz_msg_index","value":"0"}]}`,
+			),
+		),
+		authzGrantTx(3, timestamp.Add(2*time.Hour),
+			[]string{fmt.Sprintf(`{"@type":"/cosmos.authz.v1beta1.MsgRevoke","granter":"%s","grantee":"%s","msg_type_url":"%s"}`, ADDR_01_LIKE, ADDR_03_LIKE, voteTypeUrl)},
+			[]string{authzGrantEvent("EventRevoke", ADDR_01_LIKE, ADDR_03_LIKE, voteTypeUrl)},
+		),
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	// the vote grant is revoked and the stake grant is expired
+	res, err := GetAuthzGrants(Conn, QueryAuthzGrantsRequest{Granter: ADDR_01_COSMOS}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Grants, 1)
+	grant := res.Grants[0]
+	require.Equal(t, AuthzGrantKey{Granter: ADDR_01_LIKE, Grantee: ADDR_02_LIKE, MsgTypeUrl: sendTypeUrl}, grant.AuthzGrantKey)
+	require.Equal(t, "/cosmos.bank.v1beta1.SendAuthorization", grant.AuthorizationType)
+	require.JSONEq(t, sendAuthorization, string(grant.Authorization))
+	require.JSONEq(t, `[{"denom":"nanolike","amount":"100"}]`, string(grant.SpendLimit))
+	require.NotNil(t, grant.Expiration)
+	require.Equal(t, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), grant.Expiration.UTC())
+	require.Equal(t, int64(1), grant.Height)
+	require.Equal(t, "AUTHZ1", grant.TxHash)
+	require.NotNil(t, grant.LastExecHeight)
+	require.Equal(t, int64(2), *grant.LastExecHeight)
+	require.Equal(t, "AUTHZ2", grant.LastExecTxHash)
+	require.NotNil(t, grant.LastExecTimestamp)
+	require.Equal(t, timestamp.Add(time.Hour), grant.LastExecTimestamp.UTC())
+
+	res, err = GetAuthzGrants(Conn, QueryAuthzGrantsRequest{Grantee: ADDR_03_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, res.Grants)
+
+	var spendLimit string
+	err = Conn.QueryRow(context.Background(), `SELECT spend_limit::text FROM authz_grant WHERE grantee = $1`, ADDR_04_LIKE).Scan(&spendLimit)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"denom":"nanolike","amount":"500"}]`, spendLimit)
+
+	// the spend limit is used up, and the grant is deleted by the chain with EventRevoke outside the authz messages
+	InsertTestData(DBTestData{Txs: []string{
+		authzGrantTx(4, timestamp.Add(3*time.Hour),
+			[]string{fmt.Sprintf(execMsg, ADDR_02_LIKE, fmt.Sprintf(sendMsg, ADDR_01_LIKE, ADDR_02_LIKE, 70))},
+			execEvents("bank",
+				`{"type":"transfer","attributes":[{"key":"recipient","value":"`+ADDR_02_LIKE+`"},{"key":"sender","value":"`+ADDR_01_LIKE+`"},{"key":"amount","value":"70nanolike"},{"key":"authz_msg_index","value":"0"}]}`,
+				authzGrantEvent("EventRevoke", ADDR_01_LIKE, ADDR_02_LIKE, sendTypeUrl),
+			),
+		),
+	}})
+
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err = GetAuthzGrants(Conn, QueryAuthzGrantsRequest{Granter: ADDR_01_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, res.Grants)
+
+	var revokedHeight, lastExecHeight int64
+	err = Conn.QueryRow(context.Background(), `SELECT revoked_height, last_exec_height FROM authz_grant WHERE grantee = $1`, ADDR_02_LIKE).Scan(&revokedHeight, &lastExecHeight)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), revokedHeight)
+	require.Equal(t, int64(4), lastExecHeight)
+}
+
+// apiSaleTx builds the transaction of the API wallet selling an NFT, with the buyer paying the API wallet by MsgExec
+func apiSaleTx(height int, timestamp time.Time, classId, nftId, buyer, apiWallet, seller string) string {
+	transferEvents := func(sender, receiver string, amount int, authzTags string) []string {
+		return []string{
+			fmt.Sprintf(`{"type":"coin_received","attributes":[{"key":"receiver","value":"%s"},{"key":"amount","value":"%dnanolike"}%s]}`, receiver, amount, authzTags),
+			fmt.Sprintf(`{"type":"transfer","attributes":[{"key":"recipient","value":"%s"},{"key":"sender","value":"%s"},{"key":"amount","value":"%dnanolike"}%s]}`, receiver, sender, amount, authzTags),
+		}
+	}
+	authzTags := `,{"key":"authz_msg_index","value":"0"}`
+	return authzGrantTx(height, timestamp,
+		[]string{
+			fmt.Sprintf(`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%[2]s","msgs":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%[1]s","to_address":"%[2]s","amount":[{"denom":"nanolike","amount":"100"}]}]}`, buyer, apiWallet),
+			fmt.Sprintf(`{"@type":"/cosmos.nft.v1beta1.MsgSend","class_id":"%s","id":"%s","sender":"%s","receiver":"%s"}`, classId, nftId, apiWallet, buyer),
+			fmt.Sprintf(`{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s","amount":[{"denom":"nanolike","amount":"100"}]}`, apiWallet, seller),
+		},
+		append(transferEvents(buyer, apiWallet, 100, authzTags),
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"}]}`,
+		),
+		[]string{
+			fmt.Sprintf(`{"type":"cosmos.nft.v1beta1.EventSend","attributes":[{"key":"class_id","value":"\"%s\""},{"key":"id","value":"\"%s\""},{"key":"receiver","value":"\"%s\""},{"key":"sender","value":"\"%s\""}]}`, classId, nftId, buyer, apiWallet),
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.nft.v1beta1.MsgSend"}]}`,
+		},
+		append(transferEvents(apiWallet, seller, 100, ""),
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.bank.v1beta1.MsgSend"}]}`,
+		),
+	)
+}
+
+func TestSendNftWithPriceWithoutGrant(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	classId := "nftlike1nogrant"
+	nftId := "testing-nft-nogrant"
+	buyer := ADDR_02_LIKE
+	apiWallet := ADDR_03_LIKE
+	seller := ADDR_01_LIKE
+	tx := apiSaleTx(1, timestamp, classId, nftId, buyer, apiWallet, seller)
+	InsertTestData(DBTestData{
+		Iscns:      []IscnInsert{{Iscn: "iscn://testing/nogrant/1", Owner: seller}},
+		NftClasses: []NftClass{{Id: classId, Parent: NftClassParent{IscnIdPrefix: "iscn://testing/nogrant"}}},
+		Nfts:       []Nft{{NftId: nftId, ClassId: classId, Owner: apiWallet}},
+		Txs:        []string{tx},
+	})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	// the NFT is still sent, but the tokens are not sent by a grant to the API wallet, so it is not a sale
+	ownersRes, err := GetOwners(Conn, QueryOwnerRequest{ClassId: classId})
+	require.NoError(t, err)
+	require.Len(t, ownersRes.Owners, 1)
+	require.Equal(t, buyer, ownersRes.Owners[0].Owner)
+
+	incomesRes, err := GetNftIncomes(Conn, QueryIncomesRequest{ClassId: classId}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, incomesRes.ClassIncomes)
+
+	// the grants are still verified when re-extracting the incomes alone, since the authz group has extracted the height
+	err = extractor.Reextract(context.Background(), Conn, 1, 1, []string{extractor.GroupIncome})
+	require.NoError(t, err)
+	incomesRes, err = GetNftIncomes(Conn, QueryIncomesRequest{ClassId: classId}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, incomesRes.ClassIncomes)
+}
+
+func TestSendNftWithPriceWithInvalidGrant(t *testing.T) {
+	timestamp := time.Unix(1680000000, 0).UTC()
+	buyer := ADDR_02_LIKE
+	apiWallet := ADDR_03_LIKE
+	seller := ADDR_01_LIKE
+	expiredAt := timestamp.Add(-time.Hour)
+	notExpiredAt := timestamp.Add(time.Hour)
+	for _, tc := range []struct {
+		name          string
+		expiration    *time.Time
+		revokedHeight int64
+		hasIncome     bool
+	}{
+		{"valid", &notExpiredAt, 0, true},
+		{"revoked before the sale", nil, 1, false},
+		{"revoked after the sale", nil, 3, true},
+		{"expired before the sale", &expiredAt, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer CleanupTestData(Conn)
+			classId := "nftlike1invalidgrant"
+			nftId := "testing-nft-invalidgrant"
+			grant := AuthzGrant{
+				AuthzGrantKey:     AuthzGrantKey{Granter: buyer, Grantee: apiWallet, MsgTypeUrl: "/cosmos.bank.v1beta1.MsgSend"},
+				AuthorizationType: "/cosmos.bank.v1beta1.SendAuthorization",
+				Authorization:     []byte(`{}`),
+				Expiration:        tc.expiration,
+				Height:            1,
+				TxHash:            "GRANT",
+				Timestamp:         timestamp.Add(-2 * time.Hour),
+			}
+			InsertTestData(DBTestData{
+				Iscns:       []IscnInsert{{Iscn: "iscn://testing/invalidgrant/1", Owner: seller}},
+				NftClasses:  []NftClass{{Id: classId, Parent: NftClassParent{IscnIdPrefix: "iscn://testing/invalidgrant"}}},
+				Nfts:        []Nft{{NftId: nftId, ClassId: classId, Owner: apiWallet}},
+				AuthzGrants: []AuthzGrant{grant},
+				Txs:         []string{apiSaleTx(2, timestamp, classId, nftId, buyer, apiWallet, seller)},
+			})
+			if tc.revokedHeight != 0 {
+				_, err := Conn.Exec(context.Background(), `UPDATE authz_grant SET revoked_height = $1, revoked_tx_hash = 'REVOKE'`, tc.revokedHeight)
+				require.NoError(t, err)
+			}
+
+			finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+			require.NoError(t, err)
+			require.True(t, finished)
+
+			incomesRes, err := GetNftIncomes(Conn, QueryIncomesRequest{ClassId: classId}, PageRequest{Limit: 10})
+			require.NoError(t, err)
+			if tc.hasIncome {
+				require.Len(t, incomesRes.ClassIncomes, 1)
+			} else {
+				require.Empty(t, incomesRes.ClassIncomes)
+			}
+		})
+	}
+}
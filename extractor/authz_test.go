@@ -0,0 +1,106 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// testAuthzChains records the messages of the AuthzParent chain of each test.EventAuthz, from the innermost MsgExec
+var testAuthzChains = map[string][]string{}
+
+func testAuthzProcessor(payload *Payload, event *types.StringEvent) error {
+	chain := []string{}
+	for parent := payload.AuthzParent; parent != nil; parent = parent.AuthzParent {
+		var msgExec struct {
+			Grantee string `json:"grantee"`
+		}
+		// every parent in the test transactions has a single MsgExec
+		if err := json.Unmarshal(parent.Messages[0], &msgExec); err != nil {
+			return err
+		}
+		chain = append(chain, msgExec.Grantee)
+	}
+	testAuthzChains[utils.GetEventValue(event, "id")] = chain
+	return nil
+}
+
+// newTestAuthzExtractor returns an extractor with the test group only, so it is not registered to the other tests
+func newTestAuthzExtractor() *EventExtractor {
+	e := NewEventExtractor()
+	e.Group("test_authz", 1).RegisterType("test.EventAuthz", testAuthzProcessor)
+	return e
+}
+
+// nestedAuthzTx wraps msg in depth levels of MsgExec, granted to grantee-1 (outermost) ... grantee-<depth> (innermost),
+// and tags each event with authz_msg_index once for every level as the chain does
+func nestedAuthzTx(height int, txHash string, depth int, msg string, events ...string) string {
+	for level := depth; level >= 1; level-- {
+		msg = fmt.Sprintf(`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"grantee-%d","msgs":[%s]}`, level, msg)
+	}
+	authzIndexes := strings.Repeat(`,{"key":"authz_msg_index","value":"0"}`, depth)
+	taggedEvents := []string{}
+	for _, event := range events {
+		// event is in the form of {"type":"...","attributes":[...]}
+		taggedEvents = append(taggedEvents, strings.TrimSuffix(event, "]}")+authzIndexes+"]}")
+	}
+	taggedEvents = append(taggedEvents, `{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"},{"key":"sender","value":"grantee-1"}]}`)
+	return fmt.Sprintf(
+		`{"height":"%d","txhash":"%s","tx":{"body":{"messages":[%s],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[%s]}],"timestamp":"%s"}`,
+		height, txHash, msg, strings.Join(taggedEvents, ","), time.Unix(123456789, 0).UTC().Format(time.RFC3339),
+	)
+}
+
+func TestExtractNestedAuthz(t *testing.T) {
+	defer CleanupTestData(Conn)
+	defer func() { testAuthzChains = map[string][]string{} }()
+	iscnMsg := `{"@type":"/likechain.iscn.MsgCreateIscnRecord","from":"%[1]s","record":{"contentFingerprints":["hash://testing/%[2]s"],"stakeholders":[],"contentMetadata":{"name":"%[2]s"}}}`
+	iscnEvent := `{"type":"iscn_record","attributes":[{"key":"iscn_id","value":"iscn://testing/%[1]s/1"},{"key":"iscn_id_prefix","value":"iscn://testing/%[1]s"},{"key":"owner","value":"%[2]s"},{"key":"ipld","value":"ipld%[1]s"}]}`
+	authzEvent := `{"type":"test.EventAuthz","attributes":[{"key":"id","value":"%s"}]}`
+	txs := []string{}
+	for depth := 1; depth <= 3; depth++ {
+		name := fmt.Sprintf("NESTED%d", depth)
+		txs = append(txs, nestedAuthzTx(
+			depth, name, depth,
+			fmt.Sprintf(iscnMsg, ADDR_01_LIKE, name),
+			fmt.Sprintf(iscnEvent, name, ADDR_01_LIKE),
+			fmt.Sprintf(authzEvent, name),
+		))
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	e := newTestAuthzExtractor()
+	batch := NewBatch(Conn, 1)
+	for depth := 1; depth <= 3; depth++ {
+		name := fmt.Sprintf("NESTED%d", depth)
+		eventCtx, err := GetEventContextOfTx(Conn, &batch, int64(depth), name)
+		require.NoError(t, err)
+		require.NoError(t, e.Extract(eventCtx))
+
+		res, err := QueryIscn(Conn, IscnQuery{IscnIdPrefix: "iscn://testing/" + name}, PageRequest{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, res.Records, 1, name)
+		require.Equal(t, "iscn://testing/"+name+"/1", res.Records[0].Data.Id)
+		require.Equal(t, ADDR_01_LIKE, res.Records[0].Data.Owner)
+
+		expectedChain := []string{}
+		for level := depth; level >= 1; level-- {
+			expectedChain = append(expectedChain, fmt.Sprintf("grantee-%d", level))
+		}
+		require.Equal(t, expectedChain, testAuthzChains[name])
+	}
+}
@@ -0,0 +1,121 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+const (
+	bankMsgSendTypeUrl      = "/cosmos.bank.v1beta1.MsgSend"
+	bankMsgMultiSendTypeUrl = "/cosmos.bank.v1beta1.MsgMultiSend"
+)
+
+type bankCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type bankSendMessage struct {
+	FromAddress string     `json:"from_address"`
+	ToAddress   string     `json:"to_address"`
+	Amount      []bankCoin `json:"amount"`
+}
+
+type bankInputOutput struct {
+	Address string     `json:"address"`
+	Coins   []bankCoin `json:"coins"`
+}
+
+type bankMultiSendMessage struct {
+	Inputs  []bankInputOutput `json:"inputs"`
+	Outputs []bankInputOutput `json:"outputs"`
+}
+
+// tokenTransfers sums up the amounts of the transfers in a message by sender, receiver and denom,
+// which identify a transfer together with the message
+type tokenTransfers struct {
+	keys    []db.TokenTransfer
+	amounts map[db.TokenTransfer]*big.Int
+}
+
+func newTokenTransfers() *tokenTransfers {
+	return &tokenTransfers{amounts: map[db.TokenTransfer]*big.Int{}}
+}
+
+func (t *tokenTransfers) add(sender, receiver string, coins []bankCoin) error {
+	for _, coin := range coins {
+		amount, ok := new(big.Int).SetString(coin.Amount, 10)
+		if !ok {
+			return fmt.Errorf("invalid amount %s of denom %s", coin.Amount, coin.Denom)
+		}
+		key := db.TokenTransfer{Sender: sender, Receiver: receiver, Denom: coin.Denom}
+		if sum, ok := t.amounts[key]; ok {
+			sum.Add(sum, amount)
+			continue
+		}
+		t.keys = append(t.keys, key)
+		t.amounts[key] = amount
+	}
+	return nil
+}
+
+func (t *tokenTransfers) insert(payload *Payload) {
+	for _, key := range t.keys {
+		transfer := key
+		transfer.Amount = t.amounts[key].String()
+		transfer.Height = payload.Height
+		transfer.TxHash = payload.TxHash
+		transfer.MsgIndex = payload.TxMsgIndex()
+		transfer.Memo = payload.Memo
+		transfer.Timestamp = payload.Timestamp
+		payload.Batch.InsertTokenTransfer(transfer)
+	}
+}
+
+func sendTokens(payload *Payload, event *types.StringEvent) error {
+	var message bankSendMessage
+	if err := json.Unmarshal(payload.GetMessage(), &message); err != nil {
+		return fmt.Errorf("failed to unmarshal MsgSend: %w", err)
+	}
+	transfers := newTokenTransfers()
+	if err := transfers.add(message.FromAddress, message.ToAddress, message.Amount); err != nil {
+		return err
+	}
+	transfers.insert(payload)
+	return nil
+}
+
+// multiSendTokens records the outputs of a MsgMultiSend. With a single input, its address is the
+// sender of every output. With several inputs the outputs cannot be attributed to any one of them,
+// so the sender is stored as "" and such transfers are only found by receiver
+func multiSendTokens(payload *Payload, event *types.StringEvent) error {
+	var message bankMultiSendMessage
+	if err := json.Unmarshal(payload.GetMessage(), &message); err != nil {
+		return fmt.Errorf("failed to unmarshal MsgMultiSend: %w", err)
+	}
+	sender := ""
+	if len(message.Inputs) == 1 {
+		sender = message.Inputs[0].Address
+	} else {
+		logger.L.Warnw("MsgMultiSend with multiple inputs, sender is left empty", "txhash", payload.TxHash, "inputs", len(message.Inputs))
+	}
+	transfers := newTokenTransfers()
+	for _, output := range message.Outputs {
+		if err := transfers.add(sender, output.Address, output.Coins); err != nil {
+			return err
+		}
+	}
+	transfers.insert(payload)
+	return nil
+}
+
+func init() {
+	bankGroup.RegisterTypeKeyValue("message", "action", bankMsgSendTypeUrl, sendTokens)
+	bankGroup.RegisterTypeKeyValue("message", "action", bankMsgMultiSendTypeUrl, multiSendTokens)
+}
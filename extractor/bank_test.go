@@ -0,0 +1,58 @@
+package extractor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestTokenTransfers(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	txs := []string{
+		fmt.Sprintf(`{"height":"1","txhash":"SEND","tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%[1]s","to_address":"%[2]s","amount":[{"denom":"nanolike","amount":"100000000000000000000000"},{"denom":"uatom","amount":"5"}]}],"memo":"send memo"}},"logs":[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"/cosmos.bank.v1beta1.MsgSend"},{"key":"sender","value":"%[1]s"},{"key":"module","value":"bank"}]}]}],"timestamp":"%[3]s"}`, ADDR_01_LIKE, ADDR_02_LIKE, timestamp.Format(time.RFC3339)),
+		fmt.Sprintf(`{"height":"2","txhash":"MULTISEND","tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgMultiSend","inputs":[{"address":"%[1]s","coins":[{"denom":"nanolike","amount":"16"}]}],"outputs":[{"address":"%[2]s","coins":[{"denom":"nanolike","amount":"10"}]},{"address":"%[3]s","coins":[{"denom":"nanolike","amount":"1"}]},{"address":"%[2]s","coins":[{"denom":"nanolike","amount":"5"}]}]}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"/cosmos.bank.v1beta1.MsgMultiSend"},{"key":"sender","value":"%[1]s"},{"key":"module","value":"bank"}]}]}],"timestamp":"%[4]s"}`, ADDR_01_LIKE, ADDR_02_LIKE, ADDR_03_LIKE, timestamp.Add(time.Hour).Format(time.RFC3339)),
+		// MsgSend inside MsgExec, after a MsgSend at the top level
+		fmt.Sprintf(`{"height":"3","txhash":"AUTHZSEND","tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%[2]s","to_address":"%[3]s","amount":[{"denom":"nanolike","amount":"7"}]},{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%[2]s","msgs":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%[1]s","to_address":"%[3]s","amount":[{"denom":"nanolike","amount":"3"}]}]}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"/cosmos.bank.v1beta1.MsgSend"},{"key":"sender","value":"%[2]s"},{"key":"module","value":"bank"}]}]},{"msg_index":1,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"},{"key":"sender","value":"%[1]s"},{"key":"authz_msg_index","value":"0"},{"key":"module","value":"bank"},{"key":"authz_msg_index","value":"0"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"%[3]s"},{"key":"sender","value":"%[1]s"},{"key":"amount","value":"3nanolike"},{"key":"authz_msg_index","value":"0"}]}]}],"timestamp":"%[4]s"}`, ADDR_01_LIKE, ADDR_02_LIKE, ADDR_03_LIKE, timestamp.Add(2*time.Hour).Format(time.RFC3339)),
+		// MsgMultiSend with two inputs, whose outputs cannot be attributed to either of them
+		fmt.Sprintf(`{"height":"4","txhash":"MULTIINPUT","tx":{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgMultiSend","inputs":[{"address":"%[1]s","coins":[{"denom":"nanolike","amount":"4"}]},{"address":"%[2]s","coins":[{"denom":"nanolike","amount":"2"}]}],"outputs":[{"address":"%[3]s","coins":[{"denom":"nanolike","amount":"6"}]}]}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"/cosmos.bank.v1beta1.MsgMultiSend"},{"key":"sender","value":"%[1]s"},{"key":"module","value":"bank"}]}]}],"timestamp":"%[4]s"}`, ADDR_01_LIKE, ADDR_02_LIKE, ADDR_03_LIKE, timestamp.Add(3*time.Hour).Format(time.RFC3339)),
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err := GetTokenTransfers(Conn, QueryTokenTransfersRequest{}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []TokenTransfer{
+		{Sender: ADDR_01_LIKE, Receiver: ADDR_02_LIKE, Denom: "nanolike", Amount: "100000000000000000000000", Height: 1, TxHash: "SEND", MsgIndex: 0, Memo: "send memo", Timestamp: timestamp},
+		{Sender: ADDR_01_LIKE, Receiver: ADDR_02_LIKE, Denom: "uatom", Amount: "5", Height: 1, TxHash: "SEND", MsgIndex: 0, Memo: "send memo", Timestamp: timestamp},
+		// outputs to the same address are summed up
+		{Sender: ADDR_01_LIKE, Receiver: ADDR_02_LIKE, Denom: "nanolike", Amount: "15", Height: 2, TxHash: "MULTISEND", MsgIndex: 0, Timestamp: timestamp.Add(time.Hour)},
+		{Sender: ADDR_01_LIKE, Receiver: ADDR_03_LIKE, Denom: "nanolike", Amount: "1", Height: 2, TxHash: "MULTISEND", MsgIndex: 0, Timestamp: timestamp.Add(time.Hour)},
+		{Sender: ADDR_02_LIKE, Receiver: ADDR_03_LIKE, Denom: "nanolike", Amount: "7", Height: 3, TxHash: "AUTHZSEND", MsgIndex: 0, Timestamp: timestamp.Add(2 * time.Hour)},
+		// the message inside MsgExec is identified by the index of the MsgExec
+		{Sender: ADDR_01_LIKE, Receiver: ADDR_03_LIKE, Denom: "nanolike", Amount: "3", Height: 3, TxHash: "AUTHZSEND", MsgIndex: 1, Timestamp: timestamp.Add(2 * time.Hour)},
+		// the sender is left empty when there are several inputs
+		{Sender: "", Receiver: ADDR_03_LIKE, Denom: "nanolike", Amount: "6", Height: 4, TxHash: "MULTIINPUT", MsgIndex: 0, Timestamp: timestamp.Add(3 * time.Hour)},
+	}, res.Transfers)
+
+	// such transfers are found by receiver but not by any of the inputs
+	res, err = GetTokenTransfers(Conn, QueryTokenTransfersRequest{Receiver: ADDR_03_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Transfers, 3)
+	require.Equal(t, "MULTIINPUT", res.Transfers[2].TxHash)
+
+	res, err = GetTokenTransfers(Conn, QueryTokenTransfersRequest{Sender: ADDR_02_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Transfers, 1)
+	require.Equal(t, "AUTHZSEND", res.Transfers[0].TxHash)
+}
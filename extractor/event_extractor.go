@@ -2,7 +2,12 @@ package extractor
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/types"
 
@@ -13,6 +18,12 @@ import (
 
 var eventExtractor = NewEventExtractor()
 
+const msgExecTypeUrl = "/cosmos.authz.v1beta1.MsgExec"
+
+// extractAuthzEventsList splits the events of a MsgExec into the events of each message inside it.
+// Each event emitted by an inner message is tagged with authz_msg_index, and events of messages in a nested MsgExec
+// are tagged once more by each enclosing MsgExec, so the last of the consecutive authz_msg_index attributes is the index at this level,
+// and the others are kept for extracting the nested MsgExec.
 func extractAuthzEventsList(events types.StringEvents) (db.EventsList, error) {
 	authzEvents := make(map[int]types.StringEvents)
 	specialEvents := []types.StringEvent{}
@@ -20,18 +31,22 @@ func extractAuthzEventsList(events types.StringEvents) (db.EventsList, error) {
 	for _, event := range events {
 		authzAttrsMap := make(map[int][]types.Attribute)
 		accumulatedAttrs := []types.Attribute{}
-		for _, attr := range event.Attributes {
+		for i, attr := range event.Attributes {
 			if event.Type == "message" &&
 				attr.Key == "action" &&
-				attr.Value == "/cosmos.authz.v1beta1.MsgExec" {
-				// skip this so authz messages won't be recursively extracted
-				// TODO: do we need recursive extraction?
+				attr.Value == msgExecTypeUrl {
+				// the action of the MsgExec itself, which does not belong to any message inside it
 				continue
 			}
 			if attr.Key != "authz_msg_index" {
 				accumulatedAttrs = append(accumulatedAttrs, attr)
 				continue
 			}
+			if i+1 < len(event.Attributes) && event.Attributes[i+1].Key == "authz_msg_index" {
+				// tagged by a nested MsgExec
+				accumulatedAttrs = append(accumulatedAttrs, attr)
+				continue
+			}
 			// authz_msg_index is indicating the msg index of previous attributes (which are in accumulatedAttrs)
 			msgIndexUint64, err := strconv.ParseUint(attr.Value, 10, 64)
 			if err != nil {
@@ -80,9 +95,36 @@ func extractAuthzMessages(msg json.RawMessage) ([]json.RawMessage, error) {
 	return msgExec.Msgs, nil
 }
 
+// addAuthzMessageActions adds message.action to the events of the messages inside MsgExec as the top level messages have,
+// which the chain does not emit for them, so processors on message.action also run on messages inside MsgExec
+func addAuthzMessageActions(authzCtx db.EventContext) {
+	for i, msg := range authzCtx.Messages {
+		if i >= len(authzCtx.EventsList) {
+			break
+		}
+		events := authzCtx.EventsList[i].Events
+		if utils.GetEventsValue(events, "message", "action") != "" {
+			continue
+		}
+		var typed struct {
+			Type string `json:"@type"`
+		}
+		if json.Unmarshal(msg, &typed) != nil || typed.Type == "" {
+			continue
+		}
+		authzCtx.EventsList[i].Events = append(types.StringEvents{{
+			Type:       "message",
+			Attributes: []types.Attribute{{Key: "action", Value: typed.Type}},
+		}}, events...)
+	}
+}
+
+// EventContextFromAuthz returns the context of the messages inside the MsgExec at msgIndex, with AuthzParent pointing to ctx.
+// For nested MsgExec, it is called again on the returned context, so AuthzParent forms a chain up to the transaction.
 func EventContextFromAuthz(ctx db.EventContext, msgIndex int) (db.EventContext, error) {
 	authzCtx := ctx
 	authzCtx.AuthzParent = &ctx
+	authzCtx.AuthzMsgIndex = msgIndex
 	var err error
 	authzCtx.Messages, err = extractAuthzMessages(ctx.Messages[msgIndex])
 	if err != nil {
@@ -92,12 +134,14 @@ func EventContextFromAuthz(ctx db.EventContext, msgIndex int) (db.EventContext,
 	if err != nil {
 		return db.EventContext{}, err
 	}
+	addAuthzMessageActions(authzCtx)
 	return authzCtx, nil
 }
 
 type Payload struct {
 	db.EventContext
 	MsgIndex int
+	filter   *processorFilter
 }
 
 func PayloadFromEventContext(ctx db.EventContext) *Payload {
@@ -107,6 +151,47 @@ func PayloadFromEventContext(ctx db.EventContext) *Payload {
 	}
 }
 
+// processorFilter limits the processors to run, for retrying a failed processor or extracting some groups only
+type processorFilter struct {
+	// -1 for any message
+	msgIndex int
+	// empty for any processor, otherwise only the processor on the event type is allowed
+	eventType string
+	processor string
+	// nil for any group
+	groups map[string]bool
+	// number of processors which passed the filter
+	matched int
+}
+
+func (f *processorFilter) allow(eventType string, p registeredProcessor) bool {
+	if f.groups != nil && !f.groups[p.group] {
+		return false
+	}
+	if f.processor != "" && (f.eventType != eventType || f.processor != p.name) {
+		return false
+	}
+	f.matched++
+	return true
+}
+
+func processorName(processor EventProcessor) string {
+	name := runtime.FuncForPC(reflect.ValueOf(processor).Pointer()).Name()
+	// strip the package path, e.g. github.com/likecoin/likecoin-chain-tx-indexer/extractor.sendNft -> extractor.sendNft
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// appendExtractErrors flattens err into errs
+func appendExtractErrors(errs db.ExtractErrors, err error) db.ExtractErrors {
+	if err == nil {
+		return errs
+	}
+	if extractErrs, ok := err.(db.ExtractErrors); ok {
+		return append(errs, extractErrs...)
+	}
+	return append(errs, &db.ExtractError{Err: err})
+}
+
 func (payload *Payload) Next() bool {
 	payload.MsgIndex++
 	// Not sure if len(payload.Messages) is always equal to len(payload.EventsList)
@@ -119,57 +204,183 @@ func (payload *Payload) GetMessage() json.RawMessage {
 	return payload.Messages[payload.MsgIndex]
 }
 
+// TxMsgIndex returns the index of the message in the transaction, for messages inside MsgExec it is the index of the outermost MsgExec
+func (payload *Payload) TxMsgIndex() int {
+	index := payload.MsgIndex
+	for ctx := &payload.EventContext; ctx.AuthzParent != nil; ctx = ctx.AuthzParent {
+		index = ctx.AuthzMsgIndex
+	}
+	return index
+}
+
+// extractsGroup tells whether the processors of the group run on the transactions together with the current processor
+func (payload *Payload) extractsGroup(name string) bool {
+	return payload.filter == nil || payload.filter.groups == nil || payload.filter.groups[name]
+}
+
 func (payload *Payload) GetEvents() types.StringEvents {
 	return payload.EventsList[payload.MsgIndex].Events
 }
 
 type EventProcessor func(payload *Payload, event *types.StringEvent) error
 
+type registeredProcessor struct {
+	processor EventProcessor
+	name      string
+	group     string
+}
+
 type EventExtractor struct {
-	typeKeyValueMap map[string]map[string]map[string][]EventProcessor
-	typeKeyMap      map[string]map[string][]EventProcessor
-	typeMap         map[string][]EventProcessor
-	wildcards       []EventProcessor
+	typeKeyValueMap map[string]map[string]map[string][]registeredProcessor
+	typeKeyMap      map[string]map[string][]registeredProcessor
+	typeMap         map[string][]registeredProcessor
+	wildcards       []registeredProcessor
+	// processors run once on each transaction, including failed ones without logs
+	txProcessors []registeredProcessor
+	// version of each group
+	groups map[string]int
 }
 
+var _ db.GroupedExtractor = (*EventExtractor)(nil)
+
 func NewEventExtractor() *EventExtractor {
 	return &EventExtractor{
-		typeKeyValueMap: make(map[string]map[string]map[string][]EventProcessor),
-		typeKeyMap:      make(map[string]map[string][]EventProcessor),
-		typeMap:         make(map[string][]EventProcessor),
+		typeKeyValueMap: make(map[string]map[string]map[string][]registeredProcessor),
+		typeKeyMap:      make(map[string]map[string][]registeredProcessor),
+		typeMap:         make(map[string][]registeredProcessor),
+		groups:          make(map[string]int),
 	}
 }
 
-func (e *EventExtractor) RegisterTypeKeyValue(eventType, key, value string, processor EventProcessor) {
+// EventExtractorGroup registers processors under a group, e.g. nft or iscn.
+// Each group has its own extraction progress, and can be re-extracted without running the processors of other groups.
+type EventExtractorGroup struct {
+	e    *EventExtractor
+	name string
+}
+
+// Group returns the group with the given name, the version should be bumped when the processors of the group are changed
+// in a way that the group should extract all transactions again, which is then done in background from height 0.
+func (e *EventExtractor) Group(name string, version int) *EventExtractorGroup {
+	if v, ok := e.groups[name]; ok && v != version {
+		panic(fmt.Sprintf("extractor group %s is registered with different versions %d and %d", name, v, version))
+	}
+	e.groups[name] = version
+	return &EventExtractorGroup{e: e, name: name}
+}
+
+// ExtractorGroup returns the name and version of the group, e.g. for reading its extraction progress
+func (g *EventExtractorGroup) ExtractorGroup() db.ExtractorGroup {
+	return db.ExtractorGroup{Name: g.name, Version: g.e.groups[g.name]}
+}
+
+// GroupNames returns the names of the registered groups in sorted order
+func (e *EventExtractor) GroupNames() []string {
+	names := make([]string, 0, len(e.groups))
+	for name := range e.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Groups returns the registered groups sorted by name
+func (e *EventExtractor) Groups() []db.ExtractorGroup {
+	names := e.GroupNames()
+	groups := make([]db.ExtractorGroup, len(names))
+	for i, name := range names {
+		groups[i] = db.ExtractorGroup{Name: name, Version: e.groups[name]}
+	}
+	return groups
+}
+
+// ForGroups returns an extractor which runs only the processors registered under the given groups
+func (e *EventExtractor) ForGroups(groups []db.ExtractorGroup) db.Extractor {
+	filter := map[string]bool{}
+	for _, g := range groups {
+		filter[g.Name] = true
+	}
+	return func(ctx db.EventContext) error {
+		return e.extract(ctx, &processorFilter{msgIndex: -1, groups: filter})
+	}
+}
+
+// ForGroupNames is ForGroups with the group names, which returns an error for unknown names
+func (e *EventExtractor) ForGroupNames(names []string) (db.Extractor, error) {
+	groups := []db.ExtractorGroup{}
+	for _, name := range names {
+		version, ok := e.groups[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown extractor group %s, expect one of %s", name, strings.Join(e.GroupNames(), ","))
+		}
+		groups = append(groups, db.ExtractorGroup{Name: name, Version: version})
+	}
+	return e.ForGroups(groups), nil
+}
+
+func (g *EventExtractorGroup) newProcessor(processor EventProcessor) registeredProcessor {
+	return registeredProcessor{
+		processor: processor,
+		name:      processorName(processor),
+		group:     g.name,
+	}
+}
+
+func (g *EventExtractorGroup) RegisterTypeKeyValue(eventType, key, value string, processor EventProcessor) {
+	e := g.e
 	if _, ok := e.typeKeyValueMap[eventType]; !ok {
-		e.typeKeyValueMap[eventType] = make(map[string]map[string][]EventProcessor)
+		e.typeKeyValueMap[eventType] = make(map[string]map[string][]registeredProcessor)
 	}
 	if _, ok := e.typeKeyValueMap[eventType][key]; !ok {
-		e.typeKeyValueMap[eventType][key] = make(map[string][]EventProcessor)
+		e.typeKeyValueMap[eventType][key] = make(map[string][]registeredProcessor)
 	}
-	e.typeKeyValueMap[eventType][key][value] = append(e.typeKeyValueMap[eventType][key][value], processor)
+	e.typeKeyValueMap[eventType][key][value] = append(e.typeKeyValueMap[eventType][key][value], g.newProcessor(processor))
 }
 
-func (e *EventExtractor) RegisterTypeKey(eventType, key string, processor EventProcessor) {
+func (g *EventExtractorGroup) RegisterTypeKey(eventType, key string, processor EventProcessor) {
+	e := g.e
 	if _, ok := e.typeKeyMap[eventType]; !ok {
-		e.typeKeyMap[eventType] = make(map[string][]EventProcessor)
+		e.typeKeyMap[eventType] = make(map[string][]registeredProcessor)
 	}
-	e.typeKeyMap[eventType][key] = append(e.typeKeyMap[eventType][key], processor)
+	e.typeKeyMap[eventType][key] = append(e.typeKeyMap[eventType][key], g.newProcessor(processor))
+}
+
+func (g *EventExtractorGroup) RegisterType(eventType string, processor EventProcessor) {
+	g.e.typeMap[eventType] = append(g.e.typeMap[eventType], g.newProcessor(processor))
 }
 
-func (e *EventExtractor) RegisterType(eventType string, processor EventProcessor) {
-	e.typeMap[eventType] = append(e.typeMap[eventType], processor)
+func (g *EventExtractorGroup) RegisterAll(processor EventProcessor) {
+	g.e.wildcards = append(g.e.wildcards, g.newProcessor(processor))
 }
 
-func (e *EventExtractor) RegisterAll(processor EventProcessor) {
-	e.wildcards = append(e.wildcards, processor)
+// RegisterTx registers a processor which runs once on each transaction with a nil event and MsgIndex -1,
+// including failed transactions which have no logs, so no message processors run on them
+func (g *EventExtractorGroup) RegisterTx(processor EventProcessor) {
+	g.e.txProcessors = append(g.e.txProcessors, g.newProcessor(processor))
 }
 
-func (e *EventExtractor) runProcessors(payload *Payload, event *types.StringEvent, processors []EventProcessor) error {
-	for _, processor := range processors {
-		if err := processor(payload, event); err != nil {
-			return err
+// runProcessors runs all processors even if some of them fail, the failures are returned as db.ExtractErrors
+func (e *EventExtractor) runProcessors(payload *Payload, event *types.StringEvent, processors []registeredProcessor) error {
+	eventType := ""
+	if event != nil {
+		eventType = event.Type
+	}
+	var errs db.ExtractErrors
+	for _, p := range processors {
+		if payload.filter != nil && !payload.filter.allow(eventType, p) {
+			continue
 		}
+		if err := p.processor(payload, event); err != nil {
+			errs = append(errs, &db.ExtractError{
+				MsgIndex:  payload.MsgIndex,
+				EventType: eventType,
+				Processor: p.name,
+				Err:       err,
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
@@ -179,15 +390,16 @@ func (e *EventExtractor) extractTypeKeyValue(payload *Payload, event *types.Stri
 	if kvMap == nil {
 		return nil
 	}
+	var errs db.ExtractErrors
 	for _, attribute := range event.Attributes {
 		vMap := kvMap[attribute.Key]
 		if vMap == nil {
 			continue
 		}
-		err := e.runProcessors(payload, event, vMap[attribute.Value])
-		if err != nil {
-			return err
-		}
+		errs = appendExtractErrors(errs, e.runProcessors(payload, event, vMap[attribute.Value]))
+	}
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
@@ -197,11 +409,12 @@ func (e *EventExtractor) extractTypeKey(payload *Payload, event *types.StringEve
 	if kMap == nil {
 		return nil
 	}
+	var errs db.ExtractErrors
 	for _, attribute := range event.Attributes {
-		err := e.runProcessors(payload, event, kMap[attribute.Key])
-		if err != nil {
-			return err
-		}
+		errs = appendExtractErrors(errs, e.runProcessors(payload, event, kMap[attribute.Key]))
+	}
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
@@ -222,18 +435,66 @@ func (e *EventExtractor) extractAll(payload *Payload) error {
 	return nil
 }
 
+// Extract runs the registered processors on the events of each message in the transaction.
+// A failed processor does not stop the others, all failures are returned together as db.ExtractErrors.
 func (e *EventExtractor) Extract(ctx db.EventContext) error {
+	return e.extract(ctx, nil)
+}
+
+// Retry runs the failed processor recorded in failure again on the transaction.
+// It returns an error if the processor fails again, or if no such processor is registered anymore.
+func (e *EventExtractor) Retry(ctx db.EventContext, failure db.ExtractFailure) error {
+	if failure.MsgIndex < 0 {
+		return e.Extract(ctx)
+	}
+	filter := &processorFilter{
+		msgIndex:  failure.MsgIndex,
+		eventType: failure.EventType,
+		processor: failure.Processor,
+	}
+	err := e.extract(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if filter.matched == 0 {
+		return fmt.Errorf("processor %s on event %s of message %d not found", failure.Processor, failure.EventType, failure.MsgIndex)
+	}
+	return nil
+}
+
+func (e *EventExtractor) extract(ctx db.EventContext, filter *processorFilter) error {
 	payload := PayloadFromEventContext(ctx)
+	payload.filter = filter
+	var errs db.ExtractErrors
+	if ctx.AuthzParent == nil && (filter == nil || filter.msgIndex < 0) {
+		errs = appendExtractErrors(errs, e.runProcessors(payload, nil, e.txProcessors))
+	}
 	for payload.Next() {
+		if filter != nil && filter.msgIndex >= 0 && filter.msgIndex != payload.MsgIndex {
+			continue
+		}
 		events := payload.GetEvents()
-		isAuthz := utils.GetEventsValue(events, "message", "action") == "/cosmos.authz.v1beta1.MsgExec"
-		if isAuthz {
+		if utils.GetEventsValue(events, "message", "action") == msgExecTypeUrl {
 			authzCtx, err := EventContextFromAuthz(ctx, payload.MsgIndex)
 			if err == nil {
-				err = e.Extract(authzCtx)
-				if err != nil {
-					return err
+				var authzFilter *processorFilter
+				if filter != nil {
+					// messages inside MsgExec are identified by the index of the MsgExec, so all of them are retried
+					authzFilter = &processorFilter{
+						msgIndex:  -1,
+						eventType: filter.eventType,
+						processor: filter.processor,
+						groups:    filter.groups,
+					}
 				}
+				authzErrs := appendExtractErrors(nil, e.extract(authzCtx, authzFilter))
+				if authzFilter != nil {
+					filter.matched += authzFilter.matched
+				}
+				for _, authzErr := range authzErrs {
+					authzErr.MsgIndex = payload.MsgIndex
+				}
+				errs = append(errs, authzErrs...)
 				continue
 			}
 			// TODO: ???
@@ -242,16 +503,13 @@ func (e *EventExtractor) Extract(ctx db.EventContext) error {
 		for _, event := range events {
 			type extractFuncType = func(*Payload, *types.StringEvent) error
 			for _, extractFunc := range []extractFuncType{e.extractTypeKeyValue, e.extractTypeKey, e.extractType} {
-				err := extractFunc(payload, &event)
-				if err != nil {
-					return err
-				}
+				errs = appendExtractErrors(errs, extractFunc(payload, &event))
 			}
 		}
-		err := e.extractAll(payload)
-		if err != nil {
-			return err
-		}
+		errs = appendExtractErrors(errs, e.extractAll(payload))
+	}
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
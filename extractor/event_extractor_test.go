@@ -1,6 +1,7 @@
 package extractor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -30,6 +31,11 @@ func TestExtractAuthzEventList(t *testing.T) {
 			Input:    `[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr0"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"0"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"0"},{"key":"receiver","value":"addr2"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"1"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"1"},{"key":"receiver","value":"addr3"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"2"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"2"},{"key":"receiver","value":"addr4"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"3"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"3"},{"key":"receiver","value":"addr5"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"4"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"4"},{"key":"receiver","value":"addr6"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"5"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"5"},{"key":"receiver","value":"addr7"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"6"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"6"},{"key":"receiver","value":"addr8"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"7"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"7"},{"key":"receiver","value":"addr9"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"8"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"8"},{"key":"receiver","value":"addr10"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"9"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"9"},{"key":"receiver","value":"addr11"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"10"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"10"},{"key":"receiver","value":"addr12"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"11"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"11"},{"key":"receiver","value":"addr13"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"12"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"12"},{"key":"receiver","value":"addr14"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"13"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"13"},{"key":"receiver","value":"addr15"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"14"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"14"},{"key":"receiver","value":"addr16"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"15"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"15"},{"key":"receiver","value":"addr17"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"16"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"16"},{"key":"receiver","value":"addr18"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"17"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"17"},{"key":"receiver","value":"addr19"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"18"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"18"},{"key":"receiver","value":"addr20"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"19"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"19"},{"key":"receiver","value":"addr21"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"20"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"20"},{"key":"receiver","value":"addr22"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"21"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"21"},{"key":"receiver","value":"addr23"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"22"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"22"},{"key":"receiver","value":"addr24"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"23"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"23"},{"key":"receiver","value":"addr25"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"24"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"24"},{"key":"receiver","value":"addr26"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"25"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"25"},{"key":"receiver","value":"addr27"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"26"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"26"},{"key":"receiver","value":"addr28"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"27"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"27"},{"key":"receiver","value":"addr29"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"28"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"28"},{"key":"receiver","value":"addr30"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"29"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"29"},{"key":"receiver","value":"addr31"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"30"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"30"},{"key":"receiver","value":"addr32"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"31"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"31"},{"key":"receiver","value":"addr33"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"32"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"32"},{"key":"receiver","value":"addr34"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"33"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"33"},{"key":"receiver","value":"addr35"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"34"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"34"},{"key":"receiver","value":"addr36"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"35"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"35"},{"key":"receiver","value":"addr37"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"36"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"36"},{"key":"receiver","value":"addr38"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"37"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"37"},{"key":"receiver","value":"addr39"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"38"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"38"},{"key":"receiver","value":"addr40"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"39"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"39"},{"key":"receiver","value":"addr41"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"40"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"40"},{"key":"receiver","value":"addr42"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"41"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"41"},{"key":"receiver","value":"addr43"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"42"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"42"},{"key":"receiver","value":"addr44"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"43"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"43"},{"key":"receiver","value":"addr45"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"44"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"44"},{"key":"receiver","value":"addr46"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"45"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"45"},{"key":"receiver","value":"addr47"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"46"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"46"},{"key":"receiver","value":"addr48"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"47"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"47"},{"key":"receiver","value":"addr49"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"48"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"48"},{"key":"receiver","value":"addr50"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"49"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"49"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"0"},{"key":"spender","value":"addr0"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"0"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"1"},{"key":"spender","value":"addr2"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"1"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"2"},{"key":"spender","value":"addr3"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"2"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"3"},{"key":"spender","value":"addr4"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"3"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"4"},{"key":"spender","value":"addr5"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"4"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"5"},{"key":"spender","value":"addr6"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"5"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"6"},{"key":"spender","value":"addr7"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"6"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"7"},{"key":"spender","value":"addr8"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"7"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"8"},{"key":"spender","value":"addr9"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"8"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"9"},{"key":"spender","value":"addr10"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"9"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"10"},{"key":"spender","value":"addr11"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"10"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"11"},{"key":"spender","value":"addr12"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"11"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"12"},{"key":"spender","value":"addr13"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"12"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"13"},{"key":"spender","value":"addr14"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"13"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"14"},{"key":"spender","value":"addr15"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"14"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"15"},{"key":"spender","value":"addr16"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"15"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"16"},{"key":"spender","value":"addr17"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"16"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"17"},{"key":"spender","value":"addr18"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"17"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"18"},{"key":"spender","value":"addr19"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"18"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"19"},{"key":"spender","value":"addr20"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"19"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"20"},{"key":"spender","value":"addr21"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"20"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"21"},{"key":"spender","value":"addr22"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"21"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"22"},{"key":"spender","value":"addr23"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"22"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"23"},{"key":"spender","value":"addr24"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"23"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"24"},{"key":"spender","value":"addr25"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"24"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"25"},{"key":"spender","value":"addr26"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"25"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"26"},{"key":"spender","value":"addr27"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"26"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"27"},{"key":"spender","value":"addr28"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"27"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"28"},{"key":"spender","value":"addr29"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"28"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"29"},{"key":"spender","value":"addr30"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"29"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"30"},{"key":"spender","value":"addr31"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"30"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"31"},{"key":"spender","value":"addr32"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"31"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"32"},{"key":"spender","value":"addr33"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"32"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"33"},{"key":"spender","value":"addr34"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"33"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"34"},{"key":"spender","value":"addr35"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"34"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"35"},{"key":"spender","value":"addr36"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"35"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"36"},{"key":"spender","value":"addr37"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"36"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"37"},{"key":"spender","value":"addr38"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"37"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"38"},{"key":"spender","value":"addr39"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"38"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"39"},{"key":"spender","value":"addr40"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"39"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"40"},{"key":"spender","value":"addr41"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"40"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"41"},{"key":"spender","value":"addr42"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"41"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"42"},{"key":"spender","value":"addr43"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"42"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"43"},{"key":"spender","value":"addr44"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"43"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"44"},{"key":"spender","value":"addr45"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"44"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"45"},{"key":"spender","value":"addr46"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"45"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"46"},{"key":"spender","value":"addr47"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"46"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"47"},{"key":"spender","value":"addr48"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"47"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"48"},{"key":"spender","value":"addr49"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"48"},{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"49"},{"key":"spender","value":"addr50"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"49"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"0"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"1"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"2"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"3"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"4"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"5"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"6"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"7"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"8"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"9"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"10"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"11"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"12"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"13"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"14"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"15"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"16"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"17"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"18"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"19"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"20"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"21"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"22"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"23"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"24"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"25"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"26"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"27"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"28"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"29"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"30"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"31"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"32"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"33"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"34"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"35"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"36"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"37"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"38"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"39"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"40"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"41"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"42"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"43"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"44"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"45"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"46"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"47"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"48"},{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"},{"key":"authz_msg_index","value":"49"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"0"},{"key":"module","value":"staking"},{"key":"sender","value":"addr0"},{"key":"authz_msg_index","value":"0"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"1"},{"key":"module","value":"staking"},{"key":"sender","value":"addr2"},{"key":"authz_msg_index","value":"1"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"2"},{"key":"module","value":"staking"},{"key":"sender","value":"addr3"},{"key":"authz_msg_index","value":"2"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"3"},{"key":"module","value":"staking"},{"key":"sender","value":"addr4"},{"key":"authz_msg_index","value":"3"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"4"},{"key":"module","value":"staking"},{"key":"sender","value":"addr5"},{"key":"authz_msg_index","value":"4"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"5"},{"key":"module","value":"staking"},{"key":"sender","value":"addr6"},{"key":"authz_msg_index","value":"5"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"6"},{"key":"module","value":"staking"},{"key":"sender","value":"addr7"},{"key":"authz_msg_index","value":"6"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"7"},{"key":"module","value":"staking"},{"key":"sender","value":"addr8"},{"key":"authz_msg_index","value":"7"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"8"},{"key":"module","value":"staking"},{"key":"sender","value":"addr9"},{"key":"authz_msg_index","value":"8"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"9"},{"key":"module","value":"staking"},{"key":"sender","value":"addr10"},{"key":"authz_msg_index","value":"9"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"10"},{"key":"module","value":"staking"},{"key":"sender","value":"addr11"},{"key":"authz_msg_index","value":"10"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"11"},{"key":"module","value":"staking"},{"key":"sender","value":"addr12"},{"key":"authz_msg_index","value":"11"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"12"},{"key":"module","value":"staking"},{"key":"sender","value":"addr13"},{"key":"authz_msg_index","value":"12"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"13"},{"key":"module","value":"staking"},{"key":"sender","value":"addr14"},{"key":"authz_msg_index","value":"13"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"14"},{"key":"module","value":"staking"},{"key":"sender","value":"addr15"},{"key":"authz_msg_index","value":"14"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"15"},{"key":"module","value":"staking"},{"key":"sender","value":"addr16"},{"key":"authz_msg_index","value":"15"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"16"},{"key":"module","value":"staking"},{"key":"sender","value":"addr17"},{"key":"authz_msg_index","value":"16"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"17"},{"key":"module","value":"staking"},{"key":"sender","value":"addr18"},{"key":"authz_msg_index","value":"17"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"18"},{"key":"module","value":"staking"},{"key":"sender","value":"addr19"},{"key":"authz_msg_index","value":"18"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"19"},{"key":"module","value":"staking"},{"key":"sender","value":"addr20"},{"key":"authz_msg_index","value":"19"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"20"},{"key":"module","value":"staking"},{"key":"sender","value":"addr21"},{"key":"authz_msg_index","value":"20"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"21"},{"key":"module","value":"staking"},{"key":"sender","value":"addr22"},{"key":"authz_msg_index","value":"21"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"22"},{"key":"module","value":"staking"},{"key":"sender","value":"addr23"},{"key":"authz_msg_index","value":"22"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"23"},{"key":"module","value":"staking"},{"key":"sender","value":"addr24"},{"key":"authz_msg_index","value":"23"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"24"},{"key":"module","value":"staking"},{"key":"sender","value":"addr25"},{"key":"authz_msg_index","value":"24"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"25"},{"key":"module","value":"staking"},{"key":"sender","value":"addr26"},{"key":"authz_msg_index","value":"25"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"26"},{"key":"module","value":"staking"},{"key":"sender","value":"addr27"},{"key":"authz_msg_index","value":"26"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"27"},{"key":"module","value":"staking"},{"key":"sender","value":"addr28"},{"key":"authz_msg_index","value":"27"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"28"},{"key":"module","value":"staking"},{"key":"sender","value":"addr29"},{"key":"authz_msg_index","value":"28"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"29"},{"key":"module","value":"staking"},{"key":"sender","value":"addr30"},{"key":"authz_msg_index","value":"29"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"30"},{"key":"module","value":"staking"},{"key":"sender","value":"addr31"},{"key":"authz_msg_index","value":"30"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"31"},{"key":"module","value":"staking"},{"key":"sender","value":"addr32"},{"key":"authz_msg_index","value":"31"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"32"},{"key":"module","value":"staking"},{"key":"sender","value":"addr33"},{"key":"authz_msg_index","value":"32"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"33"},{"key":"module","value":"staking"},{"key":"sender","value":"addr34"},{"key":"authz_msg_index","value":"33"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"34"},{"key":"module","value":"staking"},{"key":"sender","value":"addr35"},{"key":"authz_msg_index","value":"34"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"35"},{"key":"module","value":"staking"},{"key":"sender","value":"addr36"},{"key":"authz_msg_index","value":"35"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"36"},{"key":"module","value":"staking"},{"key":"sender","value":"addr37"},{"key":"authz_msg_index","value":"36"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"37"},{"key":"module","value":"staking"},{"key":"sender","value":"addr38"},{"key":"authz_msg_index","value":"37"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"38"},{"key":"module","value":"staking"},{"key":"sender","value":"addr39"},{"key":"authz_msg_index","value":"38"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"39"},{"key":"module","value":"staking"},{"key":"sender","value":"addr40"},{"key":"authz_msg_index","value":"39"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"40"},{"key":"module","value":"staking"},{"key":"sender","value":"addr41"},{"key":"authz_msg_index","value":"40"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"41"},{"key":"module","value":"staking"},{"key":"sender","value":"addr42"},{"key":"authz_msg_index","value":"41"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"42"},{"key":"module","value":"staking"},{"key":"sender","value":"addr43"},{"key":"authz_msg_index","value":"42"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"43"},{"key":"module","value":"staking"},{"key":"sender","value":"addr44"},{"key":"authz_msg_index","value":"43"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"44"},{"key":"module","value":"staking"},{"key":"sender","value":"addr45"},{"key":"authz_msg_index","value":"44"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"45"},{"key":"module","value":"staking"},{"key":"sender","value":"addr46"},{"key":"authz_msg_index","value":"45"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"46"},{"key":"module","value":"staking"},{"key":"sender","value":"addr47"},{"key":"authz_msg_index","value":"46"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"47"},{"key":"module","value":"staking"},{"key":"sender","value":"addr48"},{"key":"authz_msg_index","value":"47"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"48"},{"key":"module","value":"staking"},{"key":"sender","value":"addr49"},{"key":"authz_msg_index","value":"48"},{"key":"sender","value":"addr51"},{"key":"authz_msg_index","value":"49"},{"key":"module","value":"staking"},{"key":"sender","value":"addr50"},{"key":"authz_msg_index","value":"49"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr0"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"0"},{"key":"recipient","value":"addr2"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"1"},{"key":"recipient","value":"addr3"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"2"},{"key":"recipient","value":"addr4"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"3"},{"key":"recipient","value":"addr5"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"4"},{"key":"recipient","value":"addr6"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"5"},{"key":"recipient","value":"addr7"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"6"},{"key":"recipient","value":"addr8"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"7"},{"key":"recipient","value":"addr9"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"8"},{"key":"recipient","value":"addr10"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"9"},{"key":"recipient","value":"addr11"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"10"},{"key":"recipient","value":"addr12"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"11"},{"key":"recipient","value":"addr13"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"12"},{"key":"recipient","value":"addr14"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"13"},{"key":"recipient","value":"addr15"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"14"},{"key":"recipient","value":"addr16"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"15"},{"key":"recipient","value":"addr17"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"16"},{"key":"recipient","value":"addr18"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"17"},{"key":"recipient","value":"addr19"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"18"},{"key":"recipient","value":"addr20"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"19"},{"key":"recipient","value":"addr21"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"20"},{"key":"recipient","value":"addr22"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"21"},{"key":"recipient","value":"addr23"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"22"},{"key":"recipient","value":"addr24"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"23"},{"key":"recipient","value":"addr25"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"24"},{"key":"recipient","value":"addr26"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"25"},{"key":"recipient","value":"addr27"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"26"},{"key":"recipient","value":"addr28"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"27"},{"key":"recipient","value":"addr29"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"28"},{"key":"recipient","value":"addr30"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"29"},{"key":"recipient","value":"addr31"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"30"},{"key":"recipient","value":"addr32"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"31"},{"key":"recipient","value":"addr33"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"32"},{"key":"recipient","value":"addr34"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"33"},{"key":"recipient","value":"addr35"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"34"},{"key":"recipient","value":"addr36"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"35"},{"key":"recipient","value":"addr37"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"36"},{"key":"recipient","value":"addr38"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"37"},{"key":"recipient","value":"addr39"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"38"},{"key":"recipient","value":"addr40"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"39"},{"key":"recipient","value":"addr41"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"40"},{"key":"recipient","value":"addr42"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"41"},{"key":"recipient","value":"addr43"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"42"},{"key":"recipient","value":"addr44"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"43"},{"key":"recipient","value":"addr45"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"44"},{"key":"recipient","value":"addr46"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"45"},{"key":"recipient","value":"addr47"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"46"},{"key":"recipient","value":"addr48"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"47"},{"key":"recipient","value":"addr49"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"48"},{"key":"recipient","value":"addr50"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"49"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"0"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"1"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"2"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"3"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"4"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"5"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"6"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"7"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"8"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"9"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"10"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"11"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"12"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"13"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"14"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"15"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"16"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"17"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"18"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"19"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"20"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"21"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"22"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"23"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"24"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"25"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"26"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"27"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"28"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"29"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"30"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"31"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"32"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"33"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"34"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"35"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"36"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"37"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"38"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"39"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"40"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"41"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"42"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"43"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"44"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"45"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"46"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"47"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"48"},{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"},{"key":"authz_msg_index","value":"49"}]}]`,
 			Expected: `[[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr0"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr0"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr0"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr0"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr2"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr2"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr2"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr2"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr3"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr3"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr3"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr3"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr4"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr4"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr4"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr4"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr5"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr5"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr5"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr5"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr6"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr6"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr6"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr6"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr7"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr7"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr7"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr7"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr8"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr8"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr8"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr8"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr9"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr9"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr9"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr9"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr10"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr10"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr10"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr10"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr11"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr11"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr11"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr11"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr12"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr12"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr12"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr12"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr13"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr13"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr13"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr13"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr14"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr14"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr14"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr14"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr15"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr15"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr15"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr15"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr16"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr16"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr16"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr16"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr17"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr17"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr17"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr17"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr18"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr18"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr18"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr18"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr19"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr19"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr19"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr19"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr20"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr20"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr20"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr20"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr21"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr21"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr21"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr21"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr22"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr22"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr22"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr22"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr23"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr23"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr23"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr23"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr24"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr24"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr24"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr24"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr25"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr25"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr25"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr25"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr26"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr26"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr26"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr26"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr27"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr27"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr27"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr27"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr28"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr28"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr28"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr28"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr29"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr29"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr29"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr29"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr30"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr30"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr30"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr30"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr31"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr31"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr31"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr31"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr32"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr32"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr32"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr32"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr33"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr33"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr33"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr33"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr34"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr34"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr34"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr34"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr35"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr35"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr35"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr35"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr36"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr36"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr36"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr36"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr37"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr37"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr37"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr37"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr38"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr38"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr38"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr38"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr39"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr39"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr39"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr39"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr40"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr40"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr40"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr40"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr41"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr41"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr41"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr41"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr42"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr42"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr42"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr42"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr43"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr43"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr43"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr43"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr44"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr44"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr44"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr44"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr45"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr45"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr45"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr45"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr46"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr46"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr46"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr46"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr47"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr47"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr47"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr47"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr48"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr48"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr48"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr48"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr49"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr49"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr49"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr49"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}],[{"type":"coin_received","attributes":[{"key":"receiver","value":"addr50"},{"key":"amount","value":"1nanolike"},{"key":"receiver","value":"addr1"},{"key":"amount","value":"1nanolike"}]},{"type":"coin_spent","attributes":[{"key":"spender","value":"addr51"},{"key":"amount","value":"1nanolike"},{"key":"spender","value":"addr50"},{"key":"amount","value":"1nanolike"}]},{"type":"delegate","attributes":[{"key":"validator","value":"addr52"},{"key":"amount","value":"1nanolike"},{"key":"new_shares","value":"0.000000000000000000"}]},{"type":"message","attributes":[{"key":"sender","value":"addr51"},{"key":"module","value":"staking"},{"key":"sender","value":"addr50"}]},{"type":"transfer","attributes":[{"key":"recipient","value":"addr50"},{"key":"sender","value":"addr51"},{"key":"amount","value":"1nanolike"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"addr52"}]}]]`,
 		},
+		{
+			Name:     "nested authz events",
+			Input:    `[{"type":"transfer","attributes":[{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"0"},{"key":"amount","value":"2nanolike"},{"key":"authz_msg_index","value":"0"},{"key":"authz_msg_index","value":"1"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"},{"key":"sender","value":"addr0"},{"key":"authz_msg_index","value":"0"},{"key":"sender","value":"addr1"},{"key":"authz_msg_index","value":"0"},{"key":"authz_msg_index","value":"1"}]}]`,
+			Expected: `[[{"type":"transfer","attributes":[{"key":"amount","value":"1nanolike"}]},{"type":"message","attributes":[{"key":"sender","value":"addr0"}]}],[{"type":"transfer","attributes":[{"key":"amount","value":"2nanolike"},{"key":"authz_msg_index","value":"0"}]},{"type":"message","attributes":[{"key":"sender","value":"addr1"},{"key":"authz_msg_index","value":"0"}]}]]`,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -91,7 +97,7 @@ func TestExtractAuthz(t *testing.T) {
 		Txs: txs,
 	})
 
-	finished, err := Extract(Conn, ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
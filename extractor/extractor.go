@@ -9,44 +9,135 @@ import (
 	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
 )
 
-var ExtractFunc db.Extractor
+// Extractors runs the registered processors, with the extraction progress tracked for each group
+var Extractors db.GroupedExtractor = eventExtractor
 
-// TODO: should we make extractor synchronous with poller instead of async?
-func Run(pool *pgxpool.Pool) chan<- int64 {
-	trigger := make(chan int64, 100)
+// names of the extractor groups, which are also the domains of the extracted data for re-extraction
+const (
+	GroupIscn        = "iscn"
+	GroupNft         = "nft"
+	GroupMarketplace = "marketplace"
+	GroupIncome      = "income"
+	GroupBank        = "bank"
+	GroupStaking     = "staking"
+	GroupGov         = "gov"
+	GroupIbc         = "ibc"
+	GroupAuthz       = "authz"
+	GroupFeegrant    = "feegrant"
+	GroupIscnEvent   = "iscn_event"
+)
+
+// bump the version of a group when it should extract all transactions again
+var (
+	iscnGroup        = eventExtractor.Group(GroupIscn, 1)
+	nftGroup         = eventExtractor.Group(GroupNft, 1)
+	marketplaceGroup = eventExtractor.Group(GroupMarketplace, 1)
+	incomeGroup      = eventExtractor.Group(GroupIncome, 1)
+	bankGroup        = eventExtractor.Group(GroupBank, 1)
+	stakingGroup     = eventExtractor.Group(GroupStaking, 1)
+	govGroup         = eventExtractor.Group(GroupGov, 1)
+	ibcGroup         = eventExtractor.Group(GroupIbc, 1)
+	authzGroup       = eventExtractor.Group(GroupAuthz, 1)
+	feegrantGroup    = eventExtractor.Group(GroupFeegrant, 1)
+	iscnEventGroup   = eventExtractor.Group(GroupIscnEvent, 1)
+)
+
+// Run starts extracting in background until ctx is done.
+// The returned trigger wakes the extractor up when new heights are polled,
+// and done is closed after the extractor stops, so the caller can wait for the running batch before exiting.
+// See poller.Run for extracting synchronously with the poller instead.
+func Run(ctx context.Context, pool *pgxpool.Pool) (trigger chan<- int64, done <-chan struct{}) {
+	triggerChan := make(chan int64, 100)
+	doneChan := make(chan struct{})
 	go func() {
+		defer close(doneChan)
 		conn, err := db.AcquireFromPool(pool)
 		if err != nil {
 			logger.L.Errorw("Failed to acquire connection for extractor", "error", err)
 			return
 		}
+		defer func() {
+			if conn != nil {
+				conn.Release()
+			}
+		}()
 
 		logger.L.Info("Extractor started")
 		var finished bool
 		for {
-			if err = conn.Ping(context.Background()); err != nil {
+			if ctx.Err() != nil {
+				logger.L.Info("Extractor stopped")
+				return
+			}
+			if conn == nil || conn.Ping(ctx) != nil {
+				if conn != nil {
+					conn.Release()
+				}
 				conn, err = db.AcquireFromPool(pool)
 				if err != nil {
+					conn = nil
 					logger.L.Errorw("Failed to acquire connection for extractor", "error", err)
-					time.Sleep(10 * time.Second)
+					sleep(ctx, 10*time.Second)
 					continue
 				}
 			}
-			finished, err = db.Extract(conn, ExtractFunc)
+			finished, err = db.Extract(ctx, conn, Extractors)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.L.Errorw("Extract error", "error", err)
+				}
+				sleep(ctx, 5*time.Second)
+				continue
+			}
+			if finished {
+				select {
+				case height := <-triggerChan:
+					logger.L.Debugf("Extractor: trigger by poller on height %d", height)
+				case <-ctx.Done():
+				}
+			}
+		}
+	}()
+	return triggerChan, doneChan
+}
+
+// RunLagging extracts in background for the groups behind the others, e.g. new groups or groups with version bumped,
+// until they catch up and are extracted together with the others by Run or the poller, or ctx is done.
+// done is closed after it stops.
+func RunLagging(ctx context.Context, pool *pgxpool.Pool) (done <-chan struct{}) {
+	doneChan := make(chan struct{})
+	go func() {
+		defer close(doneChan)
+		for ctx.Err() == nil {
+			finished, err := extractLagging(ctx, pool)
 			if err != nil {
-				logger.L.Errorw("Extract error", "error", err)
-				time.Sleep(5 * time.Second)
+				if ctx.Err() == nil {
+					logger.L.Errorw("Extract lagging groups error", "error", err)
+				}
+				sleep(ctx, 5*time.Second)
 				continue
 			}
 			if finished {
-				height := <-trigger
-				logger.L.Debugf("Extractor: trigger by poller on height %d", height)
+				logger.L.Info("No lagging extractor group")
+				return
 			}
 		}
 	}()
-	return trigger
+	return doneChan
+}
+
+func extractLagging(ctx context.Context, pool *pgxpool.Pool) (bool, error) {
+	conn, err := db.AcquireFromPool(pool)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+	return db.ExtractLagging(ctx, conn, Extractors)
 }
 
-func init() {
-	ExtractFunc = eventExtractor.Extract
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
 }
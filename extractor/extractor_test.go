@@ -1,6 +1,7 @@
 package extractor_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -52,7 +53,7 @@ func TestExtractMultipleEvents(t *testing.T) {
 		Txs: txs,
 	})
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -68,3 +69,27 @@ func TestExtractMultipleEvents(t *testing.T) {
 func TestMain(m *testing.M) {
 	SetupDbAndRunTest(m, nil)
 }
+
+func TestRunStopsOnCancel(t *testing.T) {
+	defer CleanupTestData(Conn)
+	InsertTestData(DBTestData{
+		Txs: []string{
+			`{"height":"1234","txhash":"AAAAAA","tx":{"body":{"messages":[],"memo":""}},"logs":[],"timestamp":"2022-01-01T00:00:00Z"}`,
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, done := extractor.Run(ctx, Pool)
+	require.Eventually(t, func() bool {
+		height, err := GetMetaHeight(Conn, META_EXTRACTOR)
+		return err == nil && height == 1234
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "extractor does not stop after context is canceled")
+	}
+}
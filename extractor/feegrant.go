@@ -0,0 +1,112 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+type feegrantMessage struct {
+	Allowance json.RawMessage `json:"allowance"`
+}
+
+type feeAllowance struct {
+	Type string `json:"@type"`
+	// BasicAllowance
+	SpendLimit types.Coins `json:"spend_limit"`
+	Expiration *time.Time  `json:"expiration"`
+	// PeriodicAllowance
+	Basic *feeAllowance `json:"basic"`
+	// AllowedMsgAllowance
+	Allowance *feeAllowance `json:"allowance"`
+}
+
+// basicAllowance returns the BasicAllowance limiting the total spending and expiration of the allowance
+func (a feeAllowance) basicAllowance() feeAllowance {
+	if a.Basic != nil {
+		return *a.Basic
+	}
+	if a.Allowance != nil {
+		return a.Allowance.basicAllowance()
+	}
+	return a
+}
+
+func feegrantKeyFromEvent(event *types.StringEvent) db.FeegrantKey {
+	return db.FeegrantKey{
+		Granter: utils.GetEventValue(event, "granter"),
+		Grantee: utils.GetEventValue(event, "grantee"),
+	}
+}
+
+func grantFeeAllowance(payload *Payload, event *types.StringEvent) error {
+	var message feegrantMessage
+	if err := json.Unmarshal(payload.GetMessage(), &message); err != nil {
+		return fmt.Errorf("failed to unmarshal MsgGrantAllowance: %w", err)
+	}
+	var allowance feeAllowance
+	if err := json.Unmarshal(message.Allowance, &allowance); err != nil {
+		return fmt.Errorf("failed to unmarshal allowance: %w", err)
+	}
+	basic := allowance.basicAllowance()
+	grant := db.Feegrant{
+		FeegrantKey:   feegrantKeyFromEvent(event),
+		AllowanceType: allowance.Type,
+		Allowance:     message.Allowance,
+		Expiration:    basic.Expiration,
+		Height:        payload.Height,
+		TxHash:        payload.TxHash,
+		Timestamp:     payload.Timestamp,
+	}
+	// empty spend limit means unlimited
+	if len(basic.SpendLimit) > 0 {
+		grant.SpendLimit = basic.SpendLimit
+	}
+	payload.Batch.InsertFeegrant(grant)
+	return nil
+}
+
+func revokeFeeAllowance(payload *Payload, event *types.StringEvent) error {
+	payload.Batch.RevokeFeegrant(feegrantKeyFromEvent(event), payload.Height, payload.TxHash, payload.Timestamp)
+	return nil
+}
+
+// useFeeAllowance records the fee of the transaction paid by the allowance.
+// The use_feegrant event is emitted before the messages are executed, so it is not in the logs of the messages,
+// and the allowance is found from the fee granter and fee payer of the transaction instead.
+// Failed transactions are also charged the fee, so it runs on every transaction instead of on the messages.
+func useFeeAllowance(payload *Payload, _ *types.StringEvent) error {
+	if payload.Fee.Granter == "" {
+		return nil
+	}
+	grantee := payload.Fee.Payer
+	if grantee == "" && len(payload.Messages) > 0 {
+		// the fee payer defaults to the first signer
+		grantee = messageSigner(payload.Messages[0])
+	}
+	if grantee == "" {
+		return fmt.Errorf("failed to find the fee payer of the transaction with fee granter %s", payload.Fee.Granter)
+	}
+	payload.Batch.InsertFeegrantUsage(db.FeegrantUsage{
+		FeegrantKey: db.FeegrantKey{
+			Granter: payload.Fee.Granter,
+			Grantee: grantee,
+		},
+		Fee:       payload.Fee.Amount,
+		Height:    payload.Height,
+		TxHash:    payload.TxHash,
+		Timestamp: payload.Timestamp,
+	})
+	return nil
+}
+
+func init() {
+	feegrantGroup.RegisterType("set_feegrant", grantFeeAllowance)
+	feegrantGroup.RegisterType("revoke_feegrant", revokeFeeAllowance)
+	feegrantGroup.RegisterTx(useFeeAllowance)
+}
@@ -0,0 +1,116 @@
+package extractor_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+// feegrantTx builds a transaction with a single message, and the fee paid by the allowance of feeGranter if it is not empty
+func feegrantTx(height int, timestamp time.Time, msg string, feeGranter string, events ...string) string {
+	return fmt.Sprintf(
+		`{"height":"%[1]d","txhash":"FEEGRANT%[1]d","tx":{"body":{"messages":[%[2]s],"memo":""},"auth_info":{"fee":{"amount":[{"denom":"nanolike","amount":"%[1]d0"}],"gas_limit":"200000","payer":"","granter":"%[3]s"}}},"logs":[{"msg_index":0,"log":"","events":[%[4]s]}],"timestamp":"%[5]s"}`,
+		height, msg, feeGranter, strings.Join(events, ","), timestamp.Format(time.RFC3339),
+	)
+}
+
+func TestFeegrant(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	grantMsg := `{"@type":"/cosmos.feegrant.v1beta1.MsgGrantAllowance","granter":"%s","grantee":"%s","allowance":%s}`
+	grantEvent := `{"type":"set_feegrant","attributes":[{"key":"granter","value":"%s"},{"key":"grantee","value":"%s"}]}`
+	basicAllowance := `{"@type":"/cosmos.feegrant.v1beta1.BasicAllowance","spend_limit":[{"denom":"nanolike","amount":"1000"}],"expiration":"2100-01-01T00:00:00Z"}`
+	periodicAllowance := `{"@type":"/cosmos.feegrant.v1beta1.AllowedMsgAllowance","allowance":{"@type":"/cosmos.feegrant.v1beta1.PeriodicAllowance","basic":{"spend_limit":[{"denom":"nanolike","amount":"500"}],"expiration":null},"period":"86400s","period_spend_limit":[{"denom":"nanolike","amount":"100"}],"period_can_spend":[],"period_reset":"2023-03-29T00:00:00Z"},"allowed_messages":["/cosmos.bank.v1beta1.MsgSend"]}`
+	sendMsg := `{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"%s","to_address":"%s","amount":[{"denom":"nanolike","amount":"1"}]}`
+	txs := []string{
+		feegrantTx(1, timestamp,
+			fmt.Sprintf(grantMsg, ADDR_01_LIKE, ADDR_02_LIKE, basicAllowance), "",
+			fmt.Sprintf(grantEvent, ADDR_01_LIKE, ADDR_02_LIKE),
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.feegrant.v1beta1.MsgGrantAllowance"}]}`,
+		),
+		feegrantTx(2, timestamp,
+			fmt.Sprintf(grantMsg, ADDR_01_LIKE, ADDR_03_LIKE, periodicAllowance), "",
+			fmt.Sprintf(grantEvent, ADDR_01_LIKE, ADDR_03_LIKE),
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.feegrant.v1beta1.MsgGrantAllowance"}]}`,
+		),
+		feegrantTx(3, timestamp.Add(time.Hour),
+			fmt.Sprintf(sendMsg, ADDR_02_LIKE, ADDR_04_LIKE), ADDR_01_LIKE,
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.bank.v1beta1.MsgSend"}]}`,
+		),
+		// the fee payer is the grantee of MsgExec
+		feegrantTx(4, timestamp.Add(2*time.Hour),
+			fmt.Sprintf(`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[%s]}`, ADDR_02_LIKE, fmt.Sprintf(sendMsg, ADDR_04_LIKE, ADDR_02_LIKE)), ADDR_01_LIKE,
+			`{"type":"transfer","attributes":[{"key":"recipient","value":"`+ADDR_02_LIKE+`"},{"key":"sender","value":"`+ADDR_04_LIKE+`"},{"key":"amount","value":"1nanolike"},{"key":"authz_msg_index","value":"0"}]}`,
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"}]}`,
+		),
+		feegrantTx(5, timestamp.Add(3*time.Hour),
+			fmt.Sprintf(`{"@type":"/cosmos.feegrant.v1beta1.MsgRevokeAllowance","granter":"%s","grantee":"%s"}`, ADDR_01_LIKE, ADDR_03_LIKE), "",
+			fmt.Sprintf(`{"type":"revoke_feegrant","attributes":[{"key":"granter","value":"%s"},{"key":"grantee","value":"%s"}]}`, ADDR_01_LIKE, ADDR_03_LIKE),
+			`{"type":"message","attributes":[{"key":"action","value":"/cosmos.feegrant.v1beta1.MsgRevokeAllowance"}]}`,
+		),
+		// failed transactions have no logs, but the fee is still paid by the allowance
+		fmt.Sprintf(
+			`{"height":"6","txhash":"FEEGRANT6","code":5,"tx":{"body":{"messages":[%[1]s],"memo":""},"auth_info":{"fee":{"amount":[{"denom":"nanolike","amount":"60"}],"gas_limit":"200000","payer":"","granter":"%[2]s"}}},"logs":[],"timestamp":"%[3]s"}`,
+			fmt.Sprintf(sendMsg, ADDR_02_LIKE, ADDR_04_LIKE), ADDR_01_LIKE, timestamp.Add(4*time.Hour).Format(time.RFC3339),
+		),
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err := GetFeegrants(Conn, QueryFeegrantsRequest{Granter: ADDR_01_COSMOS}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Feegrants, 1)
+	grant := res.Feegrants[0]
+	require.Equal(t, FeegrantKey{Granter: ADDR_01_LIKE, Grantee: ADDR_02_LIKE}, grant.FeegrantKey)
+	require.Equal(t, "/cosmos.feegrant.v1beta1.BasicAllowance", grant.AllowanceType)
+	require.JSONEq(t, basicAllowance, string(grant.Allowance))
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("nanolike", 1000)), grant.SpendLimit)
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("nanolike", 130)), grant.FeesSpent)
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("nanolike", 870)), grant.SpendLimitLeft)
+	require.NotNil(t, grant.Expiration)
+	require.Equal(t, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), grant.Expiration.UTC())
+	require.Equal(t, int64(1), grant.Height)
+	require.Equal(t, "FEEGRANT1", grant.TxHash)
+	require.NotNil(t, grant.LastUsedHeight)
+	require.Equal(t, int64(6), *grant.LastUsedHeight)
+	require.Equal(t, "FEEGRANT6", grant.LastUsedTxHash)
+
+	// the periodic allowance is revoked
+	res, err = GetFeegrants(Conn, QueryFeegrantsRequest{Grantee: ADDR_03_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, res.Feegrants)
+
+	var allowanceType, spendLimit string
+	var expiration *time.Time
+	err = Conn.QueryRow(context.Background(),
+		`SELECT allowance_type, spend_limit::text, expiration FROM feegrant WHERE grantee = $1`, ADDR_03_LIKE,
+	).Scan(&allowanceType, &spendLimit, &expiration)
+	require.NoError(t, err)
+	require.Equal(t, "/cosmos.feegrant.v1beta1.AllowedMsgAllowance", allowanceType)
+	require.JSONEq(t, `[{"denom":"nanolike","amount":"500"}]`, spendLimit)
+	require.Nil(t, expiration)
+
+	// extracting the same transactions again does not count the fees twice
+	_, err = Conn.Exec(context.Background(), `UPDATE meta SET height = 0 WHERE id LIKE 'extractor\_%'`)
+	require.NoError(t, err)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err = GetFeegrants(Conn, QueryFeegrantsRequest{Grantee: ADDR_02_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Feegrants, 1)
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("nanolike", 130)), res.Feegrants[0].FeesSpent)
+}
@@ -0,0 +1,188 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+const msgExecLegacyContentTypeUrl = "/cosmos.gov.v1.MsgExecLegacyContent"
+
+// govMessage covers the fields used from the gov messages of both v1beta1 and v1
+type govMessage struct {
+	// MsgSubmitProposal of v1beta1
+	Content json.RawMessage `json:"content"`
+	// MsgSubmitProposal of v1
+	Messages []json.RawMessage `json:"messages"`
+	Metadata string            `json:"metadata"`
+	Proposer string            `json:"proposer"`
+
+	// MsgDeposit
+	Depositor string `json:"depositor"`
+
+	// MsgVote and MsgVoteWeighted
+	Voter   string             `json:"voter"`
+	Option  string             `json:"option"`
+	Options []db.GovVoteOption `json:"options"`
+}
+
+type govContent struct {
+	Type        string `json:"@type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// legacy content wrapped in MsgExecLegacyContent
+	Content json.RawMessage `json:"content"`
+}
+
+func parseGovMessage(payload *Payload) (govMessage, error) {
+	var message govMessage
+	if err := json.Unmarshal(payload.GetMessage(), &message); err != nil {
+		return message, fmt.Errorf("failed to unmarshal gov message: %w", err)
+	}
+	return message, nil
+}
+
+func parseProposalId(value string) (uint64, error) {
+	proposalId, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid proposal id %s: %w", value, err)
+	}
+	return proposalId, nil
+}
+
+func submitProposal(payload *Payload, event *types.StringEvent) error {
+	message, err := parseGovMessage(payload)
+	if err != nil {
+		return err
+	}
+	proposalId, err := parseProposalId(utils.GetEventValue(event, "proposal_id"))
+	if err != nil {
+		return err
+	}
+	proposal := db.GovProposal{
+		ProposalId:    proposalId,
+		Proposer:      message.Proposer,
+		ProposalTypes: []string{},
+		Metadata:      message.Metadata,
+		Height:        payload.Height,
+		TxHash:        payload.TxHash,
+		Timestamp:     payload.Timestamp,
+	}
+	if len(message.Content) > 0 {
+		var content govContent
+		if err := json.Unmarshal(message.Content, &content); err != nil {
+			return fmt.Errorf("failed to unmarshal proposal content: %w", err)
+		}
+		proposal.ProposalTypes = append(proposal.ProposalTypes, content.Type)
+		proposal.Title = content.Title
+		proposal.Description = content.Description
+		proposal.Content = message.Content
+	} else {
+		for _, msg := range message.Messages {
+			var content govContent
+			if err := json.Unmarshal(msg, &content); err != nil {
+				return fmt.Errorf("failed to unmarshal proposal message: %w", err)
+			}
+			proposal.ProposalTypes = append(proposal.ProposalTypes, content.Type)
+			if content.Type == msgExecLegacyContentTypeUrl && proposal.Title == "" && len(content.Content) > 0 {
+				var legacyContent govContent
+				if err := json.Unmarshal(content.Content, &legacyContent); err != nil {
+					return fmt.Errorf("failed to unmarshal legacy proposal content: %w", err)
+				}
+				proposal.Title = legacyContent.Title
+				proposal.Description = legacyContent.Description
+			}
+		}
+		proposal.Content, err = json.Marshal(message.Messages)
+		if err != nil {
+			return fmt.Errorf("failed to marshal proposal messages: %w", err)
+		}
+	}
+	payload.Batch.InsertGovProposal(proposal)
+	return nil
+}
+
+// depositProposal records the deposits of MsgDeposit, and the initial deposit of MsgSubmitProposal
+func depositProposal(payload *Payload, event *types.StringEvent) error {
+	message, err := parseGovMessage(payload)
+	if err != nil {
+		return err
+	}
+	depositor := message.Depositor
+	if depositor == "" {
+		depositor = message.Proposer
+	}
+	// the attributes are pairs of amount and proposal_id, which may be followed by voting_period_start
+	amount := ""
+	for _, attr := range event.Attributes {
+		switch attr.Key {
+		case "amount":
+			amount = attr.Value
+		case "proposal_id":
+			proposalId, err := parseProposalId(attr.Value)
+			if err != nil {
+				return err
+			}
+			coins, err := types.ParseCoinsNormalized(amount)
+			if err != nil {
+				return fmt.Errorf("failed to parse deposit: %w", err)
+			}
+			for _, coin := range coins {
+				payload.Batch.InsertGovDeposit(db.GovDeposit{
+					ProposalId: proposalId,
+					Depositor:  depositor,
+					Denom:      coin.Denom,
+					Amount:     coin.Amount.String(),
+					Height:     payload.Height,
+					TxHash:     payload.TxHash,
+					MsgIndex:   payload.TxMsgIndex(),
+					Timestamp:  payload.Timestamp,
+				})
+			}
+			amount = ""
+		}
+	}
+	return nil
+}
+
+func voteProposal(payload *Payload, event *types.StringEvent) error {
+	message, err := parseGovMessage(payload)
+	if err != nil {
+		return err
+	}
+	proposalId, err := parseProposalId(utils.GetEventValue(event, "proposal_id"))
+	if err != nil {
+		return err
+	}
+	options := message.Options
+	if message.Option != "" {
+		options = []db.GovVoteOption{{Option: message.Option, Weight: types.OneDec().String()}}
+	}
+	if len(options) == 0 {
+		return fmt.Errorf("no vote option in message")
+	}
+	for i := range options {
+		options[i].Option = db.NormalizeVoteOption(options[i].Option)
+	}
+	payload.Batch.InsertGovVote(db.GovVote{
+		ProposalId: proposalId,
+		Voter:      message.Voter,
+		Options:    options,
+		Height:     payload.Height,
+		TxHash:     payload.TxHash,
+		MsgIndex:   payload.TxMsgIndex(),
+		Timestamp:  payload.Timestamp,
+	})
+	return nil
+}
+
+func init() {
+	govGroup.RegisterType("submit_proposal", submitProposal)
+	govGroup.RegisterType("proposal_deposit", depositProposal)
+	govGroup.RegisterType("proposal_vote", voteProposal)
+}
@@ -0,0 +1,117 @@
+package extractor_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestGov(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	govTx := func(height int, msg string, events string) string {
+		return fmt.Sprintf(
+			`{"height":"%[1]d","txhash":"GOV%[1]d","tx":{"body":{"messages":[%[2]s],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[%[3]s]}],"timestamp":"%[4]s"}`,
+			height, msg, events, timestamp.Format(time.RFC3339),
+		)
+	}
+	content := `{"@type":"/cosmos.gov.v1beta1.TextProposal","title":"Test proposal","description":"Testing"}`
+	legacyContent := `{"@type":"/cosmos.params.v1beta1.ParameterChangeProposal","title":"Param change","description":"Change params","changes":[]}`
+	txs := []string{
+		// v1beta1 proposal with initial deposit
+		govTx(1,
+			fmt.Sprintf(`{"@type":"/cosmos.gov.v1beta1.MsgSubmitProposal","content":%s,"initial_deposit":[{"denom":"nanolike","amount":"100"}],"proposer":"%s"}`, content, ADDR_01_COSMOS),
+			`{"type":"proposal_deposit","attributes":[{"key":"amount","value":"100nanolike"},{"key":"proposal_id","value":"1"}]},{"type":"submit_proposal","attributes":[{"key":"proposal_id","value":"1"},{"key":"proposal_messages","value":",/cosmos.gov.v1.MsgExecLegacyContent"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.gov.v1beta1.MsgSubmitProposal"}]}`,
+		),
+		// v1 proposal without initial deposit
+		govTx(2,
+			fmt.Sprintf(`{"@type":"/cosmos.gov.v1.MsgSubmitProposal","messages":[{"@type":"/cosmos.gov.v1.MsgExecLegacyContent","content":%s,"authority":"like10d07y265gmmuvt4z0w9aw880jnsr700jqr8n8k"}],"initial_deposit":[],"proposer":"%s","metadata":"ipfs://metadata"}`, legacyContent, ADDR_02_LIKE),
+			`{"type":"proposal_deposit","attributes":[{"key":"amount","value":""},{"key":"proposal_id","value":"2"}]},{"type":"submit_proposal","attributes":[{"key":"proposal_id","value":"2"},{"key":"proposal_messages","value":",/cosmos.gov.v1.MsgExecLegacyContent"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.gov.v1.MsgSubmitProposal"}]}`,
+		),
+		govTx(3,
+			fmt.Sprintf(`{"@type":"/cosmos.gov.v1beta1.MsgDeposit","proposal_id":"2","depositor":"%s","amount":[{"denom":"nanolike","amount":"200"}]}`, ADDR_03_LIKE),
+			`{"type":"proposal_deposit","attributes":[{"key":"amount","value":"200nanolike"},{"key":"proposal_id","value":"2"},{"key":"voting_period_start","value":"2"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.gov.v1beta1.MsgDeposit"}]}`,
+		),
+		govTx(4,
+			fmt.Sprintf(`{"@type":"/cosmos.gov.v1beta1.MsgVote","proposal_id":"1","voter":"%s","option":"VOTE_OPTION_YES"}`, ADDR_01_LIKE),
+			`{"type":"proposal_vote","attributes":[{"key":"option","value":"option:VOTE_OPTION_YES weight:\"1.000000000000000000\""},{"key":"proposal_id","value":"1"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.gov.v1beta1.MsgVote"}]}`,
+		),
+		govTx(5,
+			fmt.Sprintf(`{"@type":"/cosmos.gov.v1.MsgVoteWeighted","proposal_id":"1","voter":"%s","options":[{"option":"VOTE_OPTION_YES","weight":"0.700000000000000000"},{"option":"VOTE_OPTION_ABSTAIN","weight":"0.300000000000000000"}],"metadata":""}`, ADDR_02_LIKE),
+			`{"type":"proposal_vote","attributes":[{"key":"option","value":"[{\"option\":1,\"weight\":\"0.700000000000000000\"},{\"option\":2,\"weight\":\"0.300000000000000000\"}]"},{"key":"proposal_id","value":"1"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.gov.v1.MsgVoteWeighted"}]}`,
+		),
+		// vote through authz
+		govTx(6,
+			fmt.Sprintf(`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[{"@type":"/cosmos.gov.v1beta1.MsgVote","proposal_id":"2","voter":"%s","option":"VOTE_OPTION_NO"}]}`, ADDR_01_LIKE, ADDR_03_COSMOS),
+			`{"type":"proposal_vote","attributes":[{"key":"option","value":"option:VOTE_OPTION_NO weight:\"1.000000000000000000\""},{"key":"proposal_id","value":"2"},{"key":"authz_msg_index","value":"0"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"}]}`,
+		),
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	proposalsRes, err := GetGovProposals(Conn, QueryGovProposalsRequest{}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, proposalsRes.Proposals, 2)
+	p := proposalsRes.Proposals[0]
+	require.Equal(t, uint64(1), p.ProposalId)
+	require.Equal(t, ADDR_01_LIKE, p.Proposer)
+	require.Equal(t, []string{"/cosmos.gov.v1beta1.TextProposal"}, p.ProposalTypes)
+	require.Equal(t, "Test proposal", p.Title)
+	require.Equal(t, "Testing", p.Description)
+	require.JSONEq(t, content, string(p.Content))
+	p = proposalsRes.Proposals[1]
+	require.Equal(t, uint64(2), p.ProposalId)
+	require.Equal(t, ADDR_02_LIKE, p.Proposer)
+	require.Equal(t, []string{"/cosmos.gov.v1.MsgExecLegacyContent"}, p.ProposalTypes)
+	require.Equal(t, "Param change", p.Title)
+	require.Equal(t, "Change params", p.Description)
+	require.Equal(t, "ipfs://metadata", p.Metadata)
+	var messages []json.RawMessage
+	require.NoError(t, json.Unmarshal(p.Content, &messages))
+	require.Len(t, messages, 1)
+
+	depositsRes, err := GetGovDeposits(Conn, QueryGovDepositsRequest{}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	deposits := []string{}
+	for _, d := range depositsRes.Deposits {
+		deposits = append(deposits, fmt.Sprintf("%d %s %s%s %s", d.ProposalId, d.Depositor, d.Amount, d.Denom, d.TxHash))
+	}
+	require.Equal(t, []string{
+		"1 " + ADDR_01_LIKE + " 100nanolike GOV1",
+		"2 " + ADDR_03_LIKE + " 200nanolike GOV3",
+	}, deposits)
+
+	votesRes, err := GetGovVotes(Conn, QueryGovVotesRequest{ProposalId: 1}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, votesRes.Votes, 2)
+	require.Equal(t, ADDR_01_LIKE, votesRes.Votes[0].Voter)
+	require.Equal(t, []GovVoteOption{{Option: "VOTE_OPTION_YES", Weight: "1.000000000000000000"}}, votesRes.Votes[0].Options)
+	require.Equal(t, ADDR_02_LIKE, votesRes.Votes[1].Voter)
+	require.Equal(t, []GovVoteOption{
+		{Option: "VOTE_OPTION_YES", Weight: "0.700000000000000000"},
+		{Option: "VOTE_OPTION_ABSTAIN", Weight: "0.300000000000000000"},
+	}, votesRes.Votes[1].Options)
+
+	votesRes, err = GetGovVotes(Conn, QueryGovVotesRequest{Voter: ADDR_03_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, votesRes.Votes, 1)
+	require.Equal(t, uint64(2), votesRes.Votes[0].ProposalId)
+	require.Equal(t, "GOV6", votesRes.Votes[0].TxHash)
+	require.Equal(t, []GovVoteOption{{Option: "VOTE_OPTION_NO", Weight: "1.000000000000000000"}}, votesRes.Votes[0].Options)
+
+	votesRes, err = GetGovVotes(Conn, QueryGovVotesRequest{Option: "abstain"}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, votesRes.Votes, 1)
+	require.Equal(t, ADDR_02_LIKE, votesRes.Votes[0].Voter)
+}
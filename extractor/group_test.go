@@ -0,0 +1,77 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+var testLaggingGroup = ExtractorGroup{Name: "test_lagging", Version: 1}
+
+func testLaggingProcessor(payload *Payload, event *types.StringEvent) error {
+	payload.Batch.InsertNftClass(NftClass{Id: "lagging-" + utils.GetEventValue(event, "class_id")})
+	return nil
+}
+
+// newTestLaggingExtractor returns an extractor with the test group only, so it is not registered to the other tests
+func newTestLaggingExtractor() *EventExtractor {
+	e := NewEventExtractor()
+	e.Group(testLaggingGroup.Name, testLaggingGroup.Version).RegisterType("test.EventLagging", testLaggingProcessor)
+	return e
+}
+
+func laggingTestTx(height int) string {
+	return fmt.Sprintf(
+		`{"height":"%[1]d","txhash":"LAGGING%[1]d","tx":{"body":{"messages":[{"@type":"/test.Msg"}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"test.EventLagging","attributes":[{"key":"class_id","value":"class-%[1]d"}]}]}],"timestamp":"2022-01-01T00:00:00Z"}`,
+		height,
+	)
+}
+
+func TestExtractLagging(t *testing.T) {
+	defer CleanupTestData(Conn)
+	txs := []string{}
+	for height := 1; height <= 3; height++ {
+		txs = append(txs, laggingTestTx(height))
+	}
+	// the other groups have extracted these heights before the group is added
+	InsertTestData(DBTestData{Txs: txs, ExtractorHeight: 3})
+	batch := NewBatch(Conn, 1)
+	batch.UpsertMetaHeight(testLaggingGroup.MetaKey(), 0)
+	require.NoError(t, batch.Flush())
+	e := newTestLaggingExtractor()
+
+	finished, err := ExtractLagging(context.Background(), Conn, e)
+	require.NoError(t, err)
+	require.False(t, finished)
+	for height := 1; height <= 3; height++ {
+		require.Equal(t, 1, countNftClass(t, fmt.Sprintf("lagging-class-%d", height)))
+	}
+	groupHeight, err := GetMetaHeight(Conn, testLaggingGroup.MetaKey())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), groupHeight)
+	extractorHeight, err := GetMetaHeight(Conn, META_EXTRACTOR)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), extractorHeight)
+
+	finished, err = ExtractLagging(context.Background(), Conn, e)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	// the group has caught up, so new heights are extracted along with the others
+	InsertTestData(DBTestData{Txs: []string{laggingTestTx(4)}})
+	finished, err = Extract(context.Background(), Conn, e)
+	require.NoError(t, err)
+	require.True(t, finished)
+	require.Equal(t, 1, countNftClass(t, "lagging-class-4"))
+
+	cursors, err := GetExtractorCursors(Conn, e)
+	require.NoError(t, err)
+	require.Contains(t, cursors, ExtractorCursor{Name: testLaggingGroup.Name, Version: testLaggingGroup.Version, Height: 4})
+}
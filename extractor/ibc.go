@@ -0,0 +1,199 @@
+package extractor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// event emitted by the transfer module together with the core IBC events of the packet
+const ibcFungibleTokenPacketEvent = "fungible_token_packet"
+
+// ibcFungibleTokenPacketData is the packet data of ICS-20 transfers
+type ibcFungibleTokenPacketData struct {
+	Denom    string `json:"denom"`
+	Amount   string `json:"amount"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Memo     string `json:"memo"`
+}
+
+func parseIbcPacket(event *types.StringEvent) (db.IbcPacket, error) {
+	sequence, err := strconv.ParseUint(utils.GetEventValue(event, "packet_sequence"), 10, 64)
+	if err != nil {
+		return db.IbcPacket{}, fmt.Errorf("invalid packet sequence: %w", err)
+	}
+	return db.IbcPacket{
+		Sequence:   sequence,
+		SrcPort:    utils.GetEventValue(event, "packet_src_port"),
+		SrcChannel: utils.GetEventValue(event, "packet_src_channel"),
+		DstPort:    utils.GetEventValue(event, "packet_dst_port"),
+		DstChannel: utils.GetEventValue(event, "packet_dst_channel"),
+	}, nil
+}
+
+func parseIbcPacketData(event *types.StringEvent) (ibcFungibleTokenPacketData, error) {
+	var data ibcFungibleTokenPacketData
+	rawData := []byte{}
+	for _, attr := range event.Attributes {
+		if attr.Key == "packet_data" {
+			rawData = []byte(attr.Value)
+			break
+		}
+	}
+	if len(rawData) == 0 {
+		// packet_data is deprecated in favor of packet_data_hex
+		var err error
+		rawData, err = hex.DecodeString(utils.GetEventValue(event, "packet_data_hex"))
+		if err != nil {
+			return data, fmt.Errorf("failed to decode packet data: %w", err)
+		}
+	}
+	if err := json.Unmarshal(rawData, &data); err != nil {
+		return data, fmt.Errorf("failed to unmarshal packet data: %w", err)
+	}
+	return data, nil
+}
+
+func isIbcChannelId(id string) bool {
+	if !strings.HasPrefix(id, "channel-") {
+		return false
+	}
+	_, err := strconv.ParseUint(strings.TrimPrefix(id, "channel-"), 10, 64)
+	return err == nil
+}
+
+// ibcDenomTrace splits the full denom, e.g. transfer/channel-0/uatom, into the trace path and the base denom,
+// and returns the denom on this chain, which is ibc/<hash> for denoms with a path
+func ibcDenomTrace(fullDenom string) (denom, path, baseDenom string) {
+	items := strings.Split(fullDenom, "/")
+	i := 0
+	for i+2 < len(items) && isIbcChannelId(items[i+1]) {
+		i += 2
+	}
+	path = strings.Join(items[:i], "/")
+	baseDenom = strings.Join(items[i:], "/")
+	if path == "" {
+		return baseDenom, path, baseDenom
+	}
+	hash := sha256.Sum256([]byte(path + "/" + baseDenom))
+	return "ibc/" + strings.ToUpper(hex.EncodeToString(hash[:])), path, baseDenom
+}
+
+func newIbcTransfer(payload *Payload, direction db.IbcTransferDirection, packet db.IbcPacket, data ibcFungibleTokenPacketData, fullDenom string) db.IbcTransfer {
+	denom, path, baseDenom := ibcDenomTrace(fullDenom)
+	return db.IbcTransfer{
+		IbcPacket: packet,
+		Direction: direction,
+		Sender:    data.Sender,
+		Receiver:  data.Receiver,
+		Denom:     denom,
+		DenomPath: path,
+		BaseDenom: baseDenom,
+		Amount:    data.Amount,
+		Memo:      data.Memo,
+		Height:    payload.Height,
+		TxHash:    payload.TxHash,
+		MsgIndex:  payload.TxMsgIndex(),
+		Timestamp: payload.Timestamp,
+	}
+}
+
+func sendIbcTransfer(payload *Payload, event *types.StringEvent) error {
+	data, err := parseIbcPacketData(event)
+	if err != nil {
+		return err
+	}
+	if data.Denom == "" || data.Amount == "" {
+		// packet of other applications
+		return nil
+	}
+	packet, err := parseIbcPacket(event)
+	if err != nil {
+		return err
+	}
+	// the denom in the packet is the full denom on this chain
+	transfer := newIbcTransfer(payload, db.IBC_TRANSFER_OUTGOING, packet, data, data.Denom)
+	transfer.Sender = utils.NormalizeAddress(transfer.Sender, db.MainAddressPrefix)
+	transfer.Status = db.IBC_TRANSFER_PENDING
+	payload.Batch.InsertIbcTransfer(transfer)
+	return nil
+}
+
+func recvIbcTransfer(payload *Payload, event *types.StringEvent) error {
+	events := payload.GetEvents()
+	if utils.GetEventsValue(events, ibcFungibleTokenPacketEvent, "module") == "" {
+		// packet of other applications
+		return nil
+	}
+	data, err := parseIbcPacketData(event)
+	if err != nil {
+		return err
+	}
+	packet, err := parseIbcPacket(event)
+	if err != nil {
+		return err
+	}
+	// the denom in the packet is the full denom on the source chain,
+	// which is unwound if it was sent from this chain, otherwise prefixed by the destination port and channel
+	fullDenom := packet.DstPort + "/" + packet.DstChannel + "/" + data.Denom
+	sourcePrefix := packet.SrcPort + "/" + packet.SrcChannel + "/"
+	if strings.HasPrefix(data.Denom, sourcePrefix) {
+		fullDenom = strings.TrimPrefix(data.Denom, sourcePrefix)
+	}
+	transfer := newIbcTransfer(payload, db.IBC_TRANSFER_INCOMING, packet, data, fullDenom)
+	transfer.Receiver = utils.NormalizeAddress(transfer.Receiver, db.MainAddressPrefix)
+	transfer.Status = db.IBC_TRANSFER_COMPLETED
+	if utils.GetEventsValue(events, ibcFungibleTokenPacketEvent, "success") != "true" {
+		transfer.Status = db.IBC_TRANSFER_FAILED
+		transfer.Error = utils.GetEventsValue(events, ibcFungibleTokenPacketEvent, "error")
+	}
+	payload.Batch.InsertIbcTransfer(transfer)
+	return nil
+}
+
+func acknowledgeIbcTransfer(payload *Payload, event *types.StringEvent) error {
+	events := payload.GetEvents()
+	if utils.GetEventsValue(events, ibcFungibleTokenPacketEvent, "module") == "" {
+		return nil
+	}
+	packet, err := parseIbcPacket(event)
+	if err != nil {
+		return err
+	}
+	status := db.IBC_TRANSFER_COMPLETED
+	errorMessage := utils.GetEventsValue(events, ibcFungibleTokenPacketEvent, "error")
+	if errorMessage != "" {
+		status = db.IBC_TRANSFER_FAILED
+	}
+	payload.Batch.UpdateIbcTransferStatus(packet, status, errorMessage, payload.Height, payload.TxHash, payload.Timestamp)
+	return nil
+}
+
+func timeoutIbcTransfer(payload *Payload, event *types.StringEvent) error {
+	// refund event of the transfer module
+	if utils.GetEventsValue(payload.GetEvents(), "timeout", "refund_receiver") == "" {
+		return nil
+	}
+	packet, err := parseIbcPacket(event)
+	if err != nil {
+		return err
+	}
+	payload.Batch.UpdateIbcTransferStatus(packet, db.IBC_TRANSFER_TIMEOUT, "", payload.Height, payload.TxHash, payload.Timestamp)
+	return nil
+}
+
+func init() {
+	ibcGroup.RegisterType("send_packet", sendIbcTransfer)
+	ibcGroup.RegisterType("recv_packet", recvIbcTransfer)
+	ibcGroup.RegisterType("acknowledge_packet", acknowledgeIbcTransfer)
+	ibcGroup.RegisterType("timeout_packet", timeoutIbcTransfer)
+}
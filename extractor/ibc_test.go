@@ -0,0 +1,175 @@
+package extractor_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// ibcEvent marshals the event with the attributes given in key and value pairs
+func ibcEvent(eventType string, keyValues ...string) string {
+	event := types.StringEvent{Type: eventType}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		event.Attributes = append(event.Attributes, types.Attribute{Key: keyValues[i], Value: keyValues[i+1]})
+	}
+	eventJson, err := json.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+	return string(eventJson)
+}
+
+func ibcPacketEvent(eventType string, sequence int, srcChannel, dstChannel string, keyValues ...string) string {
+	return ibcEvent(eventType, append([]string{
+		"packet_sequence", fmt.Sprint(sequence),
+		"packet_src_port", "transfer",
+		"packet_src_channel", srcChannel,
+		"packet_dst_port", "transfer",
+		"packet_dst_channel", dstChannel,
+	}, keyValues...)...)
+}
+
+func ibcPacketData(denom, amount, sender, receiver string) string {
+	return fmt.Sprintf(`{"amount":"%s","denom":"%s","receiver":"%s","sender":"%s"}`, amount, denom, receiver, sender)
+}
+
+func TestIbcTransfer(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	osmoAddr, err := utils.ConvertAddressPrefix(ADDR_01_LIKE, "osmo")
+	require.NoError(t, err)
+	ibcTx := func(height int, msg string, events ...string) string {
+		events = append(events, ibcEvent("message", "action", "/test.ibc"))
+		eventsJson := events[0]
+		for _, event := range events[1:] {
+			eventsJson += "," + event
+		}
+		return fmt.Sprintf(
+			`{"height":"%[1]d","txhash":"IBC%[1]d","tx":{"body":{"messages":[%[2]s],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[%[3]s]}],"timestamp":"%[4]s"}`,
+			height, msg, eventsJson, timestamp.Format(time.RFC3339),
+		)
+	}
+	transferMsg := `{"@type":"/ibc.applications.transfer.v1.MsgTransfer"}`
+	relayMsg := `{"@type":"/ibc.core.channel.v1.MsgRecvPacket"}`
+	atomDenom := "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	txs := []string{
+		// native token, acknowledged
+		ibcTx(1, transferMsg, ibcPacketEvent("send_packet", 1, "channel-0", "channel-141",
+			"packet_data", ibcPacketData("nanolike", "100", ADDR_02_COSMOS, osmoAddr),
+		)),
+		// voucher sent back, rejected
+		ibcTx(2, transferMsg, ibcPacketEvent("send_packet", 2, "channel-0", "channel-141",
+			"packet_data", ibcPacketData("transfer/channel-0/uatom", "20", ADDR_02_LIKE, osmoAddr),
+		)),
+		// timed out, with packet_data_hex only
+		ibcTx(3, transferMsg, ibcPacketEvent("send_packet", 3, "channel-0", "channel-141",
+			"packet_data_hex", hex.EncodeToString([]byte(ibcPacketData("nanolike", "30", ADDR_02_LIKE, osmoAddr))),
+		)),
+		// packet of another application
+		ibcTx(4, `{"@type":"/ibc.applications.interchain_accounts.controller.v1.MsgSendTx"}`, ibcPacketEvent("send_packet", 4, "channel-1", "channel-2",
+			"packet_data", `{"type":"TYPE_EXECUTE_TX","data":"","memo":""}`,
+		)),
+		ibcTx(5, relayMsg,
+			ibcPacketEvent("acknowledge_packet", 1, "channel-0", "channel-141"),
+			ibcEvent("fungible_token_packet", "module", "transfer", "acknowledgement", "result:\"\\001\" ", "success", "\u0001"),
+		),
+		ibcTx(6, relayMsg,
+			ibcPacketEvent("acknowledge_packet", 2, "channel-0", "channel-141"),
+			ibcEvent("fungible_token_packet", "module", "transfer", "acknowledgement", "error:\"ABCI code: 1\"", "error", "ABCI code: 1"),
+		),
+		ibcTx(7, relayMsg,
+			ibcPacketEvent("timeout_packet", 3, "channel-0", "channel-141"),
+			ibcEvent("timeout", "module", "transfer", "refund_receiver", ADDR_02_LIKE, "refund_denom", "nanolike", "refund_amount", "30"),
+		),
+		// incoming token of another chain
+		ibcTx(8, relayMsg,
+			ibcPacketEvent("recv_packet", 5, "channel-141", "channel-0",
+				"packet_data", ibcPacketData("uatom", "40", osmoAddr, ADDR_03_COSMOS),
+			),
+			ibcEvent("fungible_token_packet", "module", "transfer", "sender", osmoAddr, "receiver", ADDR_03_COSMOS, "denom", "uatom", "amount", "40", "success", "true"),
+			ibcEvent("denomination_trace", "trace_hash", atomDenom[4:], "denom", atomDenom),
+		),
+		// incoming native token sent back
+		ibcTx(9, relayMsg,
+			ibcPacketEvent("recv_packet", 6, "channel-141", "channel-0",
+				"packet_data", ibcPacketData("transfer/channel-141/nanolike", "50", osmoAddr, ADDR_03_LIKE),
+			),
+			ibcEvent("fungible_token_packet", "module", "transfer", "sender", osmoAddr, "receiver", ADDR_03_LIKE, "denom", "transfer/channel-141/nanolike", "amount", "50", "success", "true"),
+		),
+		ibcTx(10, relayMsg,
+			ibcPacketEvent("recv_packet", 7, "channel-141", "channel-0",
+				"packet_data", ibcPacketData("uatom", "60", osmoAddr, "invalid"),
+			),
+			ibcEvent("fungible_token_packet", "module", "transfer", "sender", osmoAddr, "receiver", "invalid", "denom", "uatom", "amount", "60", "success", "false", "error", "invalid address"),
+		),
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err := GetIbcTransfers(Conn, QueryIbcTransfersRequest{Direction: IBC_TRANSFER_OUTGOING}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	transfers := []string{}
+	for _, transfer := range res.Transfers {
+		require.Equal(t, ADDR_02_LIKE, transfer.Sender)
+		require.Equal(t, osmoAddr, transfer.Receiver)
+		require.Equal(t, "channel-0", transfer.SrcChannel)
+		require.Equal(t, "channel-141", transfer.DstChannel)
+		require.NotNil(t, transfer.CompletedHeight)
+		transfers = append(transfers, fmt.Sprintf(
+			"%d %s %s%s %s %s %s %d %s",
+			transfer.Sequence, transfer.Status, transfer.Amount, transfer.Denom, transfer.DenomPath, transfer.BaseDenom,
+			transfer.TxHash, *transfer.CompletedHeight, transfer.Error,
+		))
+	}
+	require.Equal(t, []string{
+		"1 completed 100nanolike  nanolike IBC1 5 ",
+		"2 failed 20" + atomDenom + " transfer/channel-0 uatom IBC2 6 ABCI code: 1",
+		"3 timeout 30nanolike  nanolike IBC3 7 ",
+	}, transfers)
+
+	res, err = GetIbcTransfers(Conn, QueryIbcTransfersRequest{Address: ADDR_03_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	transfers = []string{}
+	for _, transfer := range res.Transfers {
+		require.Equal(t, IBC_TRANSFER_INCOMING, transfer.Direction)
+		require.Equal(t, osmoAddr, transfer.Sender)
+		require.Equal(t, ADDR_03_LIKE, transfer.Receiver)
+		require.Nil(t, transfer.CompletedHeight)
+		transfers = append(transfers, fmt.Sprintf(
+			"%d %s %s%s %s %s %s",
+			transfer.Sequence, transfer.Status, transfer.Amount, transfer.Denom, transfer.DenomPath, transfer.BaseDenom, transfer.TxHash,
+		))
+	}
+	require.Equal(t, []string{
+		"5 completed 40" + atomDenom + " transfer/channel-0 uatom IBC8",
+		"6 completed 50nanolike  nanolike IBC9",
+	}, transfers)
+
+	res, err = GetIbcTransfers(Conn, QueryIbcTransfersRequest{Status: []IbcTransferStatus{IBC_TRANSFER_FAILED}, Direction: IBC_TRANSFER_INCOMING}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Transfers, 1)
+	require.Equal(t, uint64(7), res.Transfers[0].Sequence)
+	require.Equal(t, "invalid address", res.Transfers[0].Error)
+
+	res, err = GetIbcTransfers(Conn, QueryIbcTransfersRequest{Address: osmoAddr, Denom: "uatom"}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Transfers, 3)
+
+	tracesRes, err := GetIbcDenomTraces(Conn, QueryIbcDenomTracesRequest{Denom: atomDenom[4:]})
+	require.NoError(t, err)
+	require.Equal(t, []IbcDenomTrace{{Denom: atomDenom, Path: "transfer/channel-0", BaseDenom: "uatom"}}, tracesRes.DenomTraces)
+}
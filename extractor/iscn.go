@@ -70,20 +70,61 @@ func insertIscn(payload *Payload, event *types.StringEvent) error {
 		Timestamp:    payload.Timestamp,
 		Ipld:         utils.GetEventValue(event, "ipld"),
 		Data:         data.Record,
+		Height:       payload.Height,
+		TxHash:       payload.TxHash,
 	}
 	payload.Batch.InsertIscn(iscn)
 	return nil
 }
 
 func transferIscn(payload *Payload, event *types.StringEvent) error {
-	events := payload.GetEvents()
 	iscnId := utils.GetEventValue(event, "iscn_id")
 	newOwner := utils.GetEventValue(event, "owner")
-	payload.Batch.Batch.Queue(`UPDATE iscn SET owner = $2 WHERE iscn_id = $1`, iscnId, newOwner)
+	payload.Batch.TransferIscn(iscnId, newOwner, payload.Height)
+	return nil
+}
+
+var iscnEventActions = map[string]db.IscnEventAction{
+	"/likechain.iscn.MsgCreateIscnRecord":          db.ISCN_ACTION_CREATE,
+	"/likechain.iscn.MsgUpdateIscnRecord":          db.ISCN_ACTION_UPDATE,
+	"/likechain.iscn.MsgChangeIscnRecordOwnership": db.ISCN_ACTION_CHANGE_OWNERSHIP,
+}
 
-	// TODO: sender could be different from message.sender in authz
-	sender := utils.GetEventsValue(events, "message", "sender")
-	logger.L.Debugf("Send ISCN %s from %s to %s\n", iscnId, sender, newOwner)
+// recordIscnEvent records the history of the ISCN record, which the iscn table keeps the latest state only.
+// The sender is the signer of the message instead of message.sender, which is the grantee for messages inside MsgExec.
+func recordIscnEvent(payload *Payload, event *types.StringEvent) error {
+	var msg struct {
+		Type string `json:"@type"`
+		From string `json:"from"`
+	}
+	if err := json.Unmarshal(payload.GetMessage(), &msg); err != nil {
+		return fmt.Errorf("failed to unmarshal ISCN message: %w", err)
+	}
+	action, ok := iscnEventActions[msg.Type]
+	if !ok {
+		return fmt.Errorf("unknown ISCN message type %s", msg.Type)
+	}
+	iscnId := utils.GetEventValue(event, "iscn_id")
+	e := db.IscnEvent{
+		Action:       action,
+		IscnId:       iscnId,
+		IscnIdPrefix: utils.GetEventValue(event, "iscn_id_prefix"),
+		Version:      GetIscnVersion(iscnId),
+		Sender:       msg.From,
+		NewOwner:     utils.GetEventValue(event, "owner"),
+		Height:       payload.Height,
+		TxHash:       payload.TxHash,
+		MsgIndex:     payload.TxMsgIndex(),
+		Timestamp:    payload.Timestamp,
+	}
+	switch action {
+	case db.ISCN_ACTION_UPDATE:
+		e.PrevOwner = e.NewOwner
+	case db.ISCN_ACTION_CHANGE_OWNERSHIP:
+		// only the owner can change the ownership
+		e.PrevOwner = msg.From
+	}
+	payload.Batch.InsertIscnEvent(e)
 	return nil
 }
 
@@ -98,6 +139,8 @@ func GetIscnVersion(iscn string) int {
 }
 
 func init() {
-	eventExtractor.RegisterTypeKey("iscn_record", "ipld", insertIscn)
-	eventExtractor.RegisterTypeKey("iscn_record", "owner", transferIscn)
+	iscnGroup.RegisterTypeKey("iscn_record", "ipld", insertIscn)
+	iscnGroup.RegisterTypeKey("iscn_record", "owner", transferIscn)
+
+	iscnEventGroup.RegisterType("iscn_record", recordIscnEvent)
 }
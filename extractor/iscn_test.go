@@ -1,8 +1,10 @@
 package extractor_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -69,7 +71,7 @@ func TestIscn(t *testing.T) {
 		Txs: txs,
 	})
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -80,6 +82,8 @@ func TestIscn(t *testing.T) {
 
 	record := res.Records[0]
 	require.Equal(t, ipld, record.Ipld)
+	require.Equal(t, int64(1234), record.Height)
+	require.Equal(t, "AAAAAA", record.TxHash)
 
 	data := record.Data
 	require.Equal(t, iscnId1, data.Id)
@@ -134,7 +138,7 @@ func TestIscn(t *testing.T) {
 	}
 	InsertTestData(DBTestData{Txs: txs})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -178,7 +182,7 @@ func TestIscn(t *testing.T) {
 	InsertTestData(DBTestData{Txs: txs})
 	require.NoError(t, err)
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -191,6 +195,8 @@ func TestIscn(t *testing.T) {
 
 	data = record.Data
 	require.Equal(t, iscnId2, data.Id)
+	require.Equal(t, int64(1235), record.Height)
+	require.Equal(t, "BBBBBB", record.TxHash)
 	require.Equal(t, ADDR_02_LIKE, data.Owner)
 	require.Truef(t, timestamp.Equal(data.RecordTimestamp), "Record timestamp: expect %s got %s", timestamp, data.RecordTimestamp)
 	require.Equal(t, recordNotes, string(data.RecordNotes))
@@ -211,4 +217,72 @@ func TestIscn(t *testing.T) {
 	for i, v := range fingerprints {
 		require.Equal(t, v, resFingerprints[i])
 	}
+
+	res, err = QueryIscn(Conn, IscnQuery{IscnIdPrefix: iscnIdPrefix, AllIscnVersions: true, MinHeight: 1235}, page)
+	require.NoError(t, err)
+	require.Len(t, res.Records, 1)
+	require.Equal(t, iscnId2, res.Records[0].Data.Id)
+
+	res, err = QueryIscn(Conn, IscnQuery{IscnIdPrefix: iscnIdPrefix, AllIscnVersions: true, MaxHeight: 1234}, page)
+	require.NoError(t, err)
+	require.Len(t, res.Records, 1)
+	require.Equal(t, iscnId1, res.Records[0].Data.Id)
+}
+
+func TestIscnProvenance(t *testing.T) {
+	defer CleanupTestData(Conn)
+	iscnIdPrefix := "iscn://testing/PROVENANCE"
+	timestamp := time.Unix(1680000000, 0).UTC()
+	record := `{"contentFingerprints":["hash://testing/PROVENANCE"],"stakeholders":[],"contentMetadata":{"name":"provenance"}}`
+	iscnEvent := `{"type":"iscn_record","attributes":[{"key":"iscn_id","value":"%s"},{"key":"iscn_id_prefix","value":"%s"},{"key":"owner","value":"%s"}%s]}`
+	tx := func(height int, msg string, events ...string) string {
+		return fmt.Sprintf(
+			`{"height":"%[1]d","txhash":"PROVENANCE%[1]d","tx":{"body":{"messages":[%[2]s],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[%[3]s]}],"timestamp":"%[4]s"}`,
+			height, msg, strings.Join(events, ","), timestamp.Add(time.Duration(height)*time.Hour).Format(time.RFC3339),
+		)
+	}
+	txs := []string{
+		tx(1,
+			fmt.Sprintf(`{"@type":"/likechain.iscn.MsgCreateIscnRecord","from":"%s","record":%s}`, ADDR_01_LIKE, record),
+			fmt.Sprintf(iscnEvent, iscnIdPrefix+"/1", iscnIdPrefix, ADDR_01_LIKE, `,{"key":"ipld","value":"ipld1"}`),
+			fmt.Sprintf(`{"type":"message","attributes":[{"key":"action","value":"create_iscn_record"},{"key":"sender","value":"%s"}]}`, ADDR_01_LIKE),
+		),
+		tx(2,
+			fmt.Sprintf(`{"@type":"/likechain.iscn.MsgUpdateIscnRecord","from":"%s","iscn_id":"%s","record":%s}`, ADDR_01_LIKE, iscnIdPrefix+"/1", record),
+			fmt.Sprintf(iscnEvent, iscnIdPrefix+"/2", iscnIdPrefix, ADDR_01_LIKE, `,{"key":"ipld","value":"ipld2"}`),
+			fmt.Sprintf(`{"type":"message","attributes":[{"key":"action","value":"update_iscn_record"},{"key":"sender","value":"%s"}]}`, ADDR_01_LIKE),
+		),
+		// executed by the grantee ADDR_03 on behalf of the owner
+		tx(3,
+			fmt.Sprintf(
+				`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[{"@type":"/likechain.iscn.MsgChangeIscnRecordOwnership","from":"%s","iscn_id":"%s","new_owner":"%s"}]}`,
+				ADDR_03_LIKE, ADDR_01_LIKE, iscnIdPrefix+"/2", ADDR_02_LIKE,
+			),
+			fmt.Sprintf(iscnEvent, iscnIdPrefix+"/2", iscnIdPrefix, ADDR_02_LIKE, `,{"key":"authz_msg_index","value":"0"}`),
+			fmt.Sprintf(`{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"},{"key":"sender","value":"%s"}]}`, ADDR_03_LIKE),
+		),
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err := GetIscnProvenance(Conn, iscnIdPrefix)
+	require.NoError(t, err)
+	require.Equal(t, ADDR_02_LIKE, res.Owner)
+	require.Len(t, res.Events, 3)
+	events := []string{}
+	for _, e := range res.Events {
+		require.Equal(t, iscnIdPrefix, e.IscnIdPrefix)
+		require.Equal(t, ADDR_01_LIKE, e.Sender)
+		require.Equal(t, 0, e.MsgIndex)
+		events = append(events, fmt.Sprintf("%s %d %s %s %s %d", e.Action, e.Version, e.PrevOwner, e.NewOwner, e.TxHash, e.Height))
+	}
+	require.Equal(t, []string{
+		fmt.Sprintf("create 1  %s PROVENANCE1 1", ADDR_01_LIKE),
+		fmt.Sprintf("update 2 %s %s PROVENANCE2 2", ADDR_01_LIKE, ADDR_01_LIKE),
+		fmt.Sprintf("change_ownership 2 %s %s PROVENANCE3 3", ADDR_01_LIKE, ADDR_02_LIKE),
+	}, events)
+	require.Equal(t, timestamp.Add(3*time.Hour), res.Events[2].Timestamp.UTC())
 }
@@ -38,6 +38,8 @@ func parseMessage(payload *Payload) (db.NftMarketplaceItem, error) {
 		Creator:    item.Creator,
 		Price:      price,
 		Expiration: item.Expiration,
+		Height:     payload.Height,
+		TxHash:     payload.TxHash,
 	}, nil
 }
 
@@ -58,6 +60,7 @@ func deleteListing(payload *Payload, event *types.StringEvent) error {
 		ClassId: utils.GetEventValue(event, "class_id"),
 		NftId:   utils.GetEventValue(event, "nft_id"),
 		Creator: utils.GetEventValue(event, "seller"),
+		Height:  payload.Height,
 	}
 	payload.Batch.DeleteNFTMarketplaceItem(item)
 	return nil
@@ -86,6 +89,7 @@ func deleteOffer(payload *Payload, event *types.StringEvent) error {
 		ClassId: utils.GetEventValue(event, "class_id"),
 		NftId:   utils.GetEventValue(event, "nft_id"),
 		Creator: utils.GetEventValue(event, "buyer"),
+		Height:  payload.Height,
 	}
 	payload.Batch.DeleteNFTMarketplaceItem(item)
 	return nil
@@ -121,17 +125,19 @@ func marketplaceDeal(payload *Payload, event *types.StringEvent, actionType db.N
 	e := extractNftEvent(event, "class_id", "nft_id", "seller", "buyer")
 	e.Price = getPriceFromEvent(event)
 	e.Action = actionType
-	sql := `UPDATE nft SET owner = $1 WHERE class_id = $2 AND nft_id = $3`
-	payload.Batch.Batch.Queue(sql, e.Receiver, e.ClassId, e.NftId)
+	payload.Batch.TransferNft(e.ClassId, e.NftId, e.Receiver, payload.Height)
 
-	msgIndex := payload.MsgIndex
-	msgEvents := payload.EventsList[msgIndex].Events
-	incomes := GetIncomesFromBuySellNftMsg(msgEvents, payload.TxHash)
+	attachNftEvent(&e, payload)
+	payload.Batch.InsertNftEvent(e)
+	return nil
+}
+
+func marketplaceDealIncome(payload *Payload, event *types.StringEvent) error {
+	incomes := GetIncomesFromBuySellNftMsg(payload.GetEvents(), payload.TxHash)
 	for _, income := range incomes {
+		income.Height = payload.Height
 		payload.Batch.InsertNftIncome(income)
 	}
-	attachNftEvent(&e, payload)
-	payload.Batch.InsertNftEvent(e)
 	return nil
 }
 
@@ -205,12 +211,15 @@ func GetIncomesFromBuySellNftMsg(events types.StringEvents, txHash string) []db.
 }
 
 func init() {
-	eventExtractor.RegisterType("likechain.likenft.v1.EventBuyNFT", buyNft)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventSellNFT", sellNft)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventCreateListing", createListing)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventUpdateListing", updateListing)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventDeleteListing", deleteListing)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventCreateOffer", createOffer)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventUpdateOffer", updateOffer)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventDeleteOffer", deleteOffer)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventBuyNFT", buyNft)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventSellNFT", sellNft)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventCreateListing", createListing)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventUpdateListing", updateListing)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventDeleteListing", deleteListing)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventCreateOffer", createOffer)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventUpdateOffer", updateOffer)
+	marketplaceGroup.RegisterType("likechain.likenft.v1.EventDeleteOffer", deleteOffer)
+
+	incomeGroup.RegisterType("likechain.likenft.v1.EventBuyNFT", marketplaceDealIncome)
+	incomeGroup.RegisterType("likechain.likenft.v1.EventSellNFT", marketplaceDealIncome)
 }
@@ -1,6 +1,7 @@
 package extractor_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -57,7 +58,7 @@ func TestBuyNftOwnership(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, eventRes.Events)
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -125,7 +126,7 @@ func TestSellNftOwnership(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, eventRes.Events)
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -207,7 +208,7 @@ func TestListing(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, itemsRes.Items)
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -226,6 +227,8 @@ func TestListing(t *testing.T) {
 	require.Equal(t, ADDR_01_LIKE, itemsRes.Items[0].Creator)
 	require.Equal(t, initPrice1, itemsRes.Items[0].Price)
 	require.Equal(t, expiration, itemsRes.Items[0].Expiration)
+	require.Equal(t, int64(1234), itemsRes.Items[0].Height)
+	require.Equal(t, "AAAAAA", itemsRes.Items[0].TxHash)
 	require.Equal(t, "listing", itemsRes.Items[1].Type)
 	require.Equal(t, nftClasses[0].Id, itemsRes.Items[1].ClassId)
 	require.Equal(t, nfts[1].NftId, itemsRes.Items[1].NftId)
@@ -242,7 +245,7 @@ func TestListing(t *testing.T) {
 	}
 	InsertTestData(DBTestData{Txs: txs})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -267,6 +270,8 @@ func TestListing(t *testing.T) {
 	require.Equal(t, ADDR_01_LIKE, itemsRes.Items[1].Creator)
 	require.Equal(t, updatedPrice1, itemsRes.Items[1].Price)
 	require.Equal(t, expiration.Add(2*time.Second), itemsRes.Items[1].Expiration)
+	require.Equal(t, int64(1236), itemsRes.Items[1].Height)
+	require.Equal(t, "AAAAAC", itemsRes.Items[1].TxHash)
 
 	txs = []string{
 		fmt.Sprintf(
@@ -276,7 +281,7 @@ func TestListing(t *testing.T) {
 	}
 	InsertTestData(DBTestData{Txs: txs})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -309,7 +314,7 @@ func TestListing(t *testing.T) {
 	}
 	InsertTestData(DBTestData{Txs: txs})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -358,6 +363,52 @@ func TestListing(t *testing.T) {
 	require.Equal(t, incomesRes.TotalSales, classIncome.Sales)
 }
 
+func TestListingOutOfOrder(t *testing.T) {
+	defer CleanupTestData(Conn)
+	nftClasses := []NftClass{
+		{
+			Id:     "nftlike1aaaaa1",
+			Parent: NftClassParent{IscnIdPrefix: "iscn://testing/aaaaaa"},
+		},
+	}
+	nfts := []Nft{
+		{
+			NftId:   "testing-nft-100023",
+			ClassId: nftClasses[0].Id,
+			Owner:   ADDR_01_LIKE,
+		},
+	}
+	expiration := time.Unix(1700000000, 0).UTC()
+	createListingTx := func(height int, price uint64) string {
+		return fmt.Sprintf(
+			`{"txhash":"LISTING%[6]d","height":"%[6]d","tx":{"body":{"messages":[{"@type":"/likechain.likenft.v1.MsgCreateListing","creator":"%[1]s","class_id":"%[2]s","nft_id":"%[3]s","price":"%[4]d","expiration":"%[5]s"}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"create_listing"}]},{"type":"likechain.likenft.v1.EventCreateListing","attributes":[{"key":"class_id","value":"\"%[2]s\""},{"key":"nft_id","value":"\"%[3]s\""},{"key":"seller","value":"\"%[1]s\""}]}]}]}`,
+			ADDR_01_LIKE, nftClasses[0].Id, nfts[0].NftId, price, expiration.Format(time.RFC3339), height,
+		)
+	}
+	blockTime := expiration.Add(-10000 * time.Second)
+	InsertTestData(DBTestData{
+		NftClasses:      nftClasses,
+		Nfts:            nfts,
+		Txs:             []string{createListingTx(1234, 100), createListingTx(1235, 200)},
+		LatestBlockTime: &blockTime,
+	})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	// extracting the older listing again, as backfill does for a missed height, must not replace the later one
+	err = extractor.Reextract(context.Background(), Conn, 1234, 1234, []string{extractor.GroupMarketplace})
+	require.NoError(t, err)
+
+	itemsRes, err := GetNftMarketplaceItems(Conn, QueryNftMarketplaceItemsRequest{Type: "listing"}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, itemsRes.Items, 1)
+	require.Equal(t, uint64(200), itemsRes.Items[0].Price)
+	require.Equal(t, int64(1235), itemsRes.Items[0].Height)
+	require.Equal(t, "LISTING1235", itemsRes.Items[0].TxHash)
+}
+
 func TestOffer(t *testing.T) {
 	defer CleanupTestData(Conn)
 	prefixA := "iscn://testing/aaaaaa"
@@ -417,7 +468,7 @@ func TestOffer(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, itemsRes.Items)
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -452,7 +503,7 @@ func TestOffer(t *testing.T) {
 	}
 	InsertTestData(DBTestData{Txs: txs})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -486,7 +537,7 @@ func TestOffer(t *testing.T) {
 	}
 	InsertTestData(DBTestData{Txs: txs})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -519,7 +570,7 @@ func TestOffer(t *testing.T) {
 	}
 	InsertTestData(DBTestData{Txs: txs})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -32,6 +32,8 @@ func createNftClass(payload *Payload, event *types.StringEvent) error {
 	c.Id = utils.GetEventValue(event, "class_id")
 	c.Parent = getNftParent(event)
 	c.CreatedAt = payload.Timestamp
+	c.Height = payload.Height
+	c.TxHash = payload.TxHash
 	payload.Batch.InsertNftClass(c)
 
 	e := db.NftEvent{
@@ -51,7 +53,7 @@ func updateNftClass(payload *Payload, event *types.StringEvent) error {
 	}
 	c := message.Input
 	c.Id = utils.GetEventValue(event, "class_id")
-	payload.Batch.UpdateNftClass(c)
+	payload.Batch.UpdateNftClass(c, payload.Timestamp)
 
 	e := db.NftEvent{
 		ClassId: c.Id,
@@ -89,6 +91,8 @@ func mintNft(payload *Payload, event *types.StringEvent) error {
 	nft.NftId = utils.GetEventValue(event, "nft_id")
 	nft.Owner = utils.GetEventValue(event, "owner")
 	nft.ClassId = utils.GetEventValue(event, "class_id")
+	nft.Height = payload.Height
+	nft.TxHash = payload.TxHash
 
 	payload.Batch.InsertNft(nft)
 
@@ -103,6 +107,19 @@ func mintNft(payload *Payload, event *types.StringEvent) error {
 	return nil
 }
 
+// burnNft returns the processor of the burn event, with the NFT ID under nftIdField.
+// MsgBurnNFT emits both the likenft and the x/nft events, where the second event inserts the same nft_event again and is ignored.
+func burnNft(nftIdField string) EventProcessor {
+	return func(payload *Payload, event *types.StringEvent) error {
+		e := extractNftEvent(event, "class_id", nftIdField, "owner", "")
+		e.Action = db.ACTION_BURN
+		payload.Batch.BurnNft(e.ClassId, e.NftId, payload.Height)
+		attachNftEvent(&e, payload)
+		payload.Batch.InsertNftEvent(e)
+		return nil
+	}
+}
+
 func extractPriceFromEvents(events types.StringEvents) uint64 {
 	priceStr := utils.GetEventsValue(events, "coin_received", "amount")
 	if priceStr == "" {
@@ -133,8 +150,7 @@ func extractNftEvent(event *types.StringEvent, classIdField, nftIdField, senderF
 func sendNft(payload *Payload, event *types.StringEvent) error {
 	e := extractNftEvent(event, "class_id", "id", "sender", "receiver")
 	e.Action = db.ACTION_SEND
-	sql := `UPDATE nft SET owner = $1 WHERE class_id = $2 AND nft_id = $3`
-	payload.Batch.Batch.Queue(sql, e.Receiver, e.ClassId, e.NftId)
+	payload.Batch.TransferNft(e.ClassId, e.NftId, e.Receiver, payload.Height)
 
 	// In our application, we use authz token send together with NFT send to mimic
 	// selling an NFT, where the API address is the "market" holding the NFT.
@@ -144,18 +160,13 @@ func sendNft(payload *Payload, event *types.StringEvent) error {
 	// We want to identify this case and extract the "price" from the transaction.
 
 	// We assume the first message is the authz message with token send
-	// TODO: also check if authz grantee is API address
+	// The grantee is verified against authz_grant for the incomes only, see GetIncomesFromSendNftMsgs
 	sendNftMsgIndex := payload.MsgIndex
 	if sendNftMsgIndex > 0 {
 		prevMsgEvents := payload.EventsList[sendNftMsgIndex-1].Events
 		prevMsgAction := utils.GetEventsValue(prevMsgEvents, "message", "action")
 		if prevMsgAction == "/cosmos.authz.v1beta1.MsgExec" {
 			e.Price = extractPriceFromEvents(prevMsgEvents)
-
-			incomes := GetIncomesFromSendNftMsgs(payload.EventsList, sendNftMsgIndex, payload.TxHash)
-			for _, income := range incomes {
-				payload.Batch.InsertNftIncome(income)
-			}
 		}
 	}
 	attachNftEvent(&e, payload)
@@ -163,16 +174,34 @@ func sendNft(payload *Payload, event *types.StringEvent) error {
 	return nil
 }
 
-func GetIncomesFromSendNftMsgs(eventsList db.EventsList, msgIndex int, txHash string) []db.NftIncome {
+func sendNftIncome(payload *Payload, event *types.StringEvent) error {
+	incomes, grant := GetIncomesFromSendNftMsgs(payload.EventsList, payload.MsgIndex, payload.TxHash)
+	// authz_grant is complete up to this transaction if the authz group extracts the transactions together,
+	// otherwise it depends on the progress of the authz group, e.g. it is complete when re-extracting the incomes alone,
+	// but not when the authz group is being backfilled
+	grantsMetaKey := ""
+	if !payload.extractsGroup(GroupAuthz) {
+		grantsMetaKey = authzGroup.ExtractorGroup().MetaKey()
+	}
+	for _, income := range incomes {
+		income.Height = payload.Height
+		payload.Batch.InsertGrantedNftIncome(income, grant, payload.Timestamp, grantsMetaKey)
+	}
+	return nil
+}
+
+// GetIncomesFromSendNftMsgs returns the incomes of selling an NFT by the API address, together with the grant which the API address
+// executes the token send with, i.e. from the buyer to the NFT sender. The incomes should be dropped if the grant is not in authz_grant.
+func GetIncomesFromSendNftMsgs(eventsList db.EventsList, msgIndex int, txHash string) ([]db.NftIncome, db.AuthzGrantKey) {
 	if msgIndex < 1 {
-		return []db.NftIncome{}
+		return []db.NftIncome{}, db.AuthzGrantKey{}
 	}
 	// We assume the first message is the authz message with token send
 	authzMsgIndex := msgIndex - 1
 	authzMsgEvents := eventsList[authzMsgIndex].Events
 	authzMsgAction := utils.GetEventsValue(authzMsgEvents, "message", "action")
-	if authzMsgAction != "/cosmos.authz.v1beta1.MsgExec" {
-		return []db.NftIncome{}
+	if authzMsgAction != msgExecTypeUrl {
+		return []db.NftIncome{}, db.AuthzGrantKey{}
 	}
 
 	sendTokenMsgStartIndex := msgIndex + 1
@@ -180,7 +209,7 @@ func GetIncomesFromSendNftMsgs(eventsList db.EventsList, msgIndex int, txHash st
 	for i := sendTokenMsgStartIndex; i < len(eventsList); i++ {
 		currMsgEvents := eventsList[i].Events
 		currMsgAction := utils.GetEventsValue(currMsgEvents, "message", "action")
-		if currMsgAction != "/cosmos.bank.v1beta1.MsgSend" {
+		if currMsgAction != bankMsgSendTypeUrl {
 			break
 		}
 		address := utils.GetEventsValue(currMsgEvents, "coin_received", "receiver")
@@ -197,6 +226,11 @@ func GetIncomesFromSendNftMsgs(eventsList db.EventsList, msgIndex int, txHash st
 	sendNftMsgEvnets := eventsList[msgIndex].Events
 	classId := utils.GetEventsValue(sendNftMsgEvnets, "cosmos.nft.v1beta1.EventSend", "class_id")
 	nftId := utils.GetEventsValue(sendNftMsgEvnets, "cosmos.nft.v1beta1.EventSend", "id")
+	grant := db.AuthzGrantKey{
+		Granter:    utils.GetEventsValue(authzMsgEvents, "transfer", "sender"),
+		Grantee:    utils.GetEventsValue(sendNftMsgEvnets, "cosmos.nft.v1beta1.EventSend", "sender"),
+		MsgTypeUrl: bankMsgSendTypeUrl,
+	}
 
 	incomes := []db.NftIncome{}
 	for _, income := range aggregatedIncomes {
@@ -210,12 +244,16 @@ func GetIncomesFromSendNftMsgs(eventsList db.EventsList, msgIndex int, txHash st
 		})
 	}
 
-	return incomes
+	return incomes, grant
 }
 
 func init() {
-	eventExtractor.RegisterType("likechain.likenft.v1.EventNewClass", createNftClass)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventUpdateClass", updateNftClass)
-	eventExtractor.RegisterType("likechain.likenft.v1.EventMintNFT", mintNft)
-	eventExtractor.RegisterType("cosmos.nft.v1beta1.EventSend", sendNft)
+	nftGroup.RegisterType("likechain.likenft.v1.EventNewClass", createNftClass)
+	nftGroup.RegisterType("likechain.likenft.v1.EventUpdateClass", updateNftClass)
+	nftGroup.RegisterType("likechain.likenft.v1.EventMintNFT", mintNft)
+	nftGroup.RegisterType("cosmos.nft.v1beta1.EventSend", sendNft)
+	nftGroup.RegisterType("likechain.likenft.v1.EventBurnNFT", burnNft("nft_id"))
+	nftGroup.RegisterType("cosmos.nft.v1beta1.EventBurn", burnNft("id"))
+
+	incomeGroup.RegisterType("cosmos.nft.v1beta1.EventSend", sendNftIncome)
 }
@@ -2,6 +2,7 @@ package extractor_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -43,7 +44,7 @@ func TestCreateAndUpdateNft(t *testing.T) {
 		Txs:   txs,
 	})
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -61,6 +62,8 @@ func TestCreateAndUpdateNft(t *testing.T) {
 	require.Equal(t, metadata, string(res.Classes[0].Metadata))
 	require.Equal(t, timestamp, res.Classes[0].CreatedAt.UTC())
 	require.Equal(t, config, string(res.Classes[0].Config))
+	require.Equal(t, int64(1234), res.Classes[0].Height)
+	require.Equal(t, "AAAAAA", res.Classes[0].TxHash)
 
 	eventRes, err := GetNftEvents(Conn, QueryEventsRequest{
 		ActionType: []NftEventAction{ACTION_NEW_CLASS},
@@ -91,7 +94,7 @@ func TestCreateAndUpdateNft(t *testing.T) {
 		Txs:   txs,
 	})
 
-	finished, err = Extract(Conn, extractor.ExtractFunc)
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -109,6 +112,8 @@ func TestCreateAndUpdateNft(t *testing.T) {
 	require.Equal(t, metadata, string(res.Classes[0].Metadata))
 	require.Equal(t, timestamp, res.Classes[0].CreatedAt.UTC())
 	require.Equal(t, config, string(res.Classes[0].Config))
+	require.Equal(t, int64(1234), res.Classes[0].Height)
+	require.Equal(t, "AAAAAA", res.Classes[0].TxHash)
 
 	eventRes, err = GetNftEvents(Conn, QueryEventsRequest{
 		ActionType: []NftEventAction{ACTION_UPDATE_CLASS},
@@ -118,6 +123,21 @@ func TestCreateAndUpdateNft(t *testing.T) {
 	require.Len(t, eventRes.Events, 1)
 	require.Equal(t, ADDR_01_LIKE, eventRes.Events[0].Sender)
 	require.Equal(t, "AAAAAB", eventRes.Events[0].Memo)
+
+	// re-extracting the creation alone must not revert the later update
+	err = extractor.Reextract(context.Background(), Conn, 1234, 1234, []string{extractor.GroupNft})
+	require.NoError(t, err)
+
+	res, err = GetClasses(Conn, QueryClassRequest{}, pagination)
+	require.NoError(t, err)
+	require.Len(t, res.Classes, 1)
+	require.Equal(t, name, res.Classes[0].Name)
+	require.Equal(t, symbol, res.Classes[0].Symbol)
+	require.Equal(t, uri, res.Classes[0].URI)
+	require.Equal(t, description, res.Classes[0].Description)
+	require.Equal(t, metadata, string(res.Classes[0].Metadata))
+	require.Equal(t, config, string(res.Classes[0].Config))
+	require.Equal(t, int64(1234), res.Classes[0].Height)
 }
 
 func TestSendNft(t *testing.T) {
@@ -165,7 +185,7 @@ func TestSendNft(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, eventRes.Events)
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -189,6 +209,66 @@ func TestSendNft(t *testing.T) {
 	require.Equal(t, "AAAAAA", eventRes.Events[0].Memo)
 }
 
+func TestReextractSendNftKeepsLatestOwner(t *testing.T) {
+	defer CleanupTestData(Conn)
+	nftClasses := []NftClass{
+		{
+			Id:     "nftlike1aaaaa1",
+			Parent: NftClassParent{IscnIdPrefix: "iscn://testing/aaaaaa"},
+		},
+	}
+	nfts := []Nft{
+		{
+			NftId:   "testing-nft-919775",
+			ClassId: nftClasses[0].Id,
+			Owner:   ADDR_01_LIKE,
+			Height:  1,
+		},
+	}
+	sendTx := func(height int, sender, receiver string) string {
+		return fmt.Sprintf(`{"txhash":"SEND%[1]d","height":"%[1]d","tx":{"body":{"messages":[{"@type":"/cosmos.nft.v1beta1.MsgSend","sender":"%[4]s","class_id":"%[2]s","id":"%[3]s","receiver":"%[5]s"}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"cosmos.nft.v1beta1.EventSend","attributes":[{"key":"class_id","value":"\"%[2]s\""},{"key":"id","value":"\"%[3]s\""},{"key":"sender","value":"\"%[4]s\""},{"key":"receiver","value":"\"%[5]s\""}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.nft.v1beta1.MsgSend"}]}]}],"timestamp":"2022-01-01T00:00:0%[1]dZ"}`, height, nftClasses[0].Id, nfts[0].NftId, sender, receiver)
+	}
+	InsertTestData(DBTestData{
+		NftClasses: nftClasses,
+		Nfts:       nfts,
+		Txs: []string{
+			sendTx(2, ADDR_01_LIKE, ADDR_02_LIKE),
+			sendTx(3, ADDR_02_LIKE, ADDR_03_LIKE),
+		},
+	})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	ownersRes, err := GetOwners(Conn, QueryOwnerRequest{ClassId: nftClasses[0].Id})
+	require.NoError(t, err)
+	require.Len(t, ownersRes.Owners, 1)
+	require.Equal(t, ADDR_03_LIKE, ownersRes.Owners[0].Owner)
+
+	// re-extracting the first send must not roll back the owner changed by the later send
+	err = extractor.Reextract(context.Background(), Conn, 1, 2, []string{extractor.GroupNft})
+	require.NoError(t, err)
+
+	ownersRes, err = GetOwners(Conn, QueryOwnerRequest{ClassId: nftClasses[0].Id})
+	require.NoError(t, err)
+	require.Len(t, ownersRes.Owners, 1)
+	require.Equal(t, ADDR_03_LIKE, ownersRes.Owners[0].Owner)
+}
+
+// sendAuthzGrant grants the API wallet to send the tokens of the buyer, which the incomes of selling NFTs are verified against
+func sendAuthzGrant(buyer, apiWallet string) AuthzGrant {
+	return AuthzGrant{
+		AuthzGrantKey:     AuthzGrantKey{Granter: buyer, Grantee: apiWallet, MsgTypeUrl: "/cosmos.bank.v1beta1.MsgSend"},
+		AuthorizationType: "/cosmos.bank.v1beta1.SendAuthorization",
+		Authorization:     json.RawMessage(`{"@type":"/cosmos.bank.v1beta1.SendAuthorization","spend_limit":[{"denom":"nanolike","amount":"1000"}]}`),
+		SpendLimit:        json.RawMessage(`[{"denom":"nanolike","amount":"1000"}]`),
+		Height:            1,
+		TxHash:            "GRANT",
+		Timestamp:         time.Unix(1234567890, 0).UTC(),
+	}
+}
+
 func TestSendNftWithPrice(t *testing.T) {
 	defer CleanupTestData(Conn)
 	buyer := ADDR_02_LIKE
@@ -226,13 +306,14 @@ func TestSendNftWithPrice(t *testing.T) {
 			nfts[0].NftId, timestamp.Format(time.RFC3339), price, royalty1, royalty2),
 	}
 	InsertTestData(DBTestData{
-		Iscns:      iscns,
-		NftClasses: nftClasses,
-		Nfts:       nfts,
-		Txs:        txs,
+		Iscns:       iscns,
+		NftClasses:  nftClasses,
+		Nfts:        nfts,
+		Txs:         txs,
+		AuthzGrants: []AuthzGrant{sendAuthzGrant(buyer, apiWallet)},
 	})
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -282,6 +363,11 @@ func TestSendNftWithPrice(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, price, lastPrice)
 	require.Equal(t, timestamp.UTC(), priceUpdatedAt.UTC())
+
+	var incomeHeights []int64
+	err = Conn.QueryRow(context.Background(), `SELECT array_agg(height) FROM nft_income WHERE tx_hash = 'AAAAAA'`).Scan(&incomeHeights)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1234, 1234}, incomeHeights)
 }
 func TestSendMultipleNftsWithPrice(t *testing.T) {
 	defer CleanupTestData(Conn)
@@ -346,13 +432,14 @@ func TestSendMultipleNftsWithPrice(t *testing.T) {
 		),
 	}
 	InsertTestData(DBTestData{
-		Iscns:      iscns,
-		NftClasses: nftClasses,
-		Nfts:       nfts,
-		Txs:        txs,
+		Iscns:       iscns,
+		NftClasses:  nftClasses,
+		Nfts:        nfts,
+		Txs:         txs,
+		AuthzGrants: []AuthzGrant{sendAuthzGrant(buyer, apiWallet)},
 	})
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -481,7 +568,7 @@ func TestMintNft(t *testing.T) {
 		Txs:        txs,
 	})
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
@@ -510,6 +597,12 @@ func TestMintNft(t *testing.T) {
 	require.Equal(t, metadata, string(res.Nfts[0].Metadata))
 	require.Equal(t, timestamp, res.Nfts[0].Timestamp)
 	require.Equal(t, nftClasses[0].Id, res.Nfts[0].ClassId)
+	require.Equal(t, int64(1234), res.Nfts[0].Height)
+	require.Equal(t, "AAAAAA", res.Nfts[0].TxHash)
+
+	res, err = GetNfts(Conn, QueryNftRequest{Owner: ADDR_01_LIKE, MinHeight: 1235}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, res.Nfts)
 
 	eventsRes, err := GetNftEvents(Conn,
 		QueryEventsRequest{
@@ -525,6 +618,113 @@ func TestMintNft(t *testing.T) {
 	require.Equal(t, "AAAAAA", eventsRes.Events[0].Memo)
 }
 
+func TestBurnNft(t *testing.T) {
+	defer CleanupTestData(Conn)
+	prefixA := "iscn://testing/aaaaaa"
+	iscns := []IscnInsert{
+		{
+			Iscn:  "iscn://testing/aaaaaa/1",
+			Owner: ADDR_01_LIKE,
+		},
+	}
+	nftClasses := []NftClass{
+		{
+			Id:     "nftlike1aaaaa1",
+			Parent: NftClassParent{IscnIdPrefix: prefixA},
+		},
+	}
+
+	nftId := "testing-nft-199921"
+	timestamp := time.Unix(1234567890, 0).UTC()
+	mintTx := `
+		{"txhash":"%[4]s","height":"%[5]d","tx":{"body":{"memo":"%[4]s","messages":[{"id":"%[1]s","@type":"/likechain.likenft.v1.MsgMintNFT","input":{"uri":"","uri_hash":"","metadata":{}},"creator":"%[2]s","class_id":"%[3]s"}]}},"logs":[{"events":[{"type":"cosmos.nft.v1beta1.EventMint","attributes":[{"key":"id","value":"\"%[1]s\""},{"key":"owner","value":"\"%[2]s\""},{"key":"class_id","value":"\"%[3]s\""}]},{"type":"likechain.likenft.v1.EventMintNFT","attributes":[{"key":"class_id","value":"\"%[3]s\""},{"key":"nft_id","value":"\"%[1]s\""},{"key":"owner","value":"\"%[2]s\""},{"key":"class_parent_iscn_id_prefix","value":"\"%[6]s\""},{"key":"class_parent_account","value":"\"\""}]},{"type":"message","attributes":[{"key":"action","value":"mint_nft"},{"key":"sender","value":"%[2]s"}]}],"msg_index":0}],"timestamp":"%[7]s"}`
+	burnTx := `
+		{"txhash":"%[4]s","height":"%[5]d","tx":{"body":{"memo":"%[4]s","messages":[{"nft_id":"%[1]s","@type":"/likechain.likenft.v1.MsgBurnNFT","creator":"%[2]s","class_id":"%[3]s"}]}},"logs":[{"events":[{"type":"cosmos.nft.v1beta1.EventBurn","attributes":[{"key":"class_id","value":"\"%[3]s\""},{"key":"id","value":"\"%[1]s\""},{"key":"owner","value":"\"%[2]s\""}]},{"type":"likechain.likenft.v1.EventBurnNFT","attributes":[{"key":"class_id","value":"\"%[3]s\""},{"key":"nft_id","value":"\"%[1]s\""},{"key":"owner","value":"\"%[2]s\""}]},{"type":"message","attributes":[{"key":"action","value":"burn_nft"},{"key":"sender","value":"%[2]s"}]}],"msg_index":0}],"timestamp":"%[6]s"}`
+	txs := []string{
+		fmt.Sprintf(mintTx, nftId, ADDR_01_LIKE, nftClasses[0].Id, "AAAAAA", 1234, prefixA, timestamp.Format(time.RFC3339)),
+		fmt.Sprintf(burnTx, nftId, ADDR_01_LIKE, nftClasses[0].Id, "AAAAAB", 1235, timestamp.Format(time.RFC3339)),
+	}
+	InsertTestData(DBTestData{
+		Iscns:      iscns,
+		NftClasses: nftClasses,
+		Txs:        txs,
+		// GetNfts requires event with receiver = owner
+		NftEvents: []NftEvent{
+			{
+				Action:    "dummy",
+				ClassId:   nftClasses[0].Id,
+				NftId:     nftId,
+				Sender:    ADDR_01_LIKE,
+				Receiver:  ADDR_01_LIKE,
+				Timestamp: timestamp,
+			},
+		},
+	})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err := GetNfts(Conn, QueryNftRequest{Owner: ADDR_01_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, res.Nfts)
+
+	res, err = GetNfts(Conn, QueryNftRequest{Owner: ADDR_01_LIKE, IncludeBurned: true}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Nfts, 1)
+	require.Equal(t, nftId, res.Nfts[0].NftId)
+	require.NotNil(t, res.Nfts[0].BurnedHeight)
+	require.Equal(t, int64(1235), *res.Nfts[0].BurnedHeight)
+
+	ownersRes, err := GetOwners(Conn, QueryOwnerRequest{ClassId: nftClasses[0].Id})
+	require.NoError(t, err)
+	require.Empty(t, ownersRes.Owners)
+
+	ownersRes, err = GetOwners(Conn, QueryOwnerRequest{ClassId: nftClasses[0].Id, IncludeBurned: true})
+	require.NoError(t, err)
+	require.Len(t, ownersRes.Owners, 1)
+	require.Equal(t, ADDR_01_LIKE, ownersRes.Owners[0].Owner)
+
+	countRes, err := GetNftCount(Conn, QueryNftCountRequest{IncludeOwner: true})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), countRes.Count)
+
+	countRes, err = GetNftCount(Conn, QueryNftCountRequest{IncludeOwner: true, IncludeBurned: true})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), countRes.Count)
+
+	// both burn events of the same transaction are recorded as one event
+	eventsRes, err := GetNftEvents(Conn,
+		QueryEventsRequest{
+			ClassId:    nftClasses[0].Id,
+			NftId:      nftId,
+			ActionType: []NftEventAction{ACTION_BURN},
+		},
+		PageRequest{Limit: 10},
+	)
+	require.NoError(t, err)
+	require.Len(t, eventsRes.Events, 1)
+	require.Equal(t, ADDR_01_LIKE, eventsRes.Events[0].Sender)
+	require.Equal(t, "AAAAAB", eventsRes.Events[0].TxHash)
+
+	// minting the same NFT ID again after the burn
+	InsertTestData(DBTestData{
+		Txs: []string{
+			fmt.Sprintf(mintTx, nftId, ADDR_01_LIKE, nftClasses[0].Id, "AAAAAC", 1236, prefixA, timestamp.Format(time.RFC3339)),
+		},
+	})
+	finished, err = Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	res, err = GetNfts(Conn, QueryNftRequest{Owner: ADDR_01_LIKE}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Nfts, 1)
+	require.Nil(t, res.Nfts[0].BurnedHeight)
+	require.Equal(t, int64(1236), res.Nfts[0].Height)
+	require.Equal(t, "AAAAAC", res.Nfts[0].TxHash)
+}
+
 func TestNftEventIscnOwner(t *testing.T) {
 	defer CleanupTestData(Conn)
 	prefixA := "iscn://testing/aaaaaa"
@@ -578,7 +778,7 @@ func TestNftEventIscnOwner(t *testing.T) {
 		Txs:        txs,
 	})
 
-	finished, err := Extract(Conn, extractor.ExtractFunc)
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
 	require.NoError(t, err)
 	require.True(t, finished)
 
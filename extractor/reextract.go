@@ -0,0 +1,123 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+// number of heights re-extracted in each database transaction
+var reextractBatchSize = int64(utils.EnvInt("REEXTRACT_BATCH_SIZE", 1000))
+
+// Domains returns the names of the extractor groups, which can be re-extracted separately
+func Domains() []string {
+	return eventExtractor.GroupNames()
+}
+
+func uniqueSorted(strs []string) []string {
+	set := map[string]bool{}
+	res := []string{}
+	for _, s := range strs {
+		if !set[s] {
+			set[s] = true
+			res = append(res, s)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func reextractCursorKey(from, to int64, domains []string) string {
+	return fmt.Sprintf("reextract_%s_%d_%d", strings.Join(domains, ","), from, to)
+}
+
+// Reextract runs the processors of the given domains again on the transactions in heights [from, to],
+// replacing the rows extracted before, e.g. after fixing a bug in the processors.
+// Heights are processed in batches, each committed with its progress in the meta table,
+// so an interrupted run resumes from the last committed batch when started again with the same arguments.
+// Heights not yet reached by the extractor groups are skipped, so it can run alongside the poller and extractor.
+func Reextract(ctx context.Context, conn *pgxpool.Conn, from, to int64, domains []string) error {
+	return eventExtractor.Reextract(ctx, conn, from, to, domains)
+}
+
+// Reextract is the same as the package-level Reextract, with the processors registered in e
+func (e *EventExtractor) Reextract(ctx context.Context, conn *pgxpool.Conn, from, to int64, domains []string) error {
+	domains = uniqueSorted(domains)
+	extractor, err := e.ForGroupNames(domains)
+	if err != nil {
+		return err
+	}
+	key := reextractCursorKey(from, to, domains)
+
+	groups := e.Groups()
+	_, heights, err := db.GetExtractorHeights(conn, groups)
+	if err != nil {
+		return fmt.Errorf("cannot get extractor synchronized height: %w", err)
+	}
+	extractedHeight := to
+	for i, g := range groups {
+		for _, domain := range domains {
+			if g.Name == domain && heights[i] < extractedHeight {
+				extractedHeight = heights[i]
+			}
+		}
+	}
+	if to > extractedHeight {
+		logger.L.Warnw("Heights not yet extracted are skipped", "to", to, "extractor_height", extractedHeight)
+		to = extractedHeight
+	}
+
+	start := from
+	cursor, err := db.GetMetaHeight(conn, key)
+	if err == nil {
+		start = cursor + 1
+		logger.L.Infow("Resuming re-extraction", "key", key, "height", start)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("cannot get re-extraction progress: %w", err)
+	}
+
+	for batchFrom := start; batchFrom <= to; batchFrom += reextractBatchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		batchTo := batchFrom + reextractBatchSize - 1
+		if batchTo > to {
+			batchTo = to
+		}
+		batch := db.NewBatch(conn, int(db.LIMIT))
+		batch.Overwrite = true
+		for _, domain := range domains {
+			if domain == GroupIncome {
+				batch.DeleteNftIncomesInRange(batchFrom, batchTo)
+			}
+		}
+		err = db.ExtractRange(ctx, conn, &batch, batchFrom, batchTo, extractor)
+		if err != nil {
+			return err
+		}
+		batch.UpsertMetaHeight(key, batchTo)
+		err = batch.Flush()
+		if err != nil {
+			return fmt.Errorf("cannot flush re-extraction batch, error = %w, from = %d, to = %d", err, batchFrom, batchTo)
+		}
+		logger.L.Infow("Re-extracted heights", "from", batchFrom, "to", batchTo, "domains", domains)
+	}
+
+	batch := db.NewBatch(conn, 1)
+	batch.DeleteMeta(key)
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot clear re-extraction progress: %w", err)
+	}
+	logger.L.Infow("Re-extraction completed", "from", from, "to", to, "domains", domains)
+	return nil
+}
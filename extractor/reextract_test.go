@@ -0,0 +1,98 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+const testReextractDomain = "test_reextract"
+
+// version of the processors, which is written to the name of the class to tell which run inserted it
+var testReextractVersion = "v1"
+
+func testReextractProcessor(payload *Payload, event *types.StringEvent) error {
+	payload.Batch.InsertNftClass(NftClass{
+		Id:   utils.GetEventValue(event, "class_id"),
+		Name: testReextractVersion,
+	})
+	return nil
+}
+
+func testReextractOtherProcessor(payload *Payload, event *types.StringEvent) error {
+	payload.Batch.InsertNftClass(NftClass{
+		Id:   "other-" + utils.GetEventValue(event, "class_id"),
+		Name: testReextractVersion,
+	})
+	return nil
+}
+
+// newTestReextractExtractor returns an extractor with the test groups only, so they are not registered to the other tests
+func newTestReextractExtractor() *EventExtractor {
+	e := NewEventExtractor()
+	e.Group(testReextractDomain, 1).RegisterType("test.EventReextract", testReextractProcessor)
+	e.Group("test_reextract_other", 1).RegisterType("test.EventReextract", testReextractOtherProcessor)
+	return e
+}
+
+func getNftClassName(t *testing.T, classId string) string {
+	var name string
+	err := Conn.QueryRow(context.Background(), `SELECT name FROM nft_class WHERE class_id = $1`, classId).Scan(&name)
+	require.NoError(t, err)
+	return name
+}
+
+func TestReextract(t *testing.T) {
+	defer CleanupTestData(Conn)
+	defer func() { testReextractVersion = "v1" }()
+	txs := []string{}
+	for height := 1; height <= 4; height++ {
+		txs = append(txs, fmt.Sprintf(
+			`{"height":"%[1]d","txhash":"REEXTRACT%[1]d","tx":{"body":{"messages":[{"@type":"/test.Msg"}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"test.EventReextract","attributes":[{"key":"class_id","value":"class-%[1]d"}]}]}],"timestamp":"2022-01-01T00:00:00Z"}`,
+			height,
+		))
+	}
+	InsertTestData(DBTestData{Txs: txs})
+	e := newTestReextractExtractor()
+
+	finished, err := Extract(context.Background(), Conn, e)
+	require.NoError(t, err)
+	require.True(t, finished)
+	for height := 1; height <= 4; height++ {
+		require.Equal(t, "v1", getNftClassName(t, fmt.Sprintf("class-%d", height)))
+	}
+
+	_, err = e.ForGroupNames([]string{"not_exist"})
+	require.Error(t, err)
+
+	testReextractVersion = "v2"
+	err = e.Reextract(context.Background(), Conn, 1, 2, []string{testReextractDomain})
+	require.NoError(t, err)
+	require.Equal(t, "v2", getNftClassName(t, "class-1"))
+	require.Equal(t, "v2", getNftClassName(t, "class-2"))
+	require.Equal(t, "v1", getNftClassName(t, "class-3"))
+	// processors of other domains are not run
+	require.Equal(t, "v1", getNftClassName(t, "other-class-1"))
+	_, err = GetMetaHeight(Conn, reextractCursorKey(1, 2, []string{testReextractDomain}))
+	require.Error(t, err, "progress should be cleared after completion")
+
+	// resume after height 2, and skip height 4 which is not yet extracted
+	testReextractVersion = "v3"
+	batch := NewBatch(Conn, 1)
+	batch.UpsertMetaHeight(reextractCursorKey(1, 4, []string{testReextractDomain}), 2)
+	batch.UpsertMetaHeight(ExtractorGroup{Name: testReextractDomain, Version: 1}.MetaKey(), 3)
+	require.NoError(t, batch.Flush())
+	err = e.Reextract(context.Background(), Conn, 1, 4, []string{testReextractDomain})
+	require.NoError(t, err)
+	require.Equal(t, "v2", getNftClassName(t, "class-1"))
+	require.Equal(t, "v2", getNftClassName(t, "class-2"))
+	require.Equal(t, "v3", getNftClassName(t, "class-3"))
+	require.Equal(t, "v1", getNftClassName(t, "class-4"))
+}
@@ -0,0 +1,57 @@
+package extractor
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+// RetryExtractFailures runs the failed processors again for the unresolved failures with the given ids,
+// or for all unresolved failures if ids is empty.
+// Each failure is retried in its own batch, rows queued by a processor failing again are discarded.
+func RetryExtractFailures(conn *pgxpool.Conn, ids []int64) (db.RetryExtractFailuresResponse, error) {
+	return eventExtractor.RetryExtractFailures(conn, ids)
+}
+
+// RetryExtractFailures is the same as the package-level RetryExtractFailures, with the processors registered in e
+func (e *EventExtractor) RetryExtractFailures(conn *pgxpool.Conn, ids []int64) (db.RetryExtractFailuresResponse, error) {
+	res := db.RetryExtractFailuresResponse{
+		Resolved: []int64{},
+		Failed:   []int64{},
+	}
+	failures, err := db.GetUnresolvedExtractFailures(conn, ids)
+	if err != nil {
+		return res, err
+	}
+	for _, failure := range failures {
+		batch := db.NewBatch(conn, int(db.LIMIT))
+		eventCtx, err := db.GetEventContextOfTx(conn, &batch, failure.Height, failure.TxHash)
+		if err != nil {
+			err = fmt.Errorf("cannot get transaction: %w", err)
+		} else {
+			err = e.Retry(eventCtx, failure)
+		}
+		if err == nil {
+			batch.ResolveExtractFailure(failure.Id)
+			// the extracted rows may still be rejected by the database
+			err = batch.Flush()
+		}
+		if err != nil {
+			logger.L.Warnw("Retry extract failure failed", "id", failure.Id, "txhash", failure.TxHash, "error", err)
+			batch = db.NewBatch(conn, int(db.LIMIT))
+			batch.UpdateExtractFailureError(failure.Id, err.Error())
+			err = batch.Flush()
+			if err != nil {
+				return res, fmt.Errorf("cannot update extract failure %d: %w", failure.Id, err)
+			}
+			res.Failed = append(res.Failed, failure.Id)
+			continue
+		}
+		logger.L.Infow("Extract failure resolved", "id", failure.Id, "txhash", failure.TxHash)
+		res.Resolved = append(res.Resolved, failure.Id)
+	}
+	return res, nil
+}
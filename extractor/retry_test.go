@@ -0,0 +1,100 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+var testRetryShouldFail = true
+
+var errTestRetry = errors.New("test retry error")
+
+func testRetryFailingProcessor(payload *Payload, event *types.StringEvent) error {
+	if testRetryShouldFail {
+		return errTestRetry
+	}
+	payload.Batch.InsertNftClass(NftClass{Id: utils.GetEventValue(event, "class_id")})
+	return nil
+}
+
+func testRetryProcessor(payload *Payload, event *types.StringEvent) error {
+	payload.Batch.InsertNftClass(NftClass{Id: utils.GetEventValue(event, "class_id")})
+	return nil
+}
+
+// newTestRetryExtractor returns an extractor with the test group only, so it is not registered to the other tests
+func newTestRetryExtractor() *EventExtractor {
+	e := NewEventExtractor()
+	group := e.Group("test_retry", 1)
+	group.RegisterType("test.EventRetryFailing", testRetryFailingProcessor)
+	group.RegisterType("test.EventRetryOk", testRetryProcessor)
+	return e
+}
+
+func countNftClass(t *testing.T, classId string) int {
+	var count int
+	err := Conn.QueryRow(context.Background(), `SELECT count(*) FROM nft_class WHERE class_id = $1`, classId).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestRetryExtractFailures(t *testing.T) {
+	defer CleanupTestData(Conn)
+	defer func() { testRetryShouldFail = true }()
+	txs := []string{
+		`{"height":"1234","txhash":"RETRY","tx":{"body":{"messages":[{"@type":"/test.Msg"},{"@type":"/test.Msg"}],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[{"type":"test.EventRetryFailing","attributes":[{"key":"class_id","value":"retry-class-a"}]}]},{"msg_index":1,"log":"","events":[{"type":"test.EventRetryOk","attributes":[{"key":"class_id","value":"retry-class-b"}]}]}],"timestamp":"2022-01-01T00:00:00Z"}`,
+	}
+	InsertTestData(DBTestData{Txs: txs})
+	e := newTestRetryExtractor()
+
+	finished, err := Extract(context.Background(), Conn, e)
+	require.NoError(t, err)
+	require.True(t, finished)
+	// failure of one processor does not stop the others
+	require.Equal(t, 0, countNftClass(t, "retry-class-a"))
+	require.Equal(t, 1, countNftClass(t, "retry-class-b"))
+
+	res, err := GetExtractFailures(Conn, QueryExtractFailuresRequest{}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Failures, 1)
+	failure := res.Failures[0]
+	require.Equal(t, int64(1234), failure.Height)
+	require.Equal(t, "RETRY", failure.TxHash)
+	require.Equal(t, 0, failure.MsgIndex)
+	require.Equal(t, "test.EventRetryFailing", failure.EventType)
+	require.Equal(t, "extractor.testRetryFailingProcessor", failure.Processor)
+	require.Equal(t, "test retry error", failure.Error)
+	require.Nil(t, failure.ResolvedAt)
+
+	retryRes, err := e.RetryExtractFailures(Conn, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int64{failure.Id}, retryRes.Failed)
+	require.Empty(t, retryRes.Resolved)
+
+	testRetryShouldFail = false
+	retryRes, err = e.RetryExtractFailures(Conn, []int64{failure.Id})
+	require.NoError(t, err)
+	require.Equal(t, []int64{failure.Id}, retryRes.Resolved)
+	require.Empty(t, retryRes.Failed)
+	require.Equal(t, 1, countNftClass(t, "retry-class-a"))
+	// only the failed processor is run again
+	require.Equal(t, 1, countNftClass(t, "retry-class-b"))
+
+	res, err = GetExtractFailures(Conn, QueryExtractFailuresRequest{}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, res.Failures)
+
+	res, err = GetExtractFailures(Conn, QueryExtractFailuresRequest{Resolved: true}, PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Failures, 1)
+	require.Equal(t, 2, res.Failures[0].RetryCount)
+	require.NotNil(t, res.Failures[0].ResolvedAt)
+}
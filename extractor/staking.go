@@ -0,0 +1,119 @@
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+type stakingMessage struct {
+	DelegatorAddress    string   `json:"delegator_address"`
+	ValidatorAddress    string   `json:"validator_address"`
+	ValidatorSrcAddress string   `json:"validator_src_address"`
+	ValidatorDstAddress string   `json:"validator_dst_address"`
+	Amount              bankCoin `json:"amount"`
+}
+
+func newStakingEvent(payload *Payload, action db.StakingEventAction, delegator, validator string) db.StakingEvent {
+	return db.StakingEvent{
+		Action:    action,
+		Delegator: delegator,
+		Validator: validator,
+		Height:    payload.Height,
+		TxHash:    payload.TxHash,
+		MsgIndex:  payload.TxMsgIndex(),
+		Timestamp: payload.Timestamp,
+	}
+}
+
+func parseStakingMessage(payload *Payload) (stakingMessage, error) {
+	var message stakingMessage
+	if err := json.Unmarshal(payload.GetMessage(), &message); err != nil {
+		return message, fmt.Errorf("failed to unmarshal staking message: %w", err)
+	}
+	return message, nil
+}
+
+func parseCompletionTime(event *types.StringEvent) (*time.Time, error) {
+	completionTime, err := time.Parse(time.RFC3339, utils.GetEventValue(event, "completion_time"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse completion time: %w", err)
+	}
+	return &completionTime, nil
+}
+
+func stakingProcessor(action db.StakingEventAction) EventProcessor {
+	return func(payload *Payload, event *types.StringEvent) error {
+		message, err := parseStakingMessage(payload)
+		if err != nil {
+			return err
+		}
+		validator := message.ValidatorAddress
+		if action == db.STAKING_REDELEGATE {
+			validator = message.ValidatorSrcAddress
+		}
+		e := newStakingEvent(payload, action, message.DelegatorAddress, validator)
+		e.Denom = message.Amount.Denom
+		e.Amount = message.Amount.Amount
+		switch action {
+		case db.STAKING_REDELEGATE:
+			e.DstValidator = message.ValidatorDstAddress
+			e.CompletionTime, err = parseCompletionTime(event)
+		case db.STAKING_UNDELEGATE:
+			e.CompletionTime, err = parseCompletionTime(event)
+		}
+		if err != nil {
+			return err
+		}
+		payload.Batch.InsertStakingEvent(e)
+		return nil
+	}
+}
+
+// withdrawDelegatorReward records the rewards withdrawn by MsgWithdrawDelegatorReward,
+// and those withdrawn automatically when the delegation changes
+func withdrawDelegatorReward(payload *Payload, event *types.StringEvent) error {
+	message, err := parseStakingMessage(payload)
+	if err != nil {
+		return err
+	}
+	if message.DelegatorAddress == "" {
+		return nil
+	}
+	// redelegation withdraws from both validators, and the events of the same type are merged into one,
+	// so the attributes are pairs of amount (empty if there is no reward) and validator
+	amount := ""
+	for _, attr := range event.Attributes {
+		switch attr.Key {
+		case "amount":
+			amount = attr.Value
+		case "validator":
+			rewards, err := types.ParseCoinsNormalized(amount)
+			if err != nil {
+				return fmt.Errorf("failed to parse rewards: %w", err)
+			}
+			for _, reward := range rewards {
+				e := newStakingEvent(payload, db.STAKING_WITHDRAW_REWARD, message.DelegatorAddress, attr.Value)
+				e.Denom = reward.Denom
+				e.Amount = reward.Amount.String()
+				payload.Batch.InsertStakingEvent(e)
+			}
+			amount = ""
+		}
+	}
+	return nil
+}
+
+func init() {
+	// the events are emitted once for each message, the messages provide the delegator and the amount
+	stakingGroup.RegisterType("delegate", stakingProcessor(db.STAKING_DELEGATE))
+	stakingGroup.RegisterType("unbond", stakingProcessor(db.STAKING_UNDELEGATE))
+	stakingGroup.RegisterType("redelegate", stakingProcessor(db.STAKING_REDELEGATE))
+	stakingGroup.RegisterType("cancel_unbonding_delegation", stakingProcessor(db.STAKING_CANCEL_UNBONDING))
+	stakingGroup.RegisterType("withdraw_rewards", withdrawDelegatorReward)
+}
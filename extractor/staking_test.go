@@ -0,0 +1,96 @@
+package extractor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+func TestStaking(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	completionTime := timestamp.Add(21 * 24 * time.Hour)
+	validator1, err := utils.ConvertAddressPrefix(ADDR_01_LIKE, "likevaloper")
+	require.NoError(t, err)
+	validator2, err := utils.ConvertAddressPrefix(ADDR_02_LIKE, "likevaloper")
+	require.NoError(t, err)
+	stakingTx := func(height int, msg string, events string) string {
+		return fmt.Sprintf(
+			`{"height":"%[1]d","txhash":"STAKING%[1]d","tx":{"body":{"messages":[%[2]s],"memo":""}},"logs":[{"msg_index":0,"log":"","events":[%[3]s]}],"timestamp":"%[4]s"}`,
+			height, msg, events, timestamp.Format(time.RFC3339),
+		)
+	}
+	txs := []string{
+		stakingTx(1,
+			fmt.Sprintf(`{"@type":"/cosmos.staking.v1beta1.MsgDelegate","delegator_address":"%s","validator_address":"%s","amount":{"denom":"nanolike","amount":"100"}}`, ADDR_03_LIKE, validator1),
+			fmt.Sprintf(`{"type":"delegate","attributes":[{"key":"validator","value":"%s"},{"key":"amount","value":"100nanolike"},{"key":"new_shares","value":"100.000000000000000000"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.staking.v1beta1.MsgDelegate"}]}`, validator1),
+		),
+		// delegator with another prefix, with rewards withdrawn automatically
+		stakingTx(2,
+			fmt.Sprintf(`{"@type":"/cosmos.staking.v1beta1.MsgDelegate","delegator_address":"%s","validator_address":"%s","amount":{"denom":"nanolike","amount":"50"}}`, ADDR_03_COSMOS, validator1),
+			fmt.Sprintf(`{"type":"delegate","attributes":[{"key":"validator","value":"%[1]s"},{"key":"amount","value":"50nanolike"},{"key":"new_shares","value":"50.000000000000000000"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"3nanolike"},{"key":"validator","value":"%[1]s"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.staking.v1beta1.MsgDelegate"}]}`, validator1),
+		),
+		stakingTx(3,
+			fmt.Sprintf(`{"@type":"/cosmos.staking.v1beta1.MsgBeginRedelegate","delegator_address":"%s","validator_src_address":"%s","validator_dst_address":"%s","amount":{"denom":"nanolike","amount":"30"}}`, ADDR_03_LIKE, validator1, validator2),
+			fmt.Sprintf(`{"type":"redelegate","attributes":[{"key":"source_validator","value":"%[1]s"},{"key":"destination_validator","value":"%[2]s"},{"key":"amount","value":"30nanolike"},{"key":"completion_time","value":"%[3]s"}]},{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"1nanolike"},{"key":"validator","value":"%[1]s"},{"key":"amount","value":""},{"key":"validator","value":"%[2]s"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.staking.v1beta1.MsgBeginRedelegate"}]}`, validator1, validator2, completionTime.Format(time.RFC3339)),
+		),
+		stakingTx(4,
+			fmt.Sprintf(`{"@type":"/cosmos.staking.v1beta1.MsgUndelegate","delegator_address":"%s","validator_address":"%s","amount":{"denom":"nanolike","amount":"20"}}`, ADDR_03_LIKE, validator2),
+			fmt.Sprintf(`{"type":"unbond","attributes":[{"key":"validator","value":"%[1]s"},{"key":"amount","value":"20nanolike"},{"key":"completion_time","value":"%[2]s"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.staking.v1beta1.MsgUndelegate"}]}`, validator2, completionTime.Format(time.RFC3339)),
+		),
+		// reward withdrawn through authz
+		stakingTx(5,
+			fmt.Sprintf(`{"@type":"/cosmos.authz.v1beta1.MsgExec","grantee":"%s","msgs":[{"@type":"/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward","delegator_address":"%s","validator_address":"%s"}]}`, ADDR_01_LIKE, ADDR_03_LIKE, validator1),
+			fmt.Sprintf(`{"type":"withdraw_rewards","attributes":[{"key":"amount","value":"5nanolike"},{"key":"validator","value":"%s"},{"key":"authz_msg_index","value":"0"}]},{"type":"message","attributes":[{"key":"action","value":"/cosmos.authz.v1beta1.MsgExec"}]}`, validator1),
+		),
+	}
+	InsertTestData(DBTestData{Txs: txs})
+
+	finished, err := Extract(context.Background(), Conn, extractor.Extractors)
+	require.NoError(t, err)
+	require.True(t, finished)
+
+	check := func() {
+		res, err := GetStakingEvents(Conn, QueryStakingEventsRequest{Delegator: ADDR_03_LIKE}, PageRequest{Limit: 10})
+		require.NoError(t, err)
+		actions := []string{}
+		for _, e := range res.Events {
+			require.Equal(t, ADDR_03_LIKE, e.Delegator)
+			require.Equal(t, "nanolike", e.Denom)
+			actions = append(actions, fmt.Sprintf("%d %s %s %s", e.Height, e.Action, e.Validator, e.Amount))
+		}
+		require.Equal(t, []string{
+			"1 delegate " + validator1 + " 100",
+			"2 delegate " + validator1 + " 50",
+			"2 withdraw_reward " + validator1 + " 3",
+			"3 redelegate " + validator1 + " 30",
+			"3 withdraw_reward " + validator1 + " 1",
+			"4 undelegate " + validator2 + " 20",
+			"5 withdraw_reward " + validator1 + " 5",
+		}, actions)
+		require.Equal(t, validator2, res.Events[3].DstValidator)
+		require.NotNil(t, res.Events[3].CompletionTime)
+		require.Equal(t, completionTime, *res.Events[3].CompletionTime)
+
+		delegations, err := GetDelegations(Conn, QueryDelegationsRequest{Delegator: ADDR_03_COSMOS}, PageRequest{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, []Delegation{
+			{Delegator: ADDR_03_LIKE, Validator: validator1, NetAmount: "120", Height: 3},
+			{Delegator: ADDR_03_LIKE, Validator: validator2, NetAmount: "10", Height: 4},
+		}, delegations.Delegations)
+	}
+	check()
+
+	// delegations are not counted twice when the events are extracted again
+	err = extractor.Reextract(context.Background(), Conn, 1, 5, []string{extractor.GroupStaking})
+	require.NoError(t, err)
+	check()
+}
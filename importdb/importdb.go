@@ -49,6 +49,16 @@ func Run(pool *pgxpool.Pool, likedPath string) {
 	for height := startHeight; height < maxHeight; height++ {
 		block := blockStore.LoadBlock(height)
 		txs := block.Data.Txs
+		err = batch.InsertBlock(db.Block{
+			Height:          height,
+			Hash:            block.Hash().String(),
+			ProposerAddress: block.Header.ProposerAddress.String(),
+			Time:            block.Header.Time,
+			TxCount:         len(txs),
+		})
+		if err != nil {
+			logger.L.Panicw("Cannot insert block", "height", height, "error", err)
+		}
 		for txIndex, tx := range txs {
 			txHash := bytes.HexBytes(tx.Hash())
 			txResult, err := txIndexer.Get(txHash)
@@ -24,6 +24,7 @@ func newResponseResultTx(txHash bytes.HexBytes, res *abci.TxResult, tx *codecTyp
 	return sdk.TxResponse{
 		TxHash:    txHash.String(),
 		Height:    res.Height,
+		Codespace: res.Result.Codespace,
 		Code:      res.Result.Code,
 		Data:      strings.ToUpper(hex.EncodeToString(res.Result.Data)),
 		RawLog:    res.Result.Log,
@@ -33,6 +34,7 @@ func newResponseResultTx(txHash bytes.HexBytes, res *abci.TxResult, tx *codecTyp
 		GasUsed:   res.Result.GasUsed,
 		Tx:        tx,
 		Timestamp: timestamp,
+		Events:    res.Result.Events,
 	}, nil
 }
 
@@ -54,10 +56,16 @@ func parseTx(txBytes []byte) (*codecTypes.Any, error) {
 }
 
 func formatTxResult(txHash bytes.HexBytes, resTx *abci.TxResult, block *types.Block) (sdk.TxResponse, error) {
+	return FormatTxResult(txHash, resTx, block.Header.Time)
+}
+
+// FormatTxResult builds the TxResponse from raw transaction and its execution result in the same way as LCD does
+// (sdk.NewResponseResultTx with the block time in RFC3339), so the transactions imported from different sources are stored identically
+func FormatTxResult(txHash bytes.HexBytes, resTx *abci.TxResult, blockTime time.Time) (sdk.TxResponse, error) {
 	tx, err := parseTx(resTx.Tx)
 	if err != nil {
 		return sdk.TxResponse{}, err
 	}
 
-	return newResponseResultTx(txHash, resTx, tx, block.Header.Time.Format(time.RFC3339))
+	return newResponseResultTx(txHash, resTx, tx, blockTime.UTC().Format(time.RFC3339))
 }
@@ -0,0 +1,193 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+var gapCheckChunkSize = int64(utils.EnvInt("GAP_CHECK_CHUNK_SIZE", 10000))
+
+// Gap is a height whose transactions are not fully indexed
+type Gap struct {
+	Height int64
+	// number of transactions in the block
+	Expected int
+	// number of transactions in the txs table
+	Indexed int
+}
+
+// FindGaps finds heights in [from, to] with missing transactions.
+// The transaction count of each height is taken from the blocks table, or from the source if the block header is not indexed.
+func FindGaps(ctx context.Context, pool *pgxpool.Pool, source BlockSource, from, to int64) ([]Gap, error) {
+	conn, err := db.AcquireFromPool(pool)
+	if err != nil {
+		return nil, fmt.Errorf("cannot acquire connection from database connection pool: %w", err)
+	}
+	defer conn.Release()
+
+	gaps := []Gap{}
+	for chunkFrom := from; chunkFrom <= to; chunkFrom += gapCheckChunkSize {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		chunkTo := chunkFrom + gapCheckChunkSize - 1
+		if chunkTo > to {
+			chunkTo = to
+		}
+		logger.L.Infow("Checking gaps", "from", chunkFrom, "to", chunkTo)
+		heights, err := db.GetUnverifiedHeights(conn, chunkFrom, chunkTo)
+		if err != nil {
+			return nil, err
+		}
+		chunkGaps, err := checkUnverifiedHeights(source, heights)
+		if err != nil {
+			return nil, err
+		}
+		gaps = append(gaps, chunkGaps...)
+	}
+	return gaps, nil
+}
+
+// checkUnverifiedHeights compares the transaction counts of the heights against their blocks,
+// which are fetched from the source with the bounded concurrency of the fetcher if not indexed
+func checkUnverifiedHeights(source BlockSource, heights []db.HeightTxCount) ([]Gap, error) {
+	expected := make([]int, len(heights))
+	fetchIndices := []int{}
+	fetchHeights := []int64{}
+	for i, h := range heights {
+		if h.BlockTxCount != nil {
+			expected[i] = *h.BlockTxCount
+			continue
+		}
+		fetchIndices = append(fetchIndices, i)
+		fetchHeights = append(fetchHeights, h.Height)
+	}
+	f := newFetcher(source, fetchConcurrency)
+	defer f.Stop()
+	for j, res := range f.FetchBlockList(fetchHeights) {
+		<-res.done
+		if res.err != nil {
+			return nil, fmt.Errorf("cannot get block, error = %w, height = %d", res.err, res.height)
+		}
+		expected[fetchIndices[j]] = len(res.block.Block.Data.Txs)
+	}
+	gaps := []Gap{}
+	for i, h := range heights {
+		if expected[i] != h.TxCount {
+			gaps = append(gaps, Gap{
+				Height:   h.Height,
+				Expected: expected[i],
+				Indexed:  h.TxCount,
+			})
+		}
+	}
+	return gaps, nil
+}
+
+// Backfill refetches the heights in [from, to] with missing transactions, and inserts the missing blocks and transactions.
+// Inserted transactions are extracted in the same database batch by the extractor groups which have passed their heights,
+// the other groups extract them later. The batch writes skip rows already changed by later transactions, so older heights extracted out of order do not roll them back.
+// When ctx is done, heights already backfilled are kept and the remaining ones are skipped.
+func Backfill(ctx context.Context, pool *pgxpool.Pool, source BlockSource, from, to int64, extractor db.GroupedExtractor) error {
+	gaps, err := FindGaps(ctx, pool, source, from, to)
+	if err != nil {
+		return err
+	}
+	if len(gaps) == 0 {
+		logger.L.Infow("No gap found", "from", from, "to", to)
+		return nil
+	}
+	heights := make([]int64, len(gaps))
+	for i, gap := range gaps {
+		heights[i] = gap.Height
+	}
+
+	conn, err := db.AcquireFromPool(pool)
+	if err != nil {
+		return fmt.Errorf("cannot acquire connection from database connection pool: %w", err)
+	}
+	defer conn.Release()
+
+	for i := 0; i < len(heights); i += int(batchMaxHeightDiff) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		end := i + int(batchMaxHeightDiff)
+		if end > len(heights) {
+			end = len(heights)
+		}
+		err = backfillHeights(conn, source, heights[i:end], extractor)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func backfillHeights(conn *pgxpool.Conn, source BlockSource, heights []int64, extractor db.GroupedExtractor) error {
+	from := heights[0]
+	to := heights[len(heights)-1]
+	groups := extractor.Groups()
+	_, extractedHeights, err := db.GetExtractorHeights(conn, groups)
+	if err != nil {
+		return fmt.Errorf("cannot get extractor synchronized height: %w", err)
+	}
+	existing, err := db.GetTxIndices(conn, from, to)
+	if err != nil {
+		return err
+	}
+
+	// the batch is only flushed between heights, so transactions and their extracted data are committed together
+	batch := db.NewBatch(conn, batchSize)
+	f := newFetcher(source, fetchConcurrency)
+	defer f.Stop()
+	for _, res := range f.FetchHeightList(heights) {
+		<-res.done
+		if res.err != nil {
+			return fmt.Errorf("cannot fetch height, error = %w, height = %d", res.err, res.height)
+		}
+		block, err := res.block.toDbBlock()
+		if err != nil {
+			return fmt.Errorf("cannot parse block, error = %w, height = %d", err, res.height)
+		}
+		err = batch.InsertBlock(block)
+		if err != nil {
+			return fmt.Errorf("cannot insert block, error = %w, height = %d", err, res.height)
+		}
+		for txIndex := range res.txResponses {
+			if existing[res.height][txIndex] {
+				continue
+			}
+			txRes := &res.txResponses[txIndex]
+			logger.L.Infow("Backfilling transaction", "txhash", txRes.TxHash, "height", res.height, "index", txIndex)
+			err = batch.InsertTx(*txRes, res.height, txIndex)
+			if err != nil {
+				return fmt.Errorf("cannot insert transaction, error = %w, txhash = %s, height = %d, index = %d", err, txRes.TxHash, res.height, txIndex)
+			}
+			extractedGroups := db.GroupsExtracted(groups, extractedHeights, res.height)
+			if len(extractedGroups) == 0 {
+				continue
+			}
+			eventCtx, err := db.NewEventContextFromTxResponse(&batch, txRes)
+			if err != nil {
+				return err
+			}
+			err = extractor.ForGroups(extractedGroups)(eventCtx)
+			if err != nil {
+				db.HandleExtractError(eventCtx, err)
+			}
+		}
+	}
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot flush backfill batch, error = %w, from = %d, to = %d", err, from, to)
+	}
+	logger.L.Infow("Backfilled heights", "from", from, "to", to, "count", len(heights))
+	return nil
+}
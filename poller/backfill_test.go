@@ -0,0 +1,70 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestBackfill(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blockTime := time.Unix(1600000000, 0).UTC()
+	source := newFakeSource(blockTime, 2, 0, 3, 1)
+
+	height, err := poll(context.Background(), Pool, source, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), height)
+
+	gaps, err := FindGaps(context.Background(), Pool, source, 1, 4)
+	require.NoError(t, err)
+	require.Empty(t, gaps)
+
+	// height 1 has no block header, so its transaction count is taken from the source
+	_, err = Conn.Exec(context.Background(), `DELETE FROM txs WHERE height = 1`)
+	require.NoError(t, err)
+	_, err = Conn.Exec(context.Background(), `DELETE FROM blocks WHERE height = 1`)
+	require.NoError(t, err)
+	_, err = Conn.Exec(context.Background(), `DELETE FROM txs WHERE height = 3 AND tx_index = 1`)
+	require.NoError(t, err)
+
+	batch := db.NewBatch(Conn, 100)
+	batch.UpdateExtractorHeight(testGroups, 2)
+	require.NoError(t, batch.Flush())
+
+	gaps, err = FindGaps(context.Background(), Pool, source, 1, 4)
+	require.NoError(t, err)
+	require.Equal(t, []Gap{
+		{Height: 1, Expected: 2, Indexed: 0},
+		{Height: 3, Expected: 3, Indexed: 2},
+	}, gaps)
+
+	extracted := []string{}
+	extractor := testExtractor(func(ctx db.EventContext) error {
+		extracted = append(extracted, ctx.TxHash)
+		return nil
+	})
+	err = Backfill(context.Background(), Pool, source, 1, 4, extractor)
+	require.NoError(t, err)
+	require.Equal(t, []string{"TX_1_0", "TX_1_1", "TX_3_0", "TX_3_1", "TX_3_2", "TX_4_0"}, queryTxHashes(t))
+	// height 3 is not yet processed by the extractor, so it is left to the extractor
+	require.Equal(t, []string{"TX_1_0", "TX_1_1"}, extracted)
+
+	block, err := db.GetBlockByHeight(Conn, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, block.TxCount)
+
+	gaps, err = FindGaps(context.Background(), Pool, source, 1, 4)
+	require.NoError(t, err)
+	require.Empty(t, gaps)
+
+	source.failHeights[1] = true
+	_, err = Conn.Exec(context.Background(), `DELETE FROM txs WHERE height = 1`)
+	require.NoError(t, err)
+	err = Backfill(context.Background(), Pool, source, 1, 4, extractor)
+	require.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package poller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	txTypes "github.com/cosmos/cosmos-sdk/types/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	coreTypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcTypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/endpoints"
+	"github.com/likecoin/likecoin-chain-tx-indexer/importdb"
+)
+
+var errBlockFetchUnsupported = errors.New("fetching whole block is not supported by the node")
+
+func isUnsupportedStatus(err error) bool {
+	var statusErr *endpoints.StatusCodeError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusNotImplemented
+}
+
+func GetBlockWithTxs(ctx *CosmosCallContext, height int64) (*txTypes.GetBlockWithTxsResponse, error) {
+	body, err := ctx.Lcd.Get(fmt.Sprintf("/cosmos/tx/v1beta1/txs/block/%d", height))
+	if err != nil {
+		if isUnsupportedStatus(err) {
+			return nil, fmt.Errorf("%w: %s", errBlockFetchUnsupported, err.Error())
+		}
+		return nil, err
+	}
+	res := txTypes.GetBlockWithTxsResponse{}
+	err = encodingConfig.Marshaler.UnmarshalJSON(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.Block == nil {
+		return nil, fmt.Errorf("empty block in response, height = %d", height)
+	}
+	return &res, nil
+}
+
+func getRpcResult(client *http.Client, url string, result interface{}) error {
+	body, err := getResponse(client, url)
+	if err != nil {
+		return err
+	}
+	rpcRes := rpcTypes.RPCResponse{}
+	err = json.Unmarshal(body, &rpcRes)
+	if err != nil {
+		return err
+	}
+	if rpcRes.Error != nil {
+		return rpcRes.Error
+	}
+	return tmjson.Unmarshal(rpcRes.Result, result)
+}
+
+func GetBlockResults(ctx *CosmosCallContext, height int64) (*coreTypes.ResultBlockResults, error) {
+	url := fmt.Sprintf("%s/block_results?height=%d", ctx.RpcEndpoint, height)
+	res := coreTypes.ResultBlockResults{}
+	err := getRpcResult(ctx.Client, url, &res)
+	if err != nil {
+		if isUnsupportedStatus(err) {
+			return nil, fmt.Errorf("%w: %s", errBlockFetchUnsupported, err.Error())
+		}
+		return nil, err
+	}
+	return &res, nil
+}
+
+// FormatBlockTxResponses builds TxResponses from the raw transactions of a block and their execution results
+func FormatBlockTxResponses(height int64, blockTime time.Time, txs tmTypes.Txs, results []*abci.ResponseDeliverTx) ([]types.TxResponse, error) {
+	if len(txs) != len(results) {
+		return nil, fmt.Errorf("block has %d txs but %d tx results, height = %d", len(txs), len(results), height)
+	}
+	txResponses := make([]types.TxResponse, len(txs))
+	for txIndex, tx := range txs {
+		if results[txIndex] == nil {
+			return nil, fmt.Errorf("empty tx result, height = %d, index = %d", height, txIndex)
+		}
+		txResult := abci.TxResult{
+			Height: height,
+			Index:  uint32(txIndex),
+			Tx:     tx,
+			Result: *results[txIndex],
+		}
+		txRes, err := importdb.FormatTxResult(tx.Hash(), &txResult, blockTime)
+		if err != nil {
+			return nil, fmt.Errorf("cannot format tx result, error = %w, height = %d, index = %d", err, height, txIndex)
+		}
+		txResponses[txIndex] = txRes
+	}
+	return txResponses, nil
+}
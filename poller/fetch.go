@@ -0,0 +1,134 @@
+package poller
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+type heightResult struct {
+	height      int64
+	block       *BlockResult
+	txResponses []types.TxResponse
+	err         error
+	// closed when the fields above are ready to be read
+	done chan struct{}
+}
+
+// fetcher fetches blocks and transactions from the source with a bounded number of heights in flight.
+// Results are exposed in height order, so the consumer can insert them in (height, tx_index) order
+// regardless of which request finishes first.
+type fetcher struct {
+	source      BlockSource
+	concurrency int
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+func newFetcher(source BlockSource, concurrency int) *fetcher {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &fetcher{
+		source:      source,
+		concurrency: concurrency,
+		stop:        make(chan struct{}),
+	}
+}
+
+// Stop prevents new heights from being fetched, heights already in flight are not interrupted
+func (f *fetcher) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stop)
+	})
+}
+
+// FetchHeights starts fetching heights from `from` to `to` (inclusive), and returns the results in height order.
+// The caller should wait on `done` of each result before reading it.
+func (f *fetcher) FetchHeights(from, to int64) []*heightResult {
+	if to < from {
+		return nil
+	}
+	heights := make([]int64, to-from+1)
+	for i := range heights {
+		heights[i] = from + int64(i)
+	}
+	return f.FetchHeightList(heights)
+}
+
+// FetchHeightList is the same as FetchHeights, but fetches the given heights only, e.g. for filling gaps
+func (f *fetcher) FetchHeightList(heights []int64) []*heightResult {
+	return f.fetchList(heights, f.fetchHeight)
+}
+
+// FetchBlockList is the same as FetchHeightList, but fetches the blocks only without their transactions, e.g. for counting transactions
+func (f *fetcher) FetchBlockList(heights []int64) []*heightResult {
+	return f.fetchList(heights, func(height int64) (*BlockResult, []types.TxResponse, error) {
+		block, err := f.fetchBlock(height)
+		return block, nil, err
+	})
+}
+
+func (f *fetcher) fetchList(heights []int64, fetch func(height int64) (*BlockResult, []types.TxResponse, error)) []*heightResult {
+	if len(heights) == 0 {
+		return nil
+	}
+	results := make([]*heightResult, len(heights))
+	for i, height := range heights {
+		results[i] = &heightResult{
+			height: height,
+			done:   make(chan struct{}),
+		}
+	}
+	jobs := make(chan *heightResult)
+	go func() {
+		defer close(jobs)
+		for _, res := range results {
+			select {
+			case jobs <- res:
+			case <-f.stop:
+				return
+			}
+		}
+	}()
+	workerCount := f.concurrency
+	if workerCount > len(results) {
+		workerCount = len(results)
+	}
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			for res := range jobs {
+				res.block, res.txResponses, res.err = fetch(res.height)
+				close(res.done)
+			}
+		}()
+	}
+	return results
+}
+
+func (f *fetcher) fetchBlock(height int64) (*BlockResult, error) {
+	block, err := f.source.Block(height)
+	if err != nil {
+		return nil, err
+	}
+	if block.Block.Header.Height != height {
+		return nil, fmt.Errorf("block height mismatch, expected %d, got %d", height, block.Block.Header.Height)
+	}
+	return block, nil
+}
+
+func (f *fetcher) fetchHeight(height int64) (*BlockResult, []types.TxResponse, error) {
+	block, err := f.fetchBlock(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	txResponses, err := f.source.TxResponses(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(txResponses) != len(block.Block.Data.Txs) {
+		return nil, nil, fmt.Errorf("block has %d txs but got %d tx responses, height = %d", len(block.Block.Data.Txs), len(txResponses), height)
+	}
+	return block, txResponses, nil
+}
@@ -0,0 +1,138 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	txTypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/tendermint/tendermint/libs/bytes"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+var grpcTimeout = 10 * time.Second
+
+// GrpcSource is a BlockSource which queries blocks and transactions from the gRPC services of the node,
+// the responses are decoded from protobuf directly without JSON round trip.
+type GrpcSource struct {
+	txClient txTypes.ServiceClient
+	tmClient tmservice.ServiceClient
+	// limits the number of concurrent requests sent to the node
+	requests chan struct{}
+}
+
+var _ BlockSource = (*GrpcSource)(nil)
+
+// DialGrpc connects to the gRPC endpoint of the node, using the same protobuf codec as the node
+func DialGrpc(endpoint string) (*grpc.ClientConn, error) {
+	return grpc.Dial(
+		endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec.NewProtoCodec(encodingConfig.InterfaceRegistry).GRPCCodec())),
+	)
+}
+
+func NewGrpcSource(conn grpc.ClientConnInterface) *GrpcSource {
+	concurrency := fetchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &GrpcSource{
+		txClient: txTypes.NewServiceClient(conn),
+		tmClient: tmservice.NewServiceClient(conn),
+		requests: make(chan struct{}, concurrency),
+	}
+}
+
+func (s *GrpcSource) acquire() {
+	s.requests <- struct{}{}
+}
+
+func (s *GrpcSource) release() {
+	<-s.requests
+}
+
+func (s *GrpcSource) LatestHeight() (int64, error) {
+	s.acquire()
+	defer s.release()
+	ctx, cancel := context.WithTimeout(context.Background(), grpcTimeout)
+	defer cancel()
+	res, err := s.tmClient.GetLatestBlock(ctx, &tmservice.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("cannot get latest block from grpc: %w", err)
+	}
+	if res.Block == nil {
+		return 0, fmt.Errorf("empty latest block in response")
+	}
+	return res.Block.Header.Height, nil
+}
+
+func (s *GrpcSource) Block(height int64) (*BlockResult, error) {
+	s.acquire()
+	defer s.release()
+	ctx, cancel := context.WithTimeout(context.Background(), grpcTimeout)
+	defer cancel()
+	res, err := s.tmClient.GetBlockByHeight(ctx, &tmservice.GetBlockByHeightRequest{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block from grpc, error = %w, height = %d", err, height)
+	}
+	if res.Block == nil {
+		return nil, fmt.Errorf("empty block in response, height = %d", height)
+	}
+	blockResult := &BlockResult{}
+	if res.BlockId != nil {
+		blockResult.BlockID.Hash = res.BlockId.Hash
+	}
+	blockResult.Block.Header.Height = res.Block.Header.Height
+	blockResult.Block.Header.ProposerAddress = res.Block.Header.ProposerAddress
+	blockResult.Block.Header.Time = res.Block.Header.Time.UTC().Format(time.RFC3339Nano)
+	blockResult.Block.Data.Txs = make(tmTypes.Txs, len(res.Block.Data.Txs))
+	for i, tx := range res.Block.Data.Txs {
+		blockResult.Block.Data.Txs[i] = tx
+	}
+	return blockResult, nil
+}
+
+func (s *GrpcSource) TxResponses(block *BlockResult) ([]types.TxResponse, error) {
+	height := block.Block.Header.Height
+	txs := block.Block.Data.Txs
+	txResponses := make([]types.TxResponse, len(txs))
+	errs := make([]error, len(txs))
+	wg := sync.WaitGroup{}
+	for txIndex, tx := range txs {
+		wg.Add(1)
+		go func(txIndex int, txHash bytes.HexBytes) {
+			defer wg.Done()
+			s.acquire()
+			defer s.release()
+			logger.L.Infow("Getting transaction", "txhash", txHash, "height", height, "index", txIndex)
+			ctx, cancel := context.WithTimeout(context.Background(), grpcTimeout)
+			defer cancel()
+			res, err := s.txClient.GetTx(ctx, &txTypes.GetTxRequest{Hash: txHash.String()})
+			if err != nil {
+				errs[txIndex] = fmt.Errorf("cannot get tx response from grpc, error = %w, txhash = %s, height = %d, index = %d", err, txHash.String(), height, txIndex)
+				return
+			}
+			if res.TxResponse == nil {
+				errs[txIndex] = fmt.Errorf("empty tx response, txhash = %s, height = %d, index = %d", txHash.String(), height, txIndex)
+				return
+			}
+			txResponses[txIndex] = *res.TxResponse
+		}(txIndex, bytes.HexBytes(tx.Hash()))
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txResponses, nil
+}
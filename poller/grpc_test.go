@@ -0,0 +1,173 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	txTypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/importdb"
+)
+
+type fakeGrpcNode struct {
+	blocks       map[int64]*tmTypes.Block
+	txResponses  map[string]*types.TxResponse
+	latestHeight int64
+}
+
+func newFakeGrpcNode(t *testing.T, blockTime time.Time, txCounts ...int) *fakeGrpcNode {
+	node := &fakeGrpcNode{
+		blocks:       map[int64]*tmTypes.Block{},
+		txResponses:  map[string]*types.TxResponse{},
+		latestHeight: int64(len(txCounts)),
+	}
+	for i, txCount := range txCounts {
+		height := int64(i + 1)
+		txs := []tmTypes.Tx{}
+		for txIndex := 0; txIndex < txCount; txIndex++ {
+			tx := makeTestTx(t, fmt.Sprintf("memo %d %d", height, txIndex))
+			txs = append(txs, tx)
+			txRes, err := importdb.FormatTxResult(tx.Hash(), &abci.TxResult{
+				Height: height,
+				Index:  uint32(txIndex),
+				Tx:     tx,
+				Result: *makeTestDeliverTx(txIndex),
+			}, blockTime.Add(time.Duration(height)*time.Second))
+			require.NoError(t, err)
+			node.txResponses[txRes.TxHash] = &txRes
+		}
+		block := tmTypes.MakeBlock(height, txs, nil, nil)
+		block.Header.Time = blockTime.Add(time.Duration(height) * time.Second)
+		node.blocks[height] = block
+	}
+	return node
+}
+
+func (node *fakeGrpcNode) getBlock(height int64) (*tmservice.GetBlockByHeightResponse, error) {
+	block, ok := node.blocks[height]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "requested block height %d is not available", height)
+	}
+	blockProto, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &tmservice.GetBlockByHeightResponse{Block: blockProto}, nil
+}
+
+type fakeTmService struct {
+	tmservice.UnimplementedServiceServer
+	node *fakeGrpcNode
+}
+
+func (s *fakeTmService) GetLatestBlock(ctx context.Context, req *tmservice.GetLatestBlockRequest) (*tmservice.GetLatestBlockResponse, error) {
+	res, err := s.node.getBlock(s.node.latestHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &tmservice.GetLatestBlockResponse{Block: res.Block}, nil
+}
+
+func (s *fakeTmService) GetBlockByHeight(ctx context.Context, req *tmservice.GetBlockByHeightRequest) (*tmservice.GetBlockByHeightResponse, error) {
+	return s.node.getBlock(req.Height)
+}
+
+type fakeTxService struct {
+	txTypes.UnimplementedServiceServer
+	node *fakeGrpcNode
+}
+
+func (s *fakeTxService) GetTx(ctx context.Context, req *txTypes.GetTxRequest) (*txTypes.GetTxResponse, error) {
+	txRes, ok := s.node.txResponses[req.Hash]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "tx not found: %s", req.Hash)
+	}
+	return &txTypes.GetTxResponse{TxResponse: txRes}, nil
+}
+
+func newTestGrpcSource(t *testing.T, node *fakeGrpcNode) (*GrpcSource, func()) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(encodingConfig.InterfaceRegistry).GRPCCodec()))
+	txTypes.RegisterServiceServer(server, &fakeTxService{node: node})
+	tmservice.RegisterServiceServer(server, &fakeTmService{node: node})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec.NewProtoCodec(encodingConfig.InterfaceRegistry).GRPCCodec())),
+	)
+	require.NoError(t, err)
+	return NewGrpcSource(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestGrpcSource(t *testing.T) {
+	blockTime := time.Unix(1600000000, 0).UTC()
+	node := newFakeGrpcNode(t, blockTime, 3, 0)
+	source, closeServer := newTestGrpcSource(t, node)
+	defer closeServer()
+
+	latestHeight, err := source.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), latestHeight)
+
+	block, err := source.Block(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), block.Block.Header.Height)
+	require.Equal(t, node.blocks[1].Data.Txs, block.Block.Data.Txs)
+	parsedTime, err := time.Parse(time.RFC3339Nano, block.Block.Header.Time)
+	require.NoError(t, err)
+	require.True(t, node.blocks[1].Header.Time.Equal(parsedTime))
+
+	txResponses, err := source.TxResponses(block)
+	require.NoError(t, err)
+	require.Len(t, txResponses, 3)
+	for txIndex, txRes := range txResponses {
+		tx := node.blocks[1].Data.Txs[txIndex]
+		require.Equal(t, fmt.Sprintf("%X", tx.Hash()), txRes.TxHash)
+		require.Equal(t, int64(50000+txIndex), txRes.GasUsed)
+
+		expectedJSON, err := encodingConfig.Marshaler.MarshalJSON(node.txResponses[txRes.TxHash])
+		require.NoError(t, err)
+		txResJSON, err := encodingConfig.Marshaler.MarshalJSON(&txRes)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedJSON), string(txResJSON))
+		require.Contains(t, string(txResJSON), fmt.Sprintf("memo 1 %d", txIndex))
+	}
+
+	block, err = source.Block(2)
+	require.NoError(t, err)
+	txResponses, err = source.TxResponses(block)
+	require.NoError(t, err)
+	require.Empty(t, txResponses)
+
+	_, err = source.Block(3)
+	require.Error(t, err)
+
+	delete(node.txResponses, fmt.Sprintf("%X", node.blocks[1].Data.Txs[1].Hash()))
+	block, err = source.Block(1)
+	require.NoError(t, err)
+	_, err = source.TxResponses(block)
+	require.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package poller
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	txTypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/tendermint/tendermint/libs/bytes"
+	tmTypes "github.com/tendermint/tendermint/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+// LcdSource is the default BlockSource, which queries blocks and transactions from LCD.
+// If RpcEndpoint is set in the context, the transaction results of a whole block are fetched from Tendermint RPC at once,
+// otherwise (or if the node does not support it) transactions are queried from LCD one by one.
+type LcdSource struct {
+	ctx *CosmosCallContext
+	// limits the number of concurrent requests sent to the node
+	requests           chan struct{}
+	blockFetchDisabled int32
+}
+
+var _ BlockSource = (*LcdSource)(nil)
+
+func NewLcdSource(ctx *CosmosCallContext) *LcdSource {
+	concurrency := fetchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &LcdSource{
+		ctx:      ctx,
+		requests: make(chan struct{}, concurrency),
+	}
+}
+
+func (s *LcdSource) acquire() {
+	s.requests <- struct{}{}
+}
+
+func (s *LcdSource) release() {
+	<-s.requests
+}
+
+func (s *LcdSource) blockFetchEnabled() bool {
+	return s.ctx.RpcEndpoint != "" && atomic.LoadInt32(&s.blockFetchDisabled) == 0
+}
+
+func (s *LcdSource) disableBlockFetch(err error) {
+	if atomic.CompareAndSwapInt32(&s.blockFetchDisabled, 0, 1) {
+		logger.L.Warnw("Fetching whole block is not supported, fall back to fetching transactions one by one", "error", err)
+	}
+}
+
+func (s *LcdSource) LatestHeight() (int64, error) {
+	height, err := s.ctx.Lcd.RefreshHeights()
+	if err != nil {
+		return 0, fmt.Errorf("cannot get latest block from lcd: %w", err)
+	}
+	return height, nil
+}
+
+func (s *LcdSource) Block(height int64) (*BlockResult, error) {
+	s.acquire()
+	defer s.release()
+	if s.blockFetchEnabled() {
+		blockWithTxs, err := GetBlockWithTxs(s.ctx, height)
+		if err == nil {
+			header := blockWithTxs.Block.Header
+			blockResult := &BlockResult{}
+			if blockWithTxs.BlockId != nil {
+				blockResult.BlockID.Hash = blockWithTxs.BlockId.Hash
+			}
+			blockResult.Block.Header.Height = header.Height
+			blockResult.Block.Header.ProposerAddress = header.ProposerAddress
+			blockResult.Block.Header.Time = header.Time.UTC().Format(time.RFC3339Nano)
+			blockResult.Block.Data.Txs = make(tmTypes.Txs, len(blockWithTxs.Block.Data.Txs))
+			for i, tx := range blockWithTxs.Block.Data.Txs {
+				blockResult.Block.Data.Txs[i] = tx
+			}
+			return blockResult, nil
+		}
+		if !errors.Is(err, errBlockFetchUnsupported) {
+			return nil, fmt.Errorf("cannot get block with txs from lcd, error = %w, height = %d", err, height)
+		}
+		s.disableBlockFetch(err)
+	}
+	blockResult, err := GetBlock(s.ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block from lcd, error = %w, height = %d", err, height)
+	}
+	return blockResult, nil
+}
+
+func (s *LcdSource) TxResponses(block *BlockResult) ([]types.TxResponse, error) {
+	height := block.Block.Header.Height
+	txs := block.Block.Data.Txs
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	if s.blockFetchEnabled() {
+		txResponses, err := s.getBlockTxResponses(block)
+		if err == nil {
+			return txResponses, nil
+		}
+		if !errors.Is(err, errBlockFetchUnsupported) {
+			return nil, err
+		}
+		s.disableBlockFetch(err)
+	}
+	txResponses := make([]types.TxResponse, len(txs))
+	errs := make([]error, len(txs))
+	wg := sync.WaitGroup{}
+	for txIndex, tx := range txs {
+		wg.Add(1)
+		go func(txIndex int, txHash bytes.HexBytes) {
+			defer wg.Done()
+			s.acquire()
+			defer s.release()
+			logger.L.Infow("Getting transaction", "txhash", txHash, "height", height, "index", txIndex)
+			txRes, err := GetTxResponse(s.ctx, txHash)
+			if err != nil {
+				errs[txIndex] = fmt.Errorf("error = %w, txhash = %s, height = %d, index = %d", err, txHash.String(), height, txIndex)
+				return
+			}
+			txResponses[txIndex] = *txRes
+		}(txIndex, bytes.HexBytes(tx.Hash()))
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txResponses, nil
+}
+
+func (s *LcdSource) getBlockTxResponses(block *BlockResult) ([]types.TxResponse, error) {
+	height := block.Block.Header.Height
+	blockTime, err := time.Parse(time.RFC3339Nano, block.Block.Header.Time)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse block time, error = %w, height = %d", err, height)
+	}
+	s.acquire()
+	results, err := GetBlockResults(s.ctx, height)
+	s.release()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block results from rpc, error = %w, height = %d", err, height)
+	}
+	return FormatBlockTxResponses(height, blockTime, block.Block.Data.Txs, results.TxsResults)
+}
+
+func GetTxResponse(ctx *CosmosCallContext, txHash bytes.HexBytes) (*types.TxResponse, error) {
+	txResJSON, err := ctx.Lcd.Get(fmt.Sprintf("/cosmos/tx/v1beta1/txs/%s", txHash.String()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tx response from lcd: %w", err)
+	}
+	txRes := txTypes.GetTxResponse{}
+	err = encodingConfig.Marshaler.UnmarshalJSON(txResJSON, &txRes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal tx response to JSON, error = %w, tx_response = %s", err, string(txResJSON))
+	}
+	if txRes.TxResponse == nil {
+		return nil, fmt.Errorf("empty tx response, tx_response = %s", string(txResJSON))
+	}
+	return txRes.TxResponse, nil
+}
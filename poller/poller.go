@@ -1,25 +1,27 @@
 package poller
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"time"
 
-	txTypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/endpoints"
 	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
 	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
 	"github.com/likecoin/likecoin-chain/v4/app"
 	amino "github.com/tendermint/go-amino"
-	"github.com/tendermint/tendermint/libs/bytes"
 
 	tmTypes "github.com/tendermint/tendermint/types"
 )
 
 var batchSize = utils.EnvInt("BATCH_SIZE", 1000)
 var batchMaxHeightDiff = int64(utils.EnvInt("BATCH_MAX_HEIGHT_DIFF", 1000))
+var fetchConcurrency = utils.EnvInt("FETCH_CONCURRENCY", 8)
 
 // TODO: move into config
 var sleepInitial = time.Duration(utils.EnvInt("SLEEP_INITIAL", 5)) * time.Second
@@ -34,7 +36,7 @@ func getResponse(client *http.Client, url string) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("non-200 code returned: %d", resp.StatusCode)
+		return nil, &endpoints.StatusCodeError{StatusCode: resp.StatusCode}
 	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -44,16 +46,23 @@ func getResponse(client *http.Client, url string) ([]byte, error) {
 }
 
 type CosmosCallContext struct {
-	Codec       *amino.Codec
-	Client      *http.Client
-	LcdEndpoint string
+	Codec  *amino.Codec
+	Client *http.Client
+	// LCD endpoints, requests are failed over to other endpoints if one is unhealthy
+	Lcd *endpoints.Pool
+	// optional, if set, transactions are fetched block by block with the execution results from Tendermint RPC
+	RpcEndpoint string
 }
 
 type BlockResult struct {
+	BlockID struct {
+		Hash []byte `json:"hash"`
+	} `json:"block_id"`
 	Block struct {
 		Header struct {
-			Height int64  `json:"height"`
-			Time   string `json:"time"`
+			Height          int64  `json:"height"`
+			Time            string `json:"time"`
+			ProposerAddress []byte `json:"proposer_address"`
 		} `json:"header"`
 		Data struct {
 			Txs tmTypes.Txs `json:"txs"`
@@ -61,13 +70,26 @@ type BlockResult struct {
 	} `json:"block"`
 }
 
+func (b *BlockResult) toDbBlock() (db.Block, error) {
+	blockTime, err := time.Parse(time.RFC3339Nano, b.Block.Header.Time)
+	if err != nil {
+		return db.Block{}, err
+	}
+	return db.Block{
+		Height:          b.Block.Header.Height,
+		Hash:            fmt.Sprintf("%X", b.BlockID.Hash),
+		ProposerAddress: fmt.Sprintf("%X", b.Block.Header.ProposerAddress),
+		Time:            blockTime,
+		TxCount:         len(b.Block.Data.Txs),
+	}, nil
+}
+
 func GetBlock(ctx *CosmosCallContext, height int64) (*BlockResult, error) {
 	heightStr := "latest"
 	if height > 0 {
 		heightStr = fmt.Sprintf("%d", height)
 	}
-	url := fmt.Sprintf("%s/cosmos/base/tendermint/v1beta1/blocks/%s", ctx.LcdEndpoint, heightStr)
-	body, err := getResponse(ctx.Client, url)
+	body, err := ctx.Lcd.Get(fmt.Sprintf("/cosmos/base/tendermint/v1beta1/blocks/%s", heightStr))
 	if err != nil {
 		return nil, err
 	}
@@ -96,58 +118,112 @@ func getHeight(pool *pgxpool.Pool) (int64, error) {
 	return lastHeight, nil
 }
 
-func poll(pool *pgxpool.Pool, ctx *CosmosCallContext, lastHeight int64) (int64, error) {
+// poll fetches and inserts the blocks after lastHeight.
+// When ctx is done, no more heights are fetched, and the heights already fetched are committed before returning.
+// If extractor is not nil, the fetched transactions are also extracted into the same batch by the groups tracking the latest height,
+// which is flushed only once, so transactions, derived tables and meta heights are committed atomically.
+func poll(ctx context.Context, pool *pgxpool.Pool, source BlockSource, lastHeight int64, extractor db.GroupedExtractor) (int64, error) {
 	conn, err := db.AcquireFromPool(pool)
 	if err != nil {
 		return 0, fmt.Errorf("cannot acquire connection from database connection pool: %w", err)
 	}
 	defer conn.Release()
 	batch := db.NewBatch(conn, batchSize)
-	latestBlockResult, err := GetBlock(ctx, 0)
+	extractedHeight := int64(0)
+	var trackingGroups []db.ExtractorGroup
+	var extract db.Extractor
+	if extractor != nil {
+		// never flushed before the end of the poll
+		batch = db.NewBatch(conn, math.MaxInt)
+		extractedHeight, trackingGroups, err = catchUpExtractor(ctx, conn, extractor)
+		if err != nil {
+			return 0, err
+		}
+		extract = extractor.ForGroups(trackingGroups)
+	}
+	maxHeight, err := source.LatestHeight()
 	if err != nil {
 		// TODO: retry
-		return 0, fmt.Errorf("cannot get latest block from lcd: %w", err)
+		return 0, fmt.Errorf("cannot get latest block height: %w", err)
 	}
-	maxHeight := latestBlockResult.Block.Header.Height
 	if maxHeight-lastHeight > batchMaxHeightDiff {
 		maxHeight = lastHeight + batchMaxHeightDiff
 	}
+	if maxHeight <= lastHeight {
+		return lastHeight, nil
+	}
 	logger.L.Debugw("Querying blocks", "lastHeight", lastHeight, "maxHeight", maxHeight)
-	for height := lastHeight + 1; height <= maxHeight; height++ {
-		blockResult, err := GetBlock(ctx, height)
+	f := newFetcher(source, fetchConcurrency)
+	defer f.Stop()
+	committedHeight := lastHeight
+	committedBlockTime := ""
+	var pollErr error
+	for _, res := range f.FetchHeights(lastHeight+1, maxHeight) {
+		select {
+		case <-res.done:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			f.Stop()
+			pollErr = ctx.Err()
+			break
+		}
+		if res.err != nil {
+			// stop fetching, so heights after the failed one will never be queued into the batch
+			f.Stop()
+			pollErr = res.err
+			break
+		}
+		block, err := res.block.toDbBlock()
 		if err != nil {
-			return 0, fmt.Errorf("cannot get block from lcd, error = %w, height = %d", err, height)
+			return 0, fmt.Errorf("cannot parse block, error = %w, height = %d", err, res.height)
 		}
-		for txIndex, tx := range blockResult.Block.Data.Txs {
-			txHash := bytes.HexBytes(tx.Hash())
-			logger.L.Infow("Getting transaction", "txhash", txHash, "height", height, "index", txIndex)
-			url := fmt.Sprintf("%s/cosmos/tx/v1beta1/txs/%s", ctx.LcdEndpoint, txHash.String())
-			txResJSON, err := getResponse(ctx.Client, url)
-			if err != nil {
-				return 0, fmt.Errorf("cannot get tx response from lcd, error = %w, txhash = %s, height = %d, index = %d", err, txHash.String(), height, txIndex)
-			}
-			txRes := txTypes.GetTxResponse{}
-			err = encodingConfig.Marshaler.UnmarshalJSON(txResJSON, &txRes)
+		err = batch.InsertBlock(block)
+		if err != nil {
+			return 0, fmt.Errorf("cannot insert block, error = %w, height = %d", err, res.height)
+		}
+		for txIndex, txRes := range res.txResponses {
+			err = batch.InsertTx(txRes, res.height, txIndex)
 			if err != nil {
-				return 0, fmt.Errorf("cannot unmarshal tx response to JSON, error = %w, txhash = %s, tx_response = %v", err, txHash, txResJSON)
+				return 0, fmt.Errorf("cannot insert transaction, error = %w, txhash = %s, height = %d, index = %d", err, txRes.TxHash, res.height, txIndex)
 			}
-			err = batch.InsertTx(*txRes.TxResponse, height, txIndex)
-			if err != nil {
-				return 0, fmt.Errorf("cannot insert transaction, error = %w, txhash = %s, height = %d, index = %d, tx_json = %s", err, txHash.String(), height, txIndex, string(txResJSON))
+			// the last polled height is polled again on start, skip it if it is already extracted
+			if extract != nil && res.height > extractedHeight {
+				eventCtx, err := db.NewEventContextFromTxResponse(&batch, &res.txResponses[txIndex])
+				if err != nil {
+					return 0, fmt.Errorf("cannot build event context, error = %w, txhash = %s, height = %d, index = %d", err, txRes.TxHash, res.height, txIndex)
+				}
+				err = extract(eventCtx)
+				if err != nil {
+					db.HandleExtractError(eventCtx, err)
+				}
 			}
 		}
+		committedHeight = res.height
+		committedBlockTime = res.block.Block.Header.Time
 	}
-	batch.UpdateLatestBlockHeight(maxHeight)
+	if committedHeight == lastHeight && pollErr != nil {
+		return 0, pollErr
+	}
+	batch.UpdateLatestBlockHeight(committedHeight)
 	// error is ignored since fail to update block time is not critical
-	_ = batch.UpdateLatestBlockTime(latestBlockResult.Block.Header.Time)
+	_ = batch.UpdateLatestBlockTime(committedBlockTime)
+	if extractor != nil && committedHeight > extractedHeight {
+		batch.UpdateExtractorHeight(trackingGroups, committedHeight)
+	}
 	err = batch.Flush()
 	if err != nil {
 		return 0, fmt.Errorf("cannot flush transaction batch, error = %w, batch = %v", err, batch)
 	}
-	return maxHeight, nil
+	// heights before the failed one are committed, caller should still know about the error
+	return committedHeight, pollErr
 }
 
-func Run(pool *pgxpool.Pool, ctx *CosmosCallContext, triggers ...chan<- int64) {
+// Run polls new blocks until ctx is done, the batch being inserted is committed before returning.
+// If newBlock is not nil, the poller wakes up as soon as it receives a signal from it, instead of waiting for the sleep interval.
+// If extractor is not nil, transactions are extracted synchronously in the same database transaction as they are inserted,
+// and the asynchronous extractor should not be running.
+func Run(ctx context.Context, pool *pgxpool.Pool, source BlockSource, newBlock <-chan struct{}, extractor db.GroupedExtractor, triggers ...chan<- int64) {
 	lastHeight, err := getHeight(pool)
 	logger.L.Infow("Init Height", "lastHeight", lastHeight)
 	if err != nil {
@@ -155,24 +231,65 @@ func Run(pool *pgxpool.Pool, ctx *CosmosCallContext, triggers ...chan<- int64) {
 	}
 	toSleep := sleepInitial
 	for {
-		returnedHeight, err := poll(pool, ctx, lastHeight)
-		if err == nil {
-			// reset sleep time to normal value
-			toSleep = sleepInitial
+		returnedHeight, err := poll(ctx, pool, source, lastHeight, extractor)
+		if err == nil || returnedHeight > lastHeight {
 			lastHeight = returnedHeight
 			go func() {
 				for _, trigger := range triggers {
-					trigger <- returnedHeight
+					select {
+					case trigger <- returnedHeight:
+					case <-ctx.Done():
+						return
+					}
 				}
 			}()
+		}
+		if ctx.Err() != nil {
+			logger.L.Infow("Poller stopped", "lastHeight", lastHeight)
+			return
+		}
+		if err == nil {
+			// reset sleep time to normal value
+			toSleep = sleepInitial
 		} else {
-			logger.L.Errorw("cannot poll block", "error", err)
+			logger.L.Errorw("cannot poll block", "error", err, "lastHeight", lastHeight)
 			// exponential back-off with max cap
 			toSleep = toSleep * 2
 			if toSleep > sleepMax {
 				toSleep = sleepMax
 			}
+			// keep backing off instead of hitting the failing node on every new block
+			select {
+			case <-ctx.Done():
+			case <-time.After(toSleep):
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-newBlock:
+		case <-time.After(toSleep):
 		}
-		time.Sleep(toSleep)
 	}
 }
+
+// catchUpExtractor extracts the transactions polled before synchronous extraction is enabled,
+// returns the extracted height, which is the same as the latest block height afterwards, and the groups tracking it.
+// Lagging groups are left to extractor.RunLagging.
+func catchUpExtractor(ctx context.Context, conn *pgxpool.Conn, extractor db.GroupedExtractor) (int64, []db.ExtractorGroup, error) {
+	for {
+		finished, err := db.Extract(ctx, conn, extractor)
+		if err != nil {
+			return 0, nil, fmt.Errorf("cannot extract transactions polled before: %w", err)
+		}
+		if finished {
+			break
+		}
+	}
+	groups := extractor.Groups()
+	extractedHeight, heights, err := db.GetExtractorHeights(conn, groups)
+	if err != nil {
+		return 0, nil, fmt.Errorf("cannot get extractor synchronized height: %w", err)
+	}
+	return extractedHeight, db.GroupsAt(groups, heights, extractedHeight), nil
+}
@@ -0,0 +1,266 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	tmTypes "github.com/tendermint/tendermint/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestMain(m *testing.M) {
+	SetupDbAndRunTest(m, nil)
+}
+
+type fakeBlock struct {
+	block       *BlockResult
+	txResponses []types.TxResponse
+}
+
+type fakeSource struct {
+	latestHeight int64
+	blocks       map[int64]fakeBlock
+	failHeights  map[int64]bool
+}
+
+var _ BlockSource = (*fakeSource)(nil)
+
+func newFakeSource(blockTime time.Time, txCounts ...int) *fakeSource {
+	s := &fakeSource{
+		latestHeight: int64(len(txCounts)),
+		blocks:       map[int64]fakeBlock{},
+		failHeights:  map[int64]bool{},
+	}
+	for i, txCount := range txCounts {
+		height := int64(i + 1)
+		block := &BlockResult{}
+		block.Block.Header.Height = height
+		block.Block.Header.Time = blockTime.Add(time.Duration(height) * time.Second).UTC().Format(time.RFC3339)
+		txResponses := []types.TxResponse{}
+		for txIndex := 0; txIndex < txCount; txIndex++ {
+			txHash := fmt.Sprintf("TX_%d_%d", height, txIndex)
+			block.Block.Data.Txs = append(block.Block.Data.Txs, tmTypes.Tx(txHash))
+			txResponses = append(txResponses, types.TxResponse{
+				Height:    height,
+				TxHash:    txHash,
+				Timestamp: block.Block.Header.Time,
+			})
+		}
+		s.blocks[height] = fakeBlock{block: block, txResponses: txResponses}
+	}
+	return s
+}
+
+func (s *fakeSource) LatestHeight() (int64, error) {
+	return s.latestHeight, nil
+}
+
+func (s *fakeSource) Block(height int64) (*BlockResult, error) {
+	if s.failHeights[height] {
+		return nil, fmt.Errorf("fake error at height %d", height)
+	}
+	b, ok := s.blocks[height]
+	if !ok {
+		return nil, fmt.Errorf("block not found at height %d", height)
+	}
+	return b.block, nil
+}
+
+func (s *fakeSource) TxResponses(block *BlockResult) ([]types.TxResponse, error) {
+	return s.blocks[block.Block.Header.Height].txResponses, nil
+}
+
+// testExtractor runs the same extractor for its single group
+type testExtractor db.Extractor
+
+var testGroups = []db.ExtractorGroup{{Name: "test_poller", Version: 1}}
+
+func (e testExtractor) Groups() []db.ExtractorGroup {
+	return testGroups
+}
+
+func (e testExtractor) ForGroups(groups []db.ExtractorGroup) db.Extractor {
+	return db.Extractor(e)
+}
+
+func queryTxHashes(t *testing.T) []string {
+	rows, err := Conn.Query(context.Background(), `SELECT tx->>'txhash' FROM txs ORDER BY height, tx_index`)
+	require.NoError(t, err)
+	defer rows.Close()
+	txHashes := []string{}
+	for rows.Next() {
+		var txHash string
+		require.NoError(t, rows.Scan(&txHash))
+		txHashes = append(txHashes, txHash)
+	}
+	require.NoError(t, rows.Err())
+	return txHashes
+}
+
+func TestPoll(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blockTime := time.Unix(1600000000, 0).UTC()
+	source := newFakeSource(blockTime, 2, 0, 3, 1)
+
+	height, err := poll(context.Background(), Pool, source, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), height)
+
+	require.Equal(t, []string{"TX_1_0", "TX_1_1", "TX_3_0", "TX_3_1", "TX_3_2", "TX_4_0"}, queryTxHashes(t))
+
+	latestHeight, err := db.GetLatestHeight(Conn)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), latestHeight)
+
+	latestBlockTime, err := db.GetLatestBlockTime(Conn)
+	require.NoError(t, err)
+	require.Equal(t, blockTime.Add(4*time.Second), latestBlockTime)
+
+	// empty blocks are recorded as well
+	blocks, err := db.GetBlocks(Conn, db.QueryBlocksRequest{}, db.PageRequest{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, blocks.Blocks, 4)
+	for i, b := range blocks.Blocks {
+		require.Equal(t, int64(i+1), b.Height)
+		require.Equal(t, blockTime.Add(time.Duration(i+1)*time.Second), b.Time)
+		require.Equal(t, len(source.blocks[b.Height].block.Block.Data.Txs), b.TxCount)
+	}
+
+	// nothing new to poll
+	height, err = poll(context.Background(), Pool, source, 4, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), height)
+}
+
+func TestPollPartialFailure(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blockTime := time.Unix(1600000000, 0).UTC()
+	source := newFakeSource(blockTime, 1, 1, 1, 1)
+	source.failHeights[3] = true
+
+	height, err := poll(context.Background(), Pool, source, 0, nil)
+	require.Error(t, err)
+	require.Equal(t, int64(2), height)
+	require.Equal(t, []string{"TX_1_0", "TX_2_0"}, queryTxHashes(t))
+
+	latestHeight, err := db.GetLatestHeight(Conn)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), latestHeight)
+
+	source.failHeights[1] = true
+	height, err = poll(context.Background(), Pool, source, 0, nil)
+	require.Error(t, err)
+	require.Equal(t, int64(0), height)
+
+	delete(source.failHeights, 3)
+	height, err = poll(context.Background(), Pool, source, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), height)
+	require.Equal(t, []string{"TX_1_0", "TX_2_0", "TX_3_0", "TX_4_0"}, queryTxHashes(t))
+}
+
+func TestPollCanceled(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blockTime := time.Unix(1600000000, 0).UTC()
+	source := newFakeSource(blockTime, 1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	height, err := poll(ctx, Pool, source, 0, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, int64(0), height)
+	require.Equal(t, []string{}, queryTxHashes(t))
+
+	latestHeight, err := db.GetLatestHeight(Conn)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), latestHeight)
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blockTime := time.Unix(1600000000, 0).UTC()
+	source := newFakeSource(blockTime, 1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	trigger := make(chan int64, 1)
+	stopped := make(chan struct{})
+	go func() {
+		Run(ctx, Pool, source, nil, nil, trigger)
+		close(stopped)
+	}()
+	select {
+	case height := <-trigger:
+		require.Equal(t, int64(2), height)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for poller")
+	}
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "poller does not stop after context is canceled")
+	}
+	require.Equal(t, []string{"TX_1_0", "TX_2_0"}, queryTxHashes(t))
+}
+
+func TestPollSyncExtract(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blockTime := time.Unix(1600000000, 0).UTC()
+	source := newFakeSource(blockTime, 2, 0, 1, 1)
+
+	extracted := []string{}
+	extractor := testExtractor(func(ctx db.EventContext) error {
+		extracted = append(extracted, ctx.TxHash)
+		return nil
+	})
+	height, err := poll(context.Background(), Pool, source, 0, extractor)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), height)
+	require.Equal(t, []string{"TX_1_0", "TX_1_1", "TX_3_0", "TX_4_0"}, extracted)
+
+	extractedHeight, err := db.GetMetaHeight(Conn, db.META_EXTRACTOR)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), extractedHeight)
+
+	// the last height is polled again after restart, but should not be extracted twice
+	source = newFakeSource(blockTime, 2, 0, 1, 1, 1)
+	height, err = poll(context.Background(), Pool, source, 3, extractor)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), height)
+	require.Equal(t, []string{"TX_1_0", "TX_1_1", "TX_3_0", "TX_4_0", "TX_5_0"}, extracted)
+
+	extractedHeight, err = db.GetMetaHeight(Conn, db.META_EXTRACTOR)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), extractedHeight)
+}
+
+func TestPollSyncExtractAtomic(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blockTime := time.Unix(1600000000, 0).UTC()
+	source := newFakeSource(blockTime, 1, 1)
+
+	extractor := testExtractor(func(ctx db.EventContext) error {
+		if ctx.TxHash == "TX_2_0" {
+			// fails the whole batch when it is flushed
+			ctx.Batch.Batch.Queue(`SELECT 1 / 0`)
+		}
+		return nil
+	})
+	_, err := poll(context.Background(), Pool, source, 0, extractor)
+	require.Error(t, err)
+	require.Equal(t, []string{}, queryTxHashes(t))
+
+	latestHeight, err := db.GetLatestHeight(Conn)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), latestHeight)
+
+	extractedHeight, err := db.GetMetaHeight(Conn, db.META_EXTRACTOR)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), extractedHeight)
+}
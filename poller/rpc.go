@@ -0,0 +1,160 @@
+package poller
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+	coreTypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcTypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/importdb"
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+// RpcSource is a BlockSource which queries blocks and transactions from Tendermint RPC only,
+// for nodes with LCD API disabled.
+// Transaction results are fetched from `/block_results`, or from `/tx` one by one if the node cannot provide the block results.
+type RpcSource struct {
+	ctx *CosmosCallContext
+	// limits the number of concurrent requests sent to the node
+	requests chan struct{}
+}
+
+var _ BlockSource = (*RpcSource)(nil)
+
+func NewRpcSource(ctx *CosmosCallContext) *RpcSource {
+	concurrency := fetchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &RpcSource{
+		ctx:      ctx,
+		requests: make(chan struct{}, concurrency),
+	}
+}
+
+func (s *RpcSource) acquire() {
+	s.requests <- struct{}{}
+}
+
+func (s *RpcSource) release() {
+	<-s.requests
+}
+
+func GetRpcBlock(ctx *CosmosCallContext, height int64) (*coreTypes.ResultBlock, error) {
+	url := fmt.Sprintf("%s/block", ctx.RpcEndpoint)
+	if height > 0 {
+		url = fmt.Sprintf("%s?height=%d", url, height)
+	}
+	res := coreTypes.ResultBlock{}
+	err := getRpcResult(ctx.Client, url, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.Block == nil {
+		return nil, fmt.Errorf("empty block in response, height = %d", height)
+	}
+	return &res, nil
+}
+
+func GetRpcTx(ctx *CosmosCallContext, txHash bytes.HexBytes) (*coreTypes.ResultTx, error) {
+	url := fmt.Sprintf("%s/tx?hash=0x%s", ctx.RpcEndpoint, txHash.String())
+	res := coreTypes.ResultTx{}
+	err := getRpcResult(ctx.Client, url, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (s *RpcSource) LatestHeight() (int64, error) {
+	s.acquire()
+	defer s.release()
+	res, err := GetRpcBlock(s.ctx, 0)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get latest block from rpc: %w", err)
+	}
+	return res.Block.Header.Height, nil
+}
+
+func (s *RpcSource) Block(height int64) (*BlockResult, error) {
+	s.acquire()
+	defer s.release()
+	res, err := GetRpcBlock(s.ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block from rpc, error = %w, height = %d", err, height)
+	}
+	blockResult := &BlockResult{}
+	blockResult.BlockID.Hash = res.BlockID.Hash
+	blockResult.Block.Header.Height = res.Block.Header.Height
+	blockResult.Block.Header.ProposerAddress = res.Block.Header.ProposerAddress
+	blockResult.Block.Header.Time = res.Block.Header.Time.UTC().Format(time.RFC3339Nano)
+	blockResult.Block.Data.Txs = res.Block.Data.Txs
+	return blockResult, nil
+}
+
+func (s *RpcSource) TxResponses(block *BlockResult) ([]types.TxResponse, error) {
+	height := block.Block.Header.Height
+	txs := block.Block.Data.Txs
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	blockTime, err := time.Parse(time.RFC3339Nano, block.Block.Header.Time)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse block time, error = %w, height = %d", err, height)
+	}
+	s.acquire()
+	results, err := GetBlockResults(s.ctx, height)
+	s.release()
+	if err == nil {
+		return FormatBlockTxResponses(height, blockTime, txs, results.TxsResults)
+	}
+	var rpcErr *rpcTypes.RPCError
+	if !errors.As(err, &rpcErr) {
+		return nil, fmt.Errorf("cannot get block results from rpc, error = %w, height = %d", err, height)
+	}
+	// e.g. block results are pruned or not stored by the node, while the tx index is still available
+	logger.L.Warnw("Cannot get block results from rpc, fall back to fetching transactions one by one", "error", err, "height", height)
+
+	txResponses := make([]types.TxResponse, len(txs))
+	errs := make([]error, len(txs))
+	wg := sync.WaitGroup{}
+	for txIndex, tx := range txs {
+		wg.Add(1)
+		go func(txIndex int, txHash bytes.HexBytes) {
+			defer wg.Done()
+			s.acquire()
+			defer s.release()
+			logger.L.Infow("Getting transaction", "txhash", txHash, "height", height, "index", txIndex)
+			resTx, err := GetRpcTx(s.ctx, txHash)
+			if err != nil {
+				errs[txIndex] = fmt.Errorf("cannot get tx from rpc, error = %w, txhash = %s, height = %d, index = %d", err, txHash.String(), height, txIndex)
+				return
+			}
+			txResult := abci.TxResult{
+				Height: resTx.Height,
+				Index:  resTx.Index,
+				Tx:     resTx.Tx,
+				Result: resTx.TxResult,
+			}
+			txRes, err := importdb.FormatTxResult(txHash, &txResult, blockTime)
+			if err != nil {
+				errs[txIndex] = fmt.Errorf("cannot format tx result, error = %w, txhash = %s, height = %d, index = %d", err, txHash.String(), height, txIndex)
+				return
+			}
+			txResponses[txIndex] = txRes
+		}(txIndex, bytes.HexBytes(tx.Hash()))
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txResponses, nil
+}
@@ -0,0 +1,256 @@
+package poller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	coreTypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcTypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/importdb"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+type fakeRpcNode struct {
+	blocks            map[int64]*tmTypes.Block
+	results           map[int64][]*abci.ResponseDeliverTx
+	noBlockResults    bool
+	latestHeight      int64
+	requestedTxHashes []string
+}
+
+func makeTestTx(t *testing.T, memo string) tmTypes.Tx {
+	txBuilder := encodingConfig.TxConfig.NewTxBuilder()
+	err := txBuilder.SetMsgs(&bankTypes.MsgSend{
+		FromAddress: ADDR_01_LIKE,
+		ToAddress:   ADDR_02_LIKE,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("nanolike", 1000)),
+	})
+	require.NoError(t, err)
+	txBuilder.SetMemo(memo)
+	txBuilder.SetGasLimit(200000)
+	txBytes, err := encodingConfig.TxConfig.TxEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+	return txBytes
+}
+
+func makeTestDeliverTx(index int) *abci.ResponseDeliverTx {
+	return &abci.ResponseDeliverTx{
+		Code:      0,
+		Log:       fmt.Sprintf(`[{"msg_index":0,"events":[{"type":"message","attributes":[{"key":"action","value":"/cosmos.bank.v1beta1.MsgSend"},{"key":"sender","value":"%s"},{"key":"index","value":"%d"}]}]}]`, ADDR_01_LIKE, index),
+		GasWanted: 200000,
+		GasUsed:   int64(50000 + index),
+		Events: []abci.Event{{
+			Type:       "tx",
+			Attributes: []abci.EventAttribute{{Key: []byte("fee"), Value: []byte("10nanolike"), Index: true}},
+		}},
+	}
+}
+
+func newFakeRpcNode(t *testing.T, blockTime time.Time, txCounts ...int) *fakeRpcNode {
+	node := &fakeRpcNode{
+		blocks:       map[int64]*tmTypes.Block{},
+		results:      map[int64][]*abci.ResponseDeliverTx{},
+		latestHeight: int64(len(txCounts)),
+	}
+	for i, txCount := range txCounts {
+		height := int64(i + 1)
+		txs := []tmTypes.Tx{}
+		results := []*abci.ResponseDeliverTx{}
+		for txIndex := 0; txIndex < txCount; txIndex++ {
+			txs = append(txs, makeTestTx(t, fmt.Sprintf("memo %d %d", height, txIndex)))
+			results = append(results, makeTestDeliverTx(txIndex))
+		}
+		block := tmTypes.MakeBlock(height, txs, nil, nil)
+		block.Header.Time = blockTime.Add(time.Duration(height) * time.Second)
+		node.blocks[height] = block
+		node.results[height] = results
+	}
+	return node
+}
+
+func (node *fakeRpcNode) findTx(hash string) (*coreTypes.ResultTx, bool) {
+	for height, block := range node.blocks {
+		for txIndex, tx := range block.Data.Txs {
+			if strings.EqualFold(fmt.Sprintf("0x%X", tx.Hash()), hash) {
+				return &coreTypes.ResultTx{
+					Hash:     tx.Hash(),
+					Height:   height,
+					Index:    uint32(txIndex),
+					TxResult: *node.results[height][txIndex],
+					Tx:       tx,
+				}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (node *fakeRpcNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := rpcTypes.JSONRPCIntID(-1)
+	height := node.latestHeight
+	if heightStr := r.URL.Query().Get("height"); heightStr != "" {
+		height, _ = strconv.ParseInt(heightStr, 10, 64)
+	}
+	var res rpcTypes.RPCResponse
+	switch r.URL.Path {
+	case "/block":
+		block, ok := node.blocks[height]
+		if !ok {
+			res = rpcTypes.RPCInternalError(id, fmt.Errorf("height %d is not available", height))
+			break
+		}
+		res = rpcTypes.NewRPCSuccessResponse(id, &coreTypes.ResultBlock{
+			BlockID: tmTypes.BlockID{Hash: block.Hash()},
+			Block:   block,
+		})
+	case "/block_results":
+		results, ok := node.results[height]
+		if !ok || node.noBlockResults {
+			res = rpcTypes.RPCInternalError(id, fmt.Errorf("could not find results for height #%d", height))
+			break
+		}
+		res = rpcTypes.NewRPCSuccessResponse(id, &coreTypes.ResultBlockResults{
+			Height:     height,
+			TxsResults: results,
+		})
+	case "/tx":
+		hash := r.URL.Query().Get("hash")
+		node.requestedTxHashes = append(node.requestedTxHashes, hash)
+		resTx, ok := node.findTx(hash)
+		if !ok {
+			res = rpcTypes.RPCInternalError(id, fmt.Errorf("tx (%s) not found", hash))
+			break
+		}
+		res = rpcTypes.NewRPCSuccessResponse(id, resTx)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func newTestRpcSource(node *fakeRpcNode) (*RpcSource, func()) {
+	server := httptest.NewServer(node)
+	source := NewRpcSource(&CosmosCallContext{
+		Client:      server.Client(),
+		RpcEndpoint: server.URL,
+	})
+	// the fake node is not thread-safe
+	source.requests = make(chan struct{}, 1)
+	return source, server.Close
+}
+
+func TestRpcSource(t *testing.T) {
+	blockTime := time.Unix(1600000000, 123456789).UTC()
+	node := newFakeRpcNode(t, blockTime, 2, 0)
+	source, closeServer := newTestRpcSource(node)
+	defer closeServer()
+
+	latestHeight, err := source.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), latestHeight)
+
+	block, err := source.Block(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), block.Block.Header.Height)
+	require.Equal(t, node.blocks[1].Data.Txs, block.Block.Data.Txs)
+	parsedTime, err := time.Parse(time.RFC3339Nano, block.Block.Header.Time)
+	require.NoError(t, err)
+	require.True(t, node.blocks[1].Header.Time.Equal(parsedTime))
+
+	txResponses, err := source.TxResponses(block)
+	require.NoError(t, err)
+	require.Len(t, txResponses, 2)
+	require.Empty(t, node.requestedTxHashes)
+	for txIndex, txRes := range txResponses {
+		tx := node.blocks[1].Data.Txs[txIndex]
+		require.Equal(t, fmt.Sprintf("%X", tx.Hash()), txRes.TxHash)
+		require.Equal(t, int64(1), txRes.Height)
+		require.Equal(t, int64(50000+txIndex), txRes.GasUsed)
+		require.Equal(t, blockTime.Add(time.Second).Format(time.RFC3339), txRes.Timestamp)
+		require.Len(t, txRes.Logs, 1)
+		require.Equal(t, "message", txRes.Logs[0].Events[0].Type)
+		require.NotNil(t, txRes.Tx)
+
+		// should be identical to what importdb produces from the same data
+		expected, err := importdb.FormatTxResult(tx.Hash(), &abci.TxResult{
+			Height: 1,
+			Index:  uint32(txIndex),
+			Tx:     tx,
+			Result: *node.results[1][txIndex],
+		}, node.blocks[1].Header.Time)
+		require.NoError(t, err)
+		expectedJSON, err := encodingConfig.Marshaler.MarshalJSON(&expected)
+		require.NoError(t, err)
+		txResJSON, err := encodingConfig.Marshaler.MarshalJSON(&txRes)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedJSON), string(txResJSON))
+		require.Contains(t, string(txResJSON), fmt.Sprintf("memo 1 %d", txIndex))
+
+		// and to what LCD returns for the transaction hash
+		lcdRes := sdk.NewResponseResultTx(&coreTypes.ResultTx{
+			Hash:     tx.Hash(),
+			Height:   1,
+			Index:    uint32(txIndex),
+			TxResult: *node.results[1][txIndex],
+			Tx:       tx,
+		}, txRes.Tx, node.blocks[1].Header.Time.Format(time.RFC3339))
+		lcdJSON, err := encodingConfig.Marshaler.MarshalJSON(lcdRes)
+		require.NoError(t, err)
+		require.JSONEq(t, string(lcdJSON), string(txResJSON))
+		require.Equal(t, node.results[1][txIndex].Events, txRes.Events)
+	}
+
+	block, err = source.Block(2)
+	require.NoError(t, err)
+	txResponses, err = source.TxResponses(block)
+	require.NoError(t, err)
+	require.Empty(t, txResponses)
+
+	_, err = source.Block(3)
+	require.Error(t, err)
+}
+
+func TestRpcSourceWithoutBlockResults(t *testing.T) {
+	blockTime := time.Unix(1600000000, 0).UTC()
+	node := newFakeRpcNode(t, blockTime, 3)
+	source, closeServer := newTestRpcSource(node)
+	defer closeServer()
+
+	block, err := source.Block(1)
+	require.NoError(t, err)
+	expected, err := source.TxResponses(block)
+	require.NoError(t, err)
+	require.Empty(t, node.requestedTxHashes)
+
+	node.noBlockResults = true
+	txResponses, err := source.TxResponses(block)
+	require.NoError(t, err)
+	require.Len(t, node.requestedTxHashes, 3)
+	require.Len(t, txResponses, 3)
+	for txIndex := range txResponses {
+		expectedJSON, err := encodingConfig.Marshaler.MarshalJSON(&expected[txIndex])
+		require.NoError(t, err)
+		txResJSON, err := encodingConfig.Marshaler.MarshalJSON(&txResponses[txIndex])
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedJSON), string(txResJSON))
+	}
+}
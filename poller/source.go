@@ -0,0 +1,16 @@
+package poller
+
+import (
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// BlockSource provides blocks and transactions to the poller.
+// Implementations must be safe for concurrent use, since blocks of different heights are fetched concurrently.
+type BlockSource interface {
+	// LatestHeight returns the height of the latest block on chain
+	LatestHeight() (int64, error)
+	// Block returns the block at the given height
+	Block(height int64) (*BlockResult, error)
+	// TxResponses returns the responses of all transactions in the block, in the same order as the transactions in the block
+	TxResponses(block *BlockResult) ([]types.TxResponse, error)
+}
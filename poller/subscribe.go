@@ -0,0 +1,144 @@
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+)
+
+const newBlockQuery = "tm.event='NewBlock'"
+
+// the node sends ping every ~27s, so the connection is considered dropped if nothing is received for a longer period
+var wsReadTimeout = 60 * time.Second
+var wsReconnectInitial = 1 * time.Second
+var wsReconnectMax = 60 * time.Second
+
+type wsMessage struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+func getWebsocketURL(rpcEndpoint string) (string, error) {
+	u, err := url.Parse(rpcEndpoint)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("unsupported scheme in rpc endpoint: %s", rpcEndpoint)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/websocket"
+	return u.String(), nil
+}
+
+// SubscribeNewBlock subscribes to new block events from the Tendermint RPC websocket.
+// The returned channel receives a signal when a new block is committed, signals are dropped if the previous one is not consumed yet.
+// The connection is re-established automatically when it is dropped, until ctx is done.
+func SubscribeNewBlock(ctx context.Context, rpcEndpoint string) (<-chan struct{}, error) {
+	wsURL, err := getWebsocketURL(rpcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	newBlock := make(chan struct{}, 1)
+	go func() {
+		toSleep := wsReconnectInitial
+		for {
+			connected, err := subscribeNewBlock(ctx, wsURL, newBlock)
+			if ctx.Err() != nil {
+				logger.L.Info("New block subscription stopped")
+				return
+			}
+			if connected {
+				toSleep = wsReconnectInitial
+			}
+			logger.L.Warnw("New block subscription dropped, reconnecting", "error", err, "retry_after", toSleep)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(toSleep):
+			}
+			toSleep = toSleep * 2
+			if toSleep > wsReconnectMax {
+				toSleep = wsReconnectMax
+			}
+		}
+	}()
+	return newBlock, nil
+}
+
+// subscribeNewBlock connects and listens to new block events until the connection is dropped,
+// or ctx is done, returns whether the subscription was established
+func subscribeNewBlock(ctx context.Context, wsURL string, newBlock chan<- struct{}) (bool, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot connect to websocket: %w", err)
+	}
+	defer conn.Close()
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			// interrupts the blocking read below
+			conn.Close()
+		case <-stopped:
+		}
+	}()
+	conn.SetPingHandler(func(appData string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(time.Second))
+	})
+	err = conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      0,
+		"method":  "subscribe",
+		"params":  map[string]string{"query": newBlockQuery},
+	})
+	if err != nil {
+		return false, fmt.Errorf("cannot send subscribe request: %w", err)
+	}
+	subscribed := false
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+		msg := wsMessage{}
+		err = conn.ReadJSON(&msg)
+		if err != nil {
+			return subscribed, fmt.Errorf("cannot read from websocket: %w", err)
+		}
+		if msg.Error != nil {
+			return subscribed, fmt.Errorf("subscription error: %s %s", msg.Error.Message, msg.Error.Data)
+		}
+		if !subscribed {
+			// the first response is the empty result of the subscribe request
+			subscribed = true
+			logger.L.Infow("Subscribed to new block events", "url", wsURL)
+			if isEmptyResult(msg.Result) {
+				continue
+			}
+		}
+		select {
+		case newBlock <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func isEmptyResult(result json.RawMessage) bool {
+	s := strings.TrimSpace(string(result))
+	return s == "" || s == "{}" || s == "null"
+}
@@ -0,0 +1,69 @@
+package poller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWebsocketURL(t *testing.T) {
+	table := []struct {
+		endpoint string
+		expected string
+	}{
+		{"http://localhost:26657", "ws://localhost:26657/websocket"},
+		{"https://rpc.example.com/", "wss://rpc.example.com/websocket"},
+		{"https://example.com/rpc", "wss://example.com/rpc/websocket"},
+	}
+	for _, v := range table {
+		url, err := getWebsocketURL(v.endpoint)
+		require.NoError(t, err)
+		require.Equal(t, v.expected, url)
+	}
+	_, err := getWebsocketURL("localhost:26657")
+	require.Error(t, err)
+}
+
+func TestSubscribeNewBlock(t *testing.T) {
+	wsReconnectInitial = 10 * time.Millisecond
+	var connCount int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/websocket", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		atomic.AddInt32(&connCount, 1)
+
+		req := map[string]interface{}{}
+		require.NoError(t, conn.ReadJSON(&req))
+		require.Equal(t, "subscribe", req["method"])
+		require.Equal(t, newBlockQuery, req["params"].(map[string]interface{})["query"])
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":0,"result":{}}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":0,"result":{"query":"tm.event='NewBlock'","data":{"type":"tendermint/event/NewBlock","value":{}}}}`)))
+		// drop the connection after a while, the client should reconnect
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newBlock, err := SubscribeNewBlock(ctx, server.URL)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-newBlock:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout waiting for new block signal")
+		}
+		// wait until the connection is dropped
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.GreaterOrEqual(t, atomic.LoadInt32(&connCount), int32(2))
+}
@@ -0,0 +1,65 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestAuthzGrants(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	expiration := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	grants := []AuthzGrant{
+		{
+			AuthzGrantKey:     AuthzGrantKey{Granter: ADDR_01_LIKE, Grantee: ADDR_02_LIKE, MsgTypeUrl: "/cosmos.bank.v1beta1.MsgSend"},
+			AuthorizationType: "/cosmos.bank.v1beta1.SendAuthorization",
+			Authorization:     json.RawMessage(`{"@type":"/cosmos.bank.v1beta1.SendAuthorization","spend_limit":[{"denom":"nanolike","amount":"100"}]}`),
+			SpendLimit:        json.RawMessage(`[{"denom":"nanolike","amount":"100"}]`),
+			Expiration:        &expiration,
+			Height:            1,
+			TxHash:            "TX1",
+			Timestamp:         timestamp,
+		},
+		{
+			AuthzGrantKey:     AuthzGrantKey{Granter: ADDR_01_LIKE, Grantee: ADDR_03_LIKE, MsgTypeUrl: "/cosmos.gov.v1beta1.MsgVote"},
+			AuthorizationType: "/cosmos.authz.v1beta1.GenericAuthorization",
+			Authorization:     json.RawMessage(`{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.gov.v1beta1.MsgVote"}`),
+			Height:            2,
+			TxHash:            "TX2",
+			Timestamp:         timestamp,
+		},
+	}
+	InsertTestData(DBTestData{AuthzGrants: grants})
+
+	req := httptest.NewRequest("GET", rest.AUTHZ_ENDPOINT+"/grants?granter="+ADDR_01_COSMOS, nil)
+	httpRes, body := request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var res QueryAuthzGrantsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &res), body)
+	require.Len(t, res.Grants, 2)
+	require.Equal(t, grants[0].AuthzGrantKey, res.Grants[0].AuthzGrantKey)
+	require.JSONEq(t, string(grants[0].SpendLimit), string(res.Grants[0].SpendLimit))
+	require.Equal(t, expiration, res.Grants[0].Expiration.UTC())
+	require.Equal(t, grants[1].AuthzGrantKey, res.Grants[1].AuthzGrantKey)
+	require.JSONEq(t, string(grants[1].Authorization), string(res.Grants[1].Authorization))
+	require.Nil(t, res.Grants[1].Expiration)
+
+	req = httptest.NewRequest("GET", rest.AUTHZ_ENDPOINT+"/grants?grantee="+ADDR_03_LIKE+"&msg_type_url=/cosmos.gov.v1beta1.MsgVote", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	require.NoError(t, json.Unmarshal([]byte(body), &res), body)
+	require.Len(t, res.Grants, 1)
+	require.Equal(t, grants[1].AuthzGrantKey, res.Grants[0].AuthzGrantKey)
+
+	req = httptest.NewRequest("GET", rest.AUTHZ_ENDPOINT+"/grants", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 400, httpRes.StatusCode, body)
+}
@@ -0,0 +1,64 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestTokenTransfers(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	transfers := []TokenTransfer{
+		{Sender: ADDR_01_LIKE, Receiver: ADDR_02_LIKE, Denom: "nanolike", Amount: "100000000000000000000000", Height: 1, TxHash: "TX1", Timestamp: timestamp},
+		{Sender: ADDR_02_LIKE, Receiver: ADDR_03_LIKE, Denom: "uatom", Amount: "5", Height: 2, TxHash: "TX2", Timestamp: timestamp.Add(time.Hour)},
+		{Sender: ADDR_03_LIKE, Receiver: ADDR_01_LIKE, Denom: "nanolike", Amount: "1", Height: 3, TxHash: "TX3", Timestamp: timestamp.Add(2 * time.Hour)},
+	}
+	InsertTestData(DBTestData{TokenTransfers: transfers})
+
+	table := []struct {
+		name     string
+		query    string
+		expected []TokenTransfer
+	}{
+		{"all", "", transfers},
+		{"address", "?address=" + ADDR_02_COSMOS, transfers[:2]},
+		{"sender", "?sender=" + ADDR_02_LIKE, transfers[1:2]},
+		{"receiver", "?receiver=" + ADDR_01_LIKE, transfers[2:]},
+		{"denom", "?denom=nanolike", []TokenTransfer{transfers[0], transfers[2]}},
+		{"time", fmt.Sprintf("?after=%d&before=%d", timestamp.Unix(), timestamp.Add(2*time.Hour).Unix()), transfers[1:2]},
+		{"reverse", "?pagination.reverse=true&pagination.limit=2", []TokenTransfer{transfers[2], transfers[1]}},
+	}
+	for _, test := range table {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", rest.BANK_ENDPOINT+"/transfers"+test.query, nil)
+			httpRes, body := request(req)
+			require.Equal(t, 200, httpRes.StatusCode, body)
+			var res QueryTokenTransfersResponse
+			require.NoError(t, json.Unmarshal([]byte(body), &res), body)
+			require.Equal(t, test.expected, res.Transfers)
+			require.Equal(t, len(test.expected), res.Pagination.Count)
+		})
+	}
+
+	req := httptest.NewRequest("GET", rest.BANK_ENDPOINT+"/transfers?pagination.limit=1", nil)
+	httpRes, body := request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var res QueryTokenTransfersResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &res), body)
+	require.Equal(t, transfers[:1], res.Transfers)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("%s/transfers?pagination.limit=1&pagination.key=%d", rest.BANK_ENDPOINT, res.Pagination.NextKey), nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	require.NoError(t, json.Unmarshal([]byte(body), &res), body)
+	require.Equal(t, transfers[1:2], res.Transfers)
+}
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+)
+
+func handleBlocks(c *gin.Context) {
+	var q db.QueryBlocksRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	p, err := getPagination(c)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetBlocks(conn, q, p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+func handleBlock(c *gin.Context) {
+	height, err := strconv.ParseInt(c.Param("height"), 10, 64)
+	if err != nil || height <= 0 {
+		c.AbortWithStatusJSON(400, gin.H{"error": "invalid height"})
+		return
+	}
+
+	conn := getConn(c)
+	block, err := db.GetBlockByHeight(conn, height)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			c.AbortWithStatusJSON(404, gin.H{"error": "block not found"})
+			return
+		}
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, block)
+}
+
+func handleBlockAtTime(c *gin.Context) {
+	var q db.QueryBlockAtTimeRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn := getConn(c)
+	block, err := db.GetBlockAtTime(conn, time.Unix(q.Time, 0))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			c.AbortWithStatusJSON(404, gin.H{"error": "no block before the given time"})
+			return
+		}
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, block)
+}
@@ -0,0 +1,71 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestBlocks(t *testing.T) {
+	defer CleanupTestData(Conn)
+	blocks := []Block{
+		{
+			Height:          2000,
+			Hash:            "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+			ProposerAddress: "1111111111111111111111111111111111111111",
+			Time:            time.Unix(1680000000, 0).UTC(),
+			TxCount:         1,
+		},
+		{
+			Height:          2001,
+			Hash:            "BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB",
+			ProposerAddress: "2222222222222222222222222222222222222222",
+			Time:            time.Unix(1680000006, 0).UTC(),
+			TxCount:         0,
+		},
+	}
+	InsertTestData(DBTestData{Blocks: blocks})
+
+	req := httptest.NewRequest("GET", rest.BLOCKS_ENDPOINT+"?empty=true", nil)
+	httpRes, body := request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var res QueryBlocksResponse
+	err := json.Unmarshal([]byte(body), &res)
+	require.NoError(t, err, body)
+	require.Equal(t, []Block{blocks[1]}, res.Blocks)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("%s/%d", rest.BLOCKS_ENDPOINT, 2000), nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var block Block
+	err = json.Unmarshal([]byte(body), &block)
+	require.NoError(t, err, body)
+	require.Equal(t, blocks[0], block)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("%s/%d", rest.BLOCKS_ENDPOINT, 1999), nil)
+	httpRes, body = request(req)
+	require.Equal(t, 404, httpRes.StatusCode, body)
+
+	req = httptest.NewRequest("GET", rest.BLOCKS_ENDPOINT+"/abc", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 400, httpRes.StatusCode, body)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("%s?time=%d", rest.HEIGHT_AT_TIME_ENDPOINT, 1680000005), nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	err = json.Unmarshal([]byte(body), &block)
+	require.NoError(t, err, body)
+	require.Equal(t, int64(2000), block.Height)
+
+	req = httptest.NewRequest("GET", rest.HEIGHT_AT_TIME_ENDPOINT, nil)
+	httpRes, body = request(req)
+	require.Equal(t, 400, httpRes.StatusCode, body)
+}
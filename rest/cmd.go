@@ -6,8 +6,11 @@ import (
 
 const (
 	CmdLcdEndpoint  = "lcd-endpoint"
+	CmdRpcEndpoint  = "rpc-endpoint"
+	CmdGrpcEndpoint = "grpc-endpoint"
 	CmdListenAddr   = "listen-addr"
 	CmdApiAddresses = "api-address"
+	CmdAdminToken   = "admin-token"
 
 	DefaultLcdEndpoint = "http://localhost:1317"
 	DefaultListenAddr  = "localhost:8997"
@@ -18,7 +21,10 @@ var (
 )
 
 func ConfigCmd(cmd *cobra.Command) {
-	cmd.PersistentFlags().String(CmdLcdEndpoint, DefaultLcdEndpoint, "LikeCoin chain lite client RPC endpoint")
+	cmd.PersistentFlags().StringSlice(CmdLcdEndpoint, []string{DefaultLcdEndpoint}, "LikeCoin chain lite client RPC endpoints, requests are routed to the healthy ones")
+	cmd.PersistentFlags().String(CmdRpcEndpoint, "", "LikeCoin chain Tendermint RPC endpoint, for fetching whole blocks with their transaction results")
+	cmd.PersistentFlags().String(CmdGrpcEndpoint, "", "LikeCoin chain gRPC endpoint (e.g. localhost:9090)")
 	cmd.PersistentFlags().String(CmdListenAddr, DefaultListenAddr, "HTTP API serving address")
 	cmd.PersistentFlags().StringSlice(CmdApiAddresses, DefaultApiAddresses, "Default API sender addresses for NFT ranking and stats")
+	cmd.PersistentFlags().String(CmdAdminToken, "", "Bearer token for the admin API under /indexer/admin, the admin API is disabled if empty")
 }
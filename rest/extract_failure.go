@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
+)
+
+func handleExtractFailures(c *gin.Context) {
+	var q db.QueryExtractFailuresRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	p, err := getPagination(c)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetExtractFailures(conn, q, p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+func handleRetryExtractFailures(c *gin.Context) {
+	var q db.RetryExtractFailuresRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&q); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	conn := getConn(c)
+	res, err := extractor.RetryExtractFailures(conn, q.Ids)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
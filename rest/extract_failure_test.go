@@ -0,0 +1,95 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestExtractFailures(t *testing.T) {
+	defer CleanupTestData(Conn)
+	InsertTestData(DBTestData{
+		ExtractFailures: []ExtractFailure{
+			{
+				Height:    1000,
+				TxHash:    "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+				MsgIndex:  0,
+				EventType: "likechain.likenft.v1.EventMintNFT",
+				Processor: "extractor.mintNft",
+				Error:     "some error",
+			},
+			{
+				Height:    1001,
+				TxHash:    "BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB",
+				MsgIndex:  1,
+				EventType: "cosmos.nft.v1beta1.EventSend",
+				Processor: "extractor.sendNft",
+				Error:     "another error",
+			},
+		},
+	})
+
+	endpoint := rest.ADMIN_ENDPOINT + "/extract-failures"
+
+	req := httptest.NewRequest("GET", endpoint, nil)
+	httpRes, body := request(req)
+	require.Equal(t, 401, httpRes.StatusCode, body)
+
+	req = httptest.NewRequest("GET", endpoint, nil)
+	req.Header.Set("Authorization", "Bearer wrong-token")
+	httpRes, body = request(req)
+	require.Equal(t, 401, httpRes.StatusCode, body)
+
+	req = httptest.NewRequest("GET", endpoint, nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var res QueryExtractFailuresResponse
+	err := json.Unmarshal([]byte(body), &res)
+	require.NoError(t, err, body)
+	require.Len(t, res.Failures, 2)
+	require.Equal(t, "extractor.mintNft", res.Failures[0].Processor)
+	require.Equal(t, 0, res.Failures[0].RetryCount)
+	require.Nil(t, res.Failures[0].ResolvedAt)
+
+	req = httptest.NewRequest("GET", endpoint+"?processor=extractor.sendNft", nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	res = QueryExtractFailuresResponse{}
+	err = json.Unmarshal([]byte(body), &res)
+	require.NoError(t, err, body)
+	require.Len(t, res.Failures, 1)
+	require.Equal(t, int64(1001), res.Failures[0].Height)
+
+	req = httptest.NewRequest("GET", endpoint+"?resolved=true", nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	res = QueryExtractFailuresResponse{}
+	err = json.Unmarshal([]byte(body), &res)
+	require.NoError(t, err, body)
+	require.Empty(t, res.Failures)
+
+	// the transactions are not in the txs table, so retrying fails and the error is updated
+	req = httptest.NewRequest("POST", fmt.Sprintf("%s/retry", endpoint), nil)
+	httpRes, body = request(req)
+	require.Equal(t, 401, httpRes.StatusCode, body)
+
+	req = httptest.NewRequest("POST", fmt.Sprintf("%s/retry", endpoint), nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var retryRes RetryExtractFailuresResponse
+	err = json.Unmarshal([]byte(body), &retryRes)
+	require.NoError(t, err, body)
+	require.Empty(t, retryRes.Resolved)
+	require.Len(t, retryRes.Failed, 2)
+}
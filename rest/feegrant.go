@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+)
+
+func handleFeegrants(c *gin.Context) {
+	var q db.QueryFeegrantsRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": "invalid inputs: " + err.Error()})
+		return
+	}
+	p, err := getPagination(c)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if q.Granter == "" && q.Grantee == "" {
+		c.AbortWithStatusJSON(400, gin.H{"error": "must provide either granter or grantee"})
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetFeegrants(conn, q, p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
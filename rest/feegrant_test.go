@@ -0,0 +1,65 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestFeegrants(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	feegrants := []Feegrant{
+		{
+			FeegrantKey:   FeegrantKey{Granter: ADDR_01_LIKE, Grantee: ADDR_02_LIKE},
+			AllowanceType: "/cosmos.feegrant.v1beta1.BasicAllowance",
+			Allowance:     json.RawMessage(`{"@type":"/cosmos.feegrant.v1beta1.BasicAllowance","spend_limit":[{"denom":"nanolike","amount":"1000"}],"expiration":null}`),
+			SpendLimit:    types.NewCoins(types.NewInt64Coin("nanolike", 1000)),
+			Height:        1,
+			TxHash:        "TX1",
+			Timestamp:     timestamp,
+		},
+		{
+			FeegrantKey:   FeegrantKey{Granter: ADDR_01_LIKE, Grantee: ADDR_03_LIKE},
+			AllowanceType: "/cosmos.feegrant.v1beta1.BasicAllowance",
+			Allowance:     json.RawMessage(`{"@type":"/cosmos.feegrant.v1beta1.BasicAllowance","spend_limit":[],"expiration":null}`),
+			Height:        2,
+			TxHash:        "TX2",
+			Timestamp:     timestamp,
+		},
+	}
+	InsertTestData(DBTestData{Feegrants: feegrants})
+
+	req := httptest.NewRequest("GET", rest.FEEGRANT_ENDPOINT+"/allowances?granter="+ADDR_01_COSMOS, nil)
+	httpRes, body := request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var res QueryFeegrantsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &res), body)
+	require.Len(t, res.Feegrants, 2)
+	require.Equal(t, feegrants[0].FeegrantKey, res.Feegrants[0].FeegrantKey)
+	require.Equal(t, feegrants[0].SpendLimit, res.Feegrants[0].SpendLimit)
+	require.Equal(t, feegrants[0].SpendLimit, res.Feegrants[0].SpendLimitLeft)
+	require.Empty(t, res.Feegrants[0].FeesSpent)
+	require.Equal(t, feegrants[1].FeegrantKey, res.Feegrants[1].FeegrantKey)
+	require.Nil(t, res.Feegrants[1].SpendLimit)
+	require.Nil(t, res.Feegrants[1].SpendLimitLeft)
+
+	req = httptest.NewRequest("GET", rest.FEEGRANT_ENDPOINT+"/allowances?grantee="+ADDR_03_LIKE, nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	require.NoError(t, json.Unmarshal([]byte(body), &res), body)
+	require.Len(t, res.Feegrants, 1)
+	require.Equal(t, feegrants[1].FeegrantKey, res.Feegrants[0].FeegrantKey)
+
+	req = httptest.NewRequest("GET", rest.FEEGRANT_ENDPOINT+"/allowances", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 400, httpRes.StatusCode, body)
+}
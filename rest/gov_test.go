@@ -0,0 +1,65 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+)
+
+func TestGov(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	proposals := []GovProposal{
+		{ProposalId: 1, Proposer: ADDR_01_LIKE, ProposalTypes: []string{"/cosmos.gov.v1beta1.TextProposal"}, Title: "Proposal 1", Content: json.RawMessage(`{}`), Height: 1, TxHash: "TX1", Timestamp: timestamp},
+		{ProposalId: 2, Proposer: ADDR_02_LIKE, ProposalTypes: []string{"/cosmos.gov.v1beta1.TextProposal"}, Title: "Proposal 2", Content: json.RawMessage(`{}`), Height: 2, TxHash: "TX2", Timestamp: timestamp},
+	}
+	deposits := []GovDeposit{
+		{ProposalId: 1, Depositor: ADDR_01_LIKE, Denom: "nanolike", Amount: "100", Height: 1, TxHash: "TX1", Timestamp: timestamp},
+		{ProposalId: 2, Depositor: ADDR_01_LIKE, Denom: "nanolike", Amount: "200", Height: 3, TxHash: "TX3", Timestamp: timestamp},
+	}
+	votes := []GovVote{
+		{ProposalId: 1, Voter: ADDR_01_LIKE, Options: []GovVoteOption{{Option: "VOTE_OPTION_YES", Weight: "1.000000000000000000"}}, Height: 4, TxHash: "TX4", Timestamp: timestamp},
+		{ProposalId: 1, Voter: ADDR_02_LIKE, Options: []GovVoteOption{{Option: "VOTE_OPTION_NO", Weight: "1.000000000000000000"}}, Height: 5, TxHash: "TX5", Timestamp: timestamp},
+		{ProposalId: 2, Voter: ADDR_02_LIKE, Options: []GovVoteOption{{Option: "VOTE_OPTION_YES", Weight: "1.000000000000000000"}}, Height: 6, TxHash: "TX6", Timestamp: timestamp},
+	}
+	InsertTestData(DBTestData{GovProposals: proposals, GovDeposits: deposits, GovVotes: votes})
+
+	req := httptest.NewRequest("GET", rest.GOV_ENDPOINT+"/proposals?proposer="+ADDR_02_COSMOS, nil)
+	httpRes, body := request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var proposalsRes QueryGovProposalsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &proposalsRes), body)
+	require.Len(t, proposalsRes.Proposals, 1)
+	require.Equal(t, uint64(2), proposalsRes.Proposals[0].ProposalId)
+
+	req = httptest.NewRequest("GET", rest.GOV_ENDPOINT+"/deposits?proposal_id=2", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var depositsRes QueryGovDepositsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &depositsRes), body)
+	require.Equal(t, deposits[1:], depositsRes.Deposits)
+
+	req = httptest.NewRequest("GET", rest.GOV_ENDPOINT+"/votes?voter="+ADDR_02_COSMOS+"&option=yes", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var votesRes QueryGovVotesResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &votesRes), body)
+	require.Equal(t, votes[2:], votesRes.Votes)
+
+	req = httptest.NewRequest("GET", rest.GOV_ENDPOINT+"/votes?proposal_id=1&option=VOTE_OPTION_NO", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	require.NoError(t, json.Unmarshal([]byte(body), &votesRes), body)
+	require.Equal(t, votes[1:2], votesRes.Votes)
+
+	req = httptest.NewRequest("GET", rest.GOV_ENDPOINT+"/votes?proposal_id=abc", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 400, httpRes.StatusCode, body)
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+)
+
+func handleIbcTransfers(c *gin.Context) {
+	var q db.QueryIbcTransfersRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": "invalid inputs: " + err.Error()})
+		return
+	}
+	switch q.Direction {
+	case "", db.IBC_TRANSFER_OUTGOING, db.IBC_TRANSFER_INCOMING:
+	default:
+		c.AbortWithStatusJSON(400, gin.H{"error": "direction must be either outgoing or incoming"})
+		return
+	}
+	p, err := getPagination(c)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetIbcTransfers(conn, q, p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+func handleIbcDenomTraces(c *gin.Context) {
+	var q db.QueryIbcDenomTracesRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": "invalid inputs: " + err.Error()})
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetIbcDenomTraces(conn, q)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
@@ -0,0 +1,60 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+func TestIbc(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	osmoAddr, err := utils.ConvertAddressPrefix(ADDR_01_LIKE, "osmo")
+	require.NoError(t, err)
+	atomDenom := "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	transfers := []IbcTransfer{
+		{
+			IbcPacket: IbcPacket{Sequence: 1, SrcPort: "transfer", SrcChannel: "channel-0", DstPort: "transfer", DstChannel: "channel-141"},
+			Direction: IBC_TRANSFER_OUTGOING, Status: IBC_TRANSFER_PENDING, Sender: ADDR_02_LIKE, Receiver: osmoAddr,
+			Denom: "nanolike", BaseDenom: "nanolike", Amount: "100", Height: 1, TxHash: "TX1", Timestamp: timestamp,
+		},
+		{
+			IbcPacket: IbcPacket{Sequence: 5, SrcPort: "transfer", SrcChannel: "channel-141", DstPort: "transfer", DstChannel: "channel-0"},
+			Direction: IBC_TRANSFER_INCOMING, Status: IBC_TRANSFER_COMPLETED, Sender: osmoAddr, Receiver: ADDR_03_LIKE,
+			Denom: atomDenom, DenomPath: "transfer/channel-0", BaseDenom: "uatom", Amount: "40", Height: 2, TxHash: "TX2", Timestamp: timestamp,
+		},
+	}
+	InsertTestData(DBTestData{IbcTransfers: transfers})
+
+	req := httptest.NewRequest("GET", rest.IBC_ENDPOINT+"/transfers?address="+ADDR_02_COSMOS, nil)
+	httpRes, body := request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var transfersRes QueryIbcTransfersResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &transfersRes), body)
+	require.Equal(t, transfers[:1], transfersRes.Transfers)
+
+	req = httptest.NewRequest("GET", rest.IBC_ENDPOINT+"/transfers?sender="+osmoAddr+"&direction=incoming&status=completed", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	require.NoError(t, json.Unmarshal([]byte(body), &transfersRes), body)
+	require.Equal(t, transfers[1:], transfersRes.Transfers)
+
+	req = httptest.NewRequest("GET", rest.IBC_ENDPOINT+"/transfers?direction=sideways", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 400, httpRes.StatusCode, body)
+
+	req = httptest.NewRequest("GET", rest.IBC_ENDPOINT+"/denom-traces?denom="+atomDenom, nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var tracesRes QueryIbcDenomTracesResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &tracesRes), body)
+	require.Equal(t, []IbcDenomTrace{{Denom: atomDenom, Path: "transfer/channel-0", BaseDenom: "uatom"}}, tracesRes.DenomTraces)
+}
@@ -4,15 +4,35 @@ import (
 	_ "embed"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/extractor"
 )
 
 //go:embed commit_hash.txt
 var commitHash string
 
 type InfoResponse struct {
-	CommitHash string `json:"commit_hash"`
+	CommitHash      string               `json:"commit_hash"`
+	ExtractorHeight int64                `json:"extractor_height"`
+	Extractors      []db.ExtractorCursor `json:"extractors"`
 }
 
 func handleInfo(c *gin.Context) {
-	c.JSON(200, InfoResponse{CommitHash: commitHash})
+	conn := getConn(c)
+	extractorHeight, err := db.GetMetaHeight(conn, db.META_EXTRACTOR)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	cursors, err := db.GetExtractorCursors(conn, extractor.Extractors)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, InfoResponse{
+		CommitHash:      commitHash,
+		ExtractorHeight: extractorHeight,
+		Extractors:      cursors,
+	})
 }
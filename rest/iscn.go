@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/likecoin/likecoin-chain-tx-indexer/db"
 	iscntypes "github.com/likecoin/likecoin-chain/v4/x/iscn/types"
@@ -67,3 +70,69 @@ func handleIscnSearch(c *gin.Context, form db.IscnQuery) {
 
 	c.JSON(200, res)
 }
+
+// getIscnIdPrefixParam returns the ISCN ID prefix of the iscn_id query parameter, which is either the prefix or the ID of any version,
+// e.g. /iscn/records/provenance?iscn_id=iscn%3A%2F%2Flikecoin-chain%2F...%2F1
+func getIscnIdPrefixParam(c *gin.Context) (string, bool) {
+	iscnId, err := iscntypes.ParseIscnId(c.Query("iscn_id"))
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": "invalid ISCN ID prefix: " + err.Error()})
+		return "", false
+	}
+	return iscnId.Prefix.String(), true
+}
+
+func handleIscnProvenance(c *gin.Context) {
+	iscnIdPrefix, ok := getIscnIdPrefixParam(c)
+	if !ok {
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetIscnProvenance(conn, iscnIdPrefix)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	if len(res.Events) == 0 {
+		c.AbortWithStatusJSON(404, gin.H{"error": "ISCN not found"})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// withIscnVersions serves /iscn/records/{iscn_id_prefix}/versions, with the ISCN ID prefix (or the ID of any version) URL-encoded,
+// e.g. /iscn/records/iscn%3A%2F%2Flikecoin-chain%2F...%2F1/versions.
+// The slashes in the ID cannot be matched by a path parameter unless the router matches raw paths for all routes,
+// so the path is matched here instead, and the other paths are routed or forwarded to the lite client as usual.
+func withIscnVersions(c *gin.Context) {
+	if c.Request.Method != http.MethodGet {
+		return
+	}
+	path := c.Request.URL.Path
+	if !strings.HasPrefix(path, ISCN_ENDPOINT+"/") || !strings.HasSuffix(path, "/versions") {
+		return
+	}
+	iscnId, err := iscntypes.ParseIscnId(strings.TrimSuffix(strings.TrimPrefix(path, ISCN_ENDPOINT+"/"), "/versions"))
+	if err != nil {
+		return
+	}
+	handleIscnVersions(c, iscnId.Prefix.String())
+	c.Abort()
+}
+
+func handleIscnVersions(c *gin.Context, iscnIdPrefix string) {
+	conn := getConn(c)
+	res, err := db.GetIscnVersions(conn, iscnIdPrefix)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	if len(res.Versions) == 0 {
+		c.AbortWithStatusJSON(404, gin.H{"error": "ISCN not found"})
+		return
+	}
+
+	c.JSON(200, res)
+}
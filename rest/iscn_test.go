@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -150,3 +152,83 @@ func TestISCNCombine(t *testing.T) {
 		})
 	}
 }
+
+func TestIscnProvenance(t *testing.T) {
+	defer CleanupTestData(Conn)
+	prefix := "iscn://testing/PROVENANCE"
+	events := []db.IscnEvent{
+		{
+			Action: db.ISCN_ACTION_CREATE, IscnId: prefix + "/1", IscnIdPrefix: prefix, Version: 1,
+			Sender: ADDR_01_LIKE, NewOwner: ADDR_01_LIKE, Height: 1, TxHash: "TX1", Timestamp: time.Unix(1680000000, 0).UTC(),
+		},
+		{
+			Action: db.ISCN_ACTION_CHANGE_OWNERSHIP, IscnId: prefix + "/1", IscnIdPrefix: prefix, Version: 1,
+			Sender: ADDR_01_LIKE, PrevOwner: ADDR_01_LIKE, NewOwner: ADDR_02_LIKE, Height: 2, TxHash: "TX2", Timestamp: time.Unix(1680000001, 0).UTC(),
+		},
+	}
+	InsertTestData(DBTestData{IscnEvents: events})
+
+	// both the prefix and the ID of any version are accepted
+	for _, id := range []string{prefix, prefix + "/1"} {
+		req := httptest.NewRequest("GET", ISCN_ENDPOINT+"/provenance?iscn_id="+url.QueryEscape(id), nil)
+		res, body := request(req)
+		require.Equal(t, 200, res.StatusCode, body)
+		var provenance db.QueryIscnProvenanceResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &provenance), body)
+		require.Equal(t, prefix, provenance.IscnIdPrefix)
+		require.Equal(t, ADDR_02_LIKE, provenance.Owner)
+		require.Equal(t, events, provenance.Events)
+	}
+
+	req := httptest.NewRequest("GET", ISCN_ENDPOINT+"/provenance?iscn_id="+url.QueryEscape("iscn://testing/NOTFOUND"), nil)
+	res, body := request(req)
+	require.Equal(t, 404, res.StatusCode, body)
+
+	req = httptest.NewRequest("GET", ISCN_ENDPOINT+"/provenance?iscn_id=invalid", nil)
+	res, body = request(req)
+	require.Equal(t, 400, res.StatusCode, body)
+}
+
+func TestIscnVersions(t *testing.T) {
+	defer CleanupTestData(Conn)
+	prefix := "iscn://testing/versions"
+	iscns := []db.IscnInsert{
+		{
+			Iscn:  prefix + "/1",
+			Owner: ADDR_01_LIKE,
+			Data:  []byte(`{"contentMetadata":{"name":"v1"},"stakeholders":[],"contentFingerprints":["hash://a"]}`),
+		},
+		{
+			Iscn:  prefix + "/2",
+			Owner: ADDR_01_LIKE,
+			Data:  []byte(`{"contentMetadata":{"name":"v2"},"stakeholders":[],"contentFingerprints":["hash://a"]}`),
+		},
+	}
+	// more than one event of the same version does not return the version twice
+	iscnEvents := []db.IscnEvent{
+		{Action: db.ISCN_ACTION_CREATE, IscnId: prefix + "/1", IscnIdPrefix: prefix, Version: 1, NewOwner: ADDR_01_LIKE, Height: 1, TxHash: "VERSION1"},
+		{Action: db.ISCN_ACTION_UPDATE, IscnId: prefix + "/1", IscnIdPrefix: prefix, Version: 1, NewOwner: ADDR_01_LIKE, Height: 2, TxHash: "VERSION1DUP"},
+		{Action: db.ISCN_ACTION_UPDATE, IscnId: prefix + "/2", IscnIdPrefix: prefix, Version: 2, NewOwner: ADDR_01_LIKE, Height: 3, TxHash: "VERSION2"},
+	}
+	InsertTestData(DBTestData{Iscns: iscns, IscnEvents: iscnEvents})
+
+	req := httptest.NewRequest("GET", ISCN_ENDPOINT+"/"+url.PathEscape(prefix+"/2")+"/versions", nil)
+	res, body := request(req)
+	require.Equal(t, 200, res.StatusCode, body)
+	var versions db.QueryIscnVersionsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &versions), body)
+	require.Equal(t, prefix, versions.IscnIdPrefix)
+	require.Equal(t, 2, versions.LatestVersion)
+	require.Len(t, versions.Versions, 2)
+	require.Nil(t, versions.Versions[0].Diff)
+	require.Equal(t, int64(1), versions.Versions[0].Height)
+	require.Equal(t, "VERSION1", versions.Versions[0].TxHash)
+	require.Equal(t, "VERSION2", versions.Versions[1].TxHash)
+	require.NotNil(t, versions.Versions[1].Diff)
+	require.JSONEq(t, `"v1"`, string(versions.Versions[1].Diff.ContentMetadata.Changed["name"].From))
+	require.JSONEq(t, `"v2"`, string(versions.Versions[1].Diff.ContentMetadata.Changed["name"].To))
+
+	req = httptest.NewRequest("GET", ISCN_ENDPOINT+"/"+url.PathEscape("iscn://testing/notfound")+"/versions", nil)
+	res, body = request(req)
+	require.Equal(t, 404, res.StatusCode, body)
+}
@@ -68,6 +68,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_01_LIKE,
 			Price:      100000000000,
 			Expiration: expiration,
+			Height:     1,
+			TxHash:     "MARKET1",
 		},
 		{
 			Type:       "listing",
@@ -76,6 +78,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_02_LIKE,
 			Price:      100000000001,
 			Expiration: expiration.Add(1 * time.Second),
+			Height:     2,
+			TxHash:     "MARKET2",
 		},
 		{
 			Type:       "listing",
@@ -84,6 +88,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_03_LIKE,
 			Price:      100000000002,
 			Expiration: expiration.Add(2 * time.Second),
+			Height:     3,
+			TxHash:     "MARKET3",
 		},
 		{
 			Type:       "listing",
@@ -92,6 +98,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_04_LIKE,
 			Price:      100000000003,
 			Expiration: expiration.Add(-10000 * time.Second),
+			Height:     4,
+			TxHash:     "MARKET4",
 		},
 		{
 			Type:       "offer",
@@ -100,6 +108,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_01_LIKE,
 			Price:      100000000004,
 			Expiration: expiration,
+			Height:     5,
+			TxHash:     "MARKET5",
 		},
 		{
 			Type:       "offer",
@@ -108,6 +118,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_02_LIKE,
 			Price:      100000000005,
 			Expiration: expiration.Add(-10000 * time.Second),
+			Height:     6,
+			TxHash:     "MARKET6",
 		},
 		{
 			Type:       "offer",
@@ -116,6 +128,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_03_LIKE,
 			Price:      100000000006,
 			Expiration: expiration.Add(1 * time.Second),
+			Height:     7,
+			TxHash:     "MARKET7",
 		},
 		{
 			Type:       "offer",
@@ -124,6 +138,8 @@ func TestMarketplace(t *testing.T) {
 			Creator:    ADDR_04_LIKE,
 			Price:      100000000007,
 			Expiration: expiration.Add(2 * time.Second),
+			Height:     8,
+			TxHash:     "MARKET8",
 		},
 	}
 	blockTime := expiration.Add(-100 * time.Second)
@@ -224,6 +240,18 @@ func TestMarketplace(t *testing.T) {
 			length: 3,
 			items:  []db.NftMarketplaceItem{marketplaceItems[2], marketplaceItems[1], marketplaceItems[0]},
 		},
+		{
+			name:   "min height",
+			query:  "type=listing&min_height=2",
+			length: 2,
+			items:  []db.NftMarketplaceItem{marketplaceItems[1], marketplaceItems[2]},
+		},
+		{
+			name:   "max height",
+			query:  "type=offer&max_height=7",
+			length: 2,
+			items:  []db.NftMarketplaceItem{marketplaceItems[4], marketplaceItems[6]},
+		},
 		{
 			name:          "expand",
 			query:         "type=listing&expand=true",
@@ -252,6 +280,8 @@ func TestMarketplace(t *testing.T) {
 				require.Equal(t, item.Creator, res.Items[i].Creator)
 				require.Equal(t, item.Price, res.Items[i].Price)
 				require.Equal(t, item.Expiration, res.Items[i].Expiration)
+				require.Equal(t, item.Height, res.Items[i].Height)
+				require.Equal(t, item.TxHash, res.Items[i].TxHash)
 			}
 			for i, classMetadata := range v.classMetadata {
 				if classMetadata != nil {
@@ -15,9 +15,11 @@ import (
 
 var router *gin.Engine
 
+const testAdminToken = "test-admin-token"
+
 func TestMain(m *testing.M) {
 	SetupDbAndRunTest(m, func(pool *pgxpool.Pool) {
-		router = GetRouter(pool, nil)
+		router = GetRouter(pool, nil, testAdminToken)
 	})
 }
 
@@ -1,13 +1,19 @@
 package rest
 
 import (
-	"net/http/httputil"
-	"net/url"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/endpoints"
 	"github.com/likecoin/likecoin-chain-tx-indexer/logger"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
 )
 
 const STARGATE_ENDPOINT = "/cosmos/tx/v1beta1/txs"
@@ -16,25 +22,58 @@ const LATEST_HEIGHT_ENDPOINT = "/indexer/height/latest"
 const NFT_ENDPOINT = "/likechain/likenft/v1"
 const ANALYSIS_ENDPOINT = "/statistics"
 const INFO_ENDPOINT = "/indexer/info"
+const BLOCKS_ENDPOINT = "/indexer/blocks"
+const HEIGHT_AT_TIME_ENDPOINT = "/indexer/height/at-time"
+const ADMIN_ENDPOINT = "/indexer/admin"
+const BANK_ENDPOINT = "/bank"
+const STAKING_ENDPOINT = "/staking"
+const GOV_ENDPOINT = "/gov"
+const IBC_ENDPOINT = "/ibc"
+const AUTHZ_ENDPOINT = "/authz"
+const FEEGRANT_ENDPOINT = "/feegrant"
 
-func Run(pool *pgxpool.Pool, listenAddr string, lcdEndpoint string, defaultApiAddresses []string) {
-	lcdURL, err := url.Parse(lcdEndpoint)
-	if err != nil {
-		logger.L.Panicw("Cannot parse lcd URL", "lcd_endpoint", lcdEndpoint, "error", err)
-	}
-	proxy := httputil.NewSingleHostReverseProxy(lcdURL)
+const lcdHealthCheckInterval = 30 * time.Second
+
+var shutdownTimeout = time.Duration(utils.EnvInt("HTTP_SHUTDOWN_TIMEOUT", 30)) * time.Second
+
+// Run serves the HTTP API until ctx is done, then stops accepting new connections and waits for in-flight requests to finish
+func Run(ctx context.Context, pool *pgxpool.Pool, listenAddr string, lcd *endpoints.Pool, defaultApiAddresses []string, adminToken string) error {
+	lcd.StartHealthCheck(ctx, lcdHealthCheckInterval)
+	proxy := lcd.ReverseProxy()
 	proxyHandler := func(c *gin.Context) {
 		proxy.ServeHTTP(c.Writer, c.Request)
 	}
 
-	router := GetRouter(pool, defaultApiAddresses)
+	router := GetRouter(pool, defaultApiAddresses, adminToken)
 	router.NoRoute(proxyHandler)
-	_ = router.Run(listenAddr)
+	server := &http.Server{
+		Addr:    listenAddr,
+		Handler: router,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+	logger.L.Infow("Shutting down HTTP server", "timeout", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		return fmt.Errorf("cannot shutdown HTTP server gracefully: %w", err)
+	}
+	logger.L.Info("HTTP server stopped")
+	return nil
 }
 
-func GetRouter(pool *pgxpool.Pool, defaultApiAddresses []string) *gin.Engine {
+// GetRouter returns the router of the indexer API, the admin API is disabled if adminToken is empty
+func GetRouter(pool *pgxpool.Pool, defaultApiAddresses []string, adminToken string) *gin.Engine {
 	router := gin.New()
-	router.Use(withConn(pool), withDefaultApiAddresses(defaultApiAddresses))
+	router.Use(withConn(pool), withDefaultApiAddresses(defaultApiAddresses), withIscnVersions)
 	nft := router.Group(NFT_ENDPOINT)
 	{
 		nft.GET("/class", handleNftClass)
@@ -61,10 +100,47 @@ func GetRouter(pool *pgxpool.Pool, defaultApiAddresses []string) *gin.Engine {
 		analysis.GET("/nft/owner-count", handleNftOwnerCount)
 		analysis.GET("/nft/owners", handleNftOwnerList)
 	}
+	bank := router.Group(BANK_ENDPOINT)
+	{
+		bank.GET("/transfers", handleTokenTransfers)
+	}
+	staking := router.Group(STAKING_ENDPOINT)
+	{
+		staking.GET("/events", handleStakingEvents)
+		staking.GET("/delegations", handleDelegations)
+	}
+	gov := router.Group(GOV_ENDPOINT)
+	{
+		gov.GET("/proposals", handleGovProposals)
+		gov.GET("/deposits", handleGovDeposits)
+		gov.GET("/votes", handleGovVotes)
+	}
+	ibc := router.Group(IBC_ENDPOINT)
+	{
+		ibc.GET("/transfers", handleIbcTransfers)
+		ibc.GET("/denom-traces", handleIbcDenomTraces)
+	}
+	authz := router.Group(AUTHZ_ENDPOINT)
+	{
+		authz.GET("/grants", handleAuthzGrants)
+	}
+	feegrant := router.Group(FEEGRANT_ENDPOINT)
+	{
+		feegrant.GET("/allowances", handleFeegrants)
+	}
 	router.GET(ISCN_ENDPOINT, handleIscn)
+	router.GET(ISCN_ENDPOINT+"/provenance", handleIscnProvenance)
 	router.GET(STARGATE_ENDPOINT, handleStargateTxsSearch)
 	router.GET(LATEST_HEIGHT_ENDPOINT, handleLatestHeight)
 	router.GET(INFO_ENDPOINT, handleInfo)
+	router.GET(BLOCKS_ENDPOINT, handleBlocks)
+	router.GET(BLOCKS_ENDPOINT+"/:height", handleBlock)
+	router.GET(HEIGHT_AT_TIME_ENDPOINT, handleBlockAtTime)
+	admin := router.Group(ADMIN_ENDPOINT, withAdminToken(adminToken))
+	{
+		admin.GET("/extract-failures", handleExtractFailures)
+		admin.POST("/extract-failures/retry", handleRetryExtractFailures)
+	}
 	return router
 }
 
@@ -83,6 +159,21 @@ func getDefaultApiAddresses(c *gin.Context) []string {
 	return c.MustGet("default-api-addresses").([]string)
 }
 
+func withAdminToken(adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if adminToken == "" {
+			c.AbortWithStatusJSON(404, gin.H{"error": "admin API is disabled"})
+			return
+		}
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			c.AbortWithStatusJSON(401, gin.H{"error": "invalid admin token"})
+			return
+		}
+		c.Next()
+	}
+}
+
 func withConn(pool *pgxpool.Pool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		conn, err := db.AcquireFromPool(pool)
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/likecoin/likecoin-chain-tx-indexer/db"
+)
+
+func handleStakingEvents(c *gin.Context) {
+	var q db.QueryStakingEventsRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": "invalid inputs: " + err.Error()})
+		return
+	}
+	p, err := getPagination(c)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if q.Delegator == "" && q.Validator == "" {
+		c.AbortWithStatusJSON(400, gin.H{"error": "must provide either delegator or validator"})
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetStakingEvents(conn, q, p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+func handleDelegations(c *gin.Context) {
+	var q db.QueryDelegationsRequest
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": "invalid inputs: " + err.Error()})
+		return
+	}
+	p, err := getPagination(c)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	if q.Delegator == "" && q.Validator == "" {
+		c.AbortWithStatusJSON(400, gin.H{"error": "must provide either delegator or validator"})
+		return
+	}
+
+	conn := getConn(c)
+	res, err := db.GetDelegations(conn, q, p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
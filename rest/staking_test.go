@@ -0,0 +1,60 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/likecoin/likecoin-chain-tx-indexer/db"
+	"github.com/likecoin/likecoin-chain-tx-indexer/rest"
+	. "github.com/likecoin/likecoin-chain-tx-indexer/test"
+	"github.com/likecoin/likecoin-chain-tx-indexer/utils"
+)
+
+func TestStaking(t *testing.T) {
+	defer CleanupTestData(Conn)
+	timestamp := time.Unix(1680000000, 0).UTC()
+	validator, err := utils.ConvertAddressPrefix(ADDR_01_LIKE, "likevaloper")
+	require.NoError(t, err)
+	cosmosValidator, err := utils.ConvertAddressPrefix(ADDR_01_LIKE, "cosmosvaloper")
+	require.NoError(t, err)
+	events := []StakingEvent{
+		{Action: STAKING_DELEGATE, Delegator: ADDR_02_LIKE, Validator: validator, Denom: "nanolike", Amount: "100", Height: 1, TxHash: "TX1", Timestamp: timestamp},
+		{Action: STAKING_DELEGATE, Delegator: ADDR_03_LIKE, Validator: validator, Denom: "nanolike", Amount: "50", Height: 2, TxHash: "TX2", Timestamp: timestamp},
+		{Action: STAKING_WITHDRAW_REWARD, Delegator: ADDR_02_LIKE, Validator: validator, Denom: "nanolike", Amount: "1", Height: 3, TxHash: "TX3", Timestamp: timestamp},
+		{Action: STAKING_UNDELEGATE, Delegator: ADDR_03_LIKE, Validator: validator, Denom: "nanolike", Amount: "50", Height: 4, TxHash: "TX4", Timestamp: timestamp},
+	}
+	InsertTestData(DBTestData{StakingEvents: events})
+
+	req := httptest.NewRequest("GET", rest.STAKING_ENDPOINT+"/events?delegator="+ADDR_02_COSMOS, nil)
+	httpRes, body := request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var eventsRes QueryStakingEventsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &eventsRes), body)
+	require.Equal(t, []StakingEvent{events[0], events[2]}, eventsRes.Events)
+
+	req = httptest.NewRequest("GET", rest.STAKING_ENDPOINT+"/events?validator="+cosmosValidator+"&action=delegate", nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	require.NoError(t, json.Unmarshal([]byte(body), &eventsRes), body)
+	require.Equal(t, events[:2], eventsRes.Events)
+
+	// fully undelegated delegators are not listed
+	req = httptest.NewRequest("GET", rest.STAKING_ENDPOINT+"/delegations?validator="+validator, nil)
+	httpRes, body = request(req)
+	require.Equal(t, 200, httpRes.StatusCode, body)
+	var delegationsRes QueryDelegationsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &delegationsRes), body)
+	require.Equal(t, []Delegation{
+		{Delegator: ADDR_02_LIKE, Validator: validator, NetAmount: "100", Height: 1},
+	}, delegationsRes.Delegations)
+
+	for _, endpoint := range []string{"/events", "/delegations"} {
+		req = httptest.NewRequest("GET", rest.STAKING_ENDPOINT+endpoint, nil)
+		httpRes, body = request(req)
+		require.Equal(t, 400, httpRes.StatusCode, body)
+	}
+}
@@ -7,8 +7,23 @@ DELETE FROM nft;
 DELETE FROM nft_class;
 DELETE FROM nft_marketplace;
 DELETE FROM nft_income;
+DELETE FROM blocks;
+DELETE FROM extract_failures;
+DELETE FROM token_transfer;
+DELETE FROM staking_event;
+DELETE FROM staking_delegation;
+DELETE FROM gov_proposal;
+DELETE FROM gov_deposit;
+DELETE FROM gov_vote;
+DELETE FROM ibc_transfer;
+DELETE FROM ibc_denom_trace;
+DELETE FROM authz_grant;
+DELETE FROM feegrant;
+DELETE FROM feegrant_usage;
+DELETE FROM iscn_event;
+DELETE FROM meta WHERE id LIKE 'reextract\_%';
 UPDATE meta SET height = 0
-  WHERE id = 'extractor_v1'
+  WHERE id LIKE 'extractor\_%'
       OR id = 'latest_block_height'
       OR id = 'latest_block_time_epoch_ns'
 ;
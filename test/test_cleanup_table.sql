@@ -8,3 +8,17 @@ DROP TABLE nft;
 DROP TABLE nft_class;
 DROP TABLE nft_marketplace;
 DROP TABLE nft_income;
+DROP TABLE blocks;
+DROP TABLE extract_failures;
+DROP TABLE token_transfer;
+DROP TABLE staking_event;
+DROP TABLE staking_delegation;
+DROP TABLE gov_proposal;
+DROP TABLE gov_deposit;
+DROP TABLE gov_vote;
+DROP TABLE ibc_transfer;
+DROP TABLE ibc_denom_trace;
+DROP TABLE authz_grant;
+DROP TABLE feegrant;
+DROP TABLE feegrant_usage;
+DROP TABLE iscn_event;
@@ -161,6 +161,17 @@ type DBTestData struct {
 	NftEvents           []db.NftEvent
 	NftMarketplaceItems []db.NftMarketplaceItem
 	Txs                 []string
+	Blocks              []db.Block
+	ExtractFailures     []db.ExtractFailure
+	TokenTransfers      []db.TokenTransfer
+	StakingEvents       []db.StakingEvent
+	GovProposals        []db.GovProposal
+	GovDeposits         []db.GovDeposit
+	GovVotes            []db.GovVote
+	IbcTransfers        []db.IbcTransfer
+	AuthzGrants         []db.AuthzGrant
+	Feegrants           []db.Feegrant
+	IscnEvents          []db.IscnEvent
 	ExtractorHeight     int64
 	LatestBlockHeight   int64
 	LatestBlockTime     *time.Time
@@ -194,14 +205,16 @@ func InsertTestData(testData DBTestData) {
 		sql := `
 		INSERT INTO nft (
 			nft_id, class_id, owner, uri, uri_hash,
-			metadata, latest_price, price_updated_at
+			metadata, latest_price, price_updated_at, height, tx_hash,
+			burned_height
 		)
 		VALUES
-		($1, $2, $3, $4, $5, $6, $7, $8)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT DO NOTHING`
 		b.Batch.Queue(sql,
 			n.NftId, n.ClassId, n.Owner, n.Uri, n.UriHash,
-			n.Metadata, n.LatestPrice, time.Unix(0, 0).UTC(),
+			n.Metadata, n.LatestPrice, time.Unix(0, 0).UTC(), n.Height, n.TxHash,
+			n.BurnedHeight,
 		)
 	}
 	for _, e := range testData.NftEvents {
@@ -231,6 +244,39 @@ func InsertTestData(testData DBTestData) {
 		item.Expiration = item.Expiration.UTC()
 		b.InsertNFTMarketplaceItem(item)
 	}
+	for _, block := range testData.Blocks {
+		b.InsertBlock(block)
+	}
+	for _, f := range testData.ExtractFailures {
+		b.InsertExtractFailure(f)
+	}
+	for _, t := range testData.TokenTransfers {
+		b.InsertTokenTransfer(t)
+	}
+	for _, e := range testData.StakingEvents {
+		b.InsertStakingEvent(e)
+	}
+	for _, p := range testData.GovProposals {
+		b.InsertGovProposal(p)
+	}
+	for _, d := range testData.GovDeposits {
+		b.InsertGovDeposit(d)
+	}
+	for _, v := range testData.GovVotes {
+		b.InsertGovVote(v)
+	}
+	for _, t := range testData.IbcTransfers {
+		b.InsertIbcTransfer(t)
+	}
+	for _, g := range testData.AuthzGrants {
+		b.InsertAuthzGrant(g)
+	}
+	for _, g := range testData.Feegrants {
+		b.InsertFeegrant(g)
+	}
+	for _, e := range testData.IscnEvents {
+		b.InsertIscnEvent(e)
+	}
 	for i, tx := range testData.Txs {
 		height := 1
 		type Log struct {
@@ -264,7 +310,8 @@ func InsertTestData(testData DBTestData) {
 		b.UpdateMetaHeight(db.META_BLOCK_TIME_EPOCH_NS, testData.LatestBlockTime.UTC().UnixNano())
 	}
 	if testData.ExtractorHeight != 0 {
-		b.UpdateMetaHeight(db.META_EXTRACTOR, testData.ExtractorHeight)
+		// including the height of every extractor group
+		b.Batch.Queue(`UPDATE meta SET height = $1 WHERE id LIKE 'extractor\_%'`, testData.ExtractorHeight)
 	}
 	err := b.Flush()
 	if err != nil {
@@ -12,6 +12,16 @@ func ConvertAddressPrefix(addr, prefix string) (string, error) {
 	return bech32.ConvertAndEncode(prefix, bz)
 }
 
+// NormalizeAddress converts addr to the given prefix, so the same address with different prefixes is stored in the same way.
+// It returns addr as is if it is not a bech32 address, e.g. an empty string.
+func NormalizeAddress(addr, prefix string) string {
+	converted, err := ConvertAddressPrefix(addr, prefix)
+	if err != nil {
+		return addr
+	}
+	return converted
+}
+
 func ConvertAddressPrefixes(addr string, prefixes []string) []string {
 	if addr == "" {
 		return nil
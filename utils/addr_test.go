@@ -26,3 +26,14 @@ func TestConvertAddressPrefixes(t *testing.T) {
 	convertedAddrs4 := ConvertAddressPrefixes("", prefixes)
 	require.Empty(t, convertedAddrs4)
 }
+
+func TestNormalizeAddress(t *testing.T) {
+	addr1 := "like1hggde2u9lrjy9x9kqfwzzgjwkxe2y9wz9ykdd5"
+	addr2 := "cosmos1hggde2u9lrjy9x9kqfwzzgjwkxe2y9wzkc20w0"
+	require.Equal(t, addr1, NormalizeAddress(addr1, "like"))
+	require.Equal(t, addr1, NormalizeAddress(addr2, "like"))
+
+	wrongAddr := "like1hggde2u9lrjy9x9kqfwzzgjwkxe2y9wz9ykdd6" // wrong checksum
+	require.Equal(t, wrongAddr, NormalizeAddress(wrongAddr, "like"))
+	require.Equal(t, "", NormalizeAddress("", "like"))
+}